@@ -0,0 +1,212 @@
+package ekodb
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Aggregation is a fluent builder for grouped aggregates, compiled to a
+// Functions pipeline (FindAll or Query, then Group) and run on the server:
+//
+//	rows, err := client.Aggregate("orders").
+//	    Where(ekodb.NewQueryBuilder().Gte("amount", 10)).
+//	    GroupBy("status").
+//	    Sum("amount").As("total").
+//	    Count().
+//	    Having(func(row ekodb.Record) bool { return row.Float("total") > 1000 }).
+//	    Run()
+//
+// Each row holds the group-by fields and one output field per aggregate,
+// named "<op>_<field>" (such as "sum_amount" or "avg_price", and "count" for
+// Count) unless renamed with As. Having is applied client-side to the grouped
+// rows, since a Group stage cannot be filtered on the server.
+type Aggregation struct {
+	client     *Client
+	collection string
+	filter     interface{}
+	by         []string
+	functions  []GroupFunctionConfig
+	having     []func(Record) bool
+	err        error
+}
+
+// Aggregate starts an aggregation over collection.
+func (c *Client) Aggregate(collection string, opts ...RequestOption) *Aggregation {
+	c = c.withOptions(opts)
+	return &Aggregation{client: c, collection: collection}
+}
+
+// Where restricts the aggregation to records matching filter, which takes the
+// same forms as for Count
+func (a *Aggregation) Where(filter interface{}) *Aggregation {
+	a.filter = filter
+	return a
+}
+
+// GroupBy sets the fields rows are grouped by; with none, all matching
+// records form a single group
+func (a *Aggregation) GroupBy(fields ...string) *Aggregation {
+	a.by = append(a.by, fields...)
+	return a
+}
+
+// Sum adds the sum of field
+func (a *Aggregation) Sum(field string) *Aggregation {
+	return a.add(GroupFunctionSum, "sum", field)
+}
+
+// Avg adds the average of field
+func (a *Aggregation) Avg(field string) *Aggregation {
+	return a.add(GroupFunctionAverage, "avg", field)
+}
+
+// Min adds the minimum of field
+func (a *Aggregation) Min(field string) *Aggregation {
+	return a.add(GroupFunctionMin, "min", field)
+}
+
+// Max adds the maximum of field
+func (a *Aggregation) Max(field string) *Aggregation {
+	return a.add(GroupFunctionMax, "max", field)
+}
+
+// First adds the value of field in the first record of each group
+func (a *Aggregation) First(field string) *Aggregation {
+	return a.add(GroupFunctionFirst, "first", field)
+}
+
+// Last adds the value of field in the last record of each group
+func (a *Aggregation) Last(field string) *Aggregation {
+	return a.add(GroupFunctionLast, "last", field)
+}
+
+// Push adds the list of field's values in each group
+func (a *Aggregation) Push(field string) *Aggregation {
+	return a.add(GroupFunctionPush, "push", field)
+}
+
+// Count adds the number of records in each group
+func (a *Aggregation) Count() *Aggregation {
+	a.functions = append(a.functions, GroupFunctionConfig{
+		OutputField: "count",
+		Operation:   GroupFunctionCount,
+	})
+	return a
+}
+
+func (a *Aggregation) add(op GroupFunctionOp, prefix, field string) *Aggregation {
+	input := field
+	a.functions = append(a.functions, GroupFunctionConfig{
+		OutputField: prefix + "_" + field,
+		Operation:   op,
+		InputField:  &input,
+	})
+	return a
+}
+
+// As renames the output field of the aggregate added last
+func (a *Aggregation) As(name string) *Aggregation {
+	if len(a.functions) == 0 {
+		a.err = fmt.Errorf("As(%q) called before any aggregate", name)
+		return a
+	}
+	a.functions[len(a.functions)-1].OutputField = name
+	return a
+}
+
+// Having keeps only the rows for which keep returns true. Several calls must
+// all pass.
+func (a *Aggregation) Having(keep func(row Record) bool) *Aggregation {
+	a.having = append(a.having, keep)
+	return a
+}
+
+// Functions returns the pipeline the aggregation compiles to.
+func (a *Aggregation) Functions() ([]FunctionStageConfig, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	if len(a.functions) == 0 {
+		return nil, fmt.Errorf("aggregate %s: no aggregates", a.collection)
+	}
+	if err := validateQueryArg(a.filter); err != nil {
+		return nil, fmt.Errorf("aggregate %s: %w", a.collection, err)
+	}
+	seen := make(map[string]bool, len(a.functions)+len(a.by))
+	for _, f := range a.by {
+		seen[f] = true
+	}
+	for _, f := range a.functions {
+		if seen[f.OutputField] {
+			return nil, fmt.Errorf("aggregate %s: duplicate output field %q", a.collection, f.OutputField)
+		}
+		seen[f.OutputField] = true
+	}
+	by := a.by
+	if by == nil {
+		by = []string{}
+	}
+	return []FunctionStageConfig{
+		sourceStage(a.collection, a.filter),
+		StageGroup(by, a.functions),
+	}, nil
+}
+
+// Run executes the aggregation and returns the rows that pass Having.
+func (a *Aggregation) Run() ([]Record, error) {
+	functions, err := a.Functions()
+	if err != nil {
+		return nil, err
+	}
+	result, err := a.client.runTempFunction("aggregate "+a.collection, functions)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]Record, 0, len(result.Records))
+next:
+	for _, r := range result.Records {
+		row := Record(r)
+		for _, keep := range a.having {
+			if !keep(row) {
+				continue next
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// RunInto executes the aggregation and decodes the rows into dest, a pointer
+// to a slice of structs, with UnmarshalRecord:
+//
+//	var totals []struct {
+//	    Status string  `json:"status"`
+//	    Total  float64 `json:"total"`
+//	}
+//	err := client.Aggregate("orders").GroupBy("status").Sum("amount").As("total").RunInto(&totals)
+func (a *Aggregation) RunInto(dest interface{}) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("RunInto: expected a non-nil pointer to a slice, got %T", dest)
+	}
+	rows, err := a.Run()
+	if err != nil {
+		return err
+	}
+	slice := rv.Elem()
+	elem := slice.Type().Elem()
+	out := reflect.MakeSlice(slice.Type(), len(rows), len(rows))
+	for i, row := range rows {
+		target := out.Index(i)
+		if elem.Kind() == reflect.Pointer {
+			target.Set(reflect.New(elem.Elem()))
+		} else {
+			target = target.Addr()
+		}
+		if err := UnmarshalRecord(row, target.Interface()); err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+	}
+	slice.Set(out)
+	return nil
+}
@@ -0,0 +1,87 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	var saved struct {
+		Functions []map[string]interface{} `json:"functions"`
+	}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/functions": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&saved)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok", "id": "fn_1"})
+		},
+		"POST /api/functions/*": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"records": []interface{}{
+					map[string]interface{}{"status": "paid", "total": 1500.5, "count": 3},
+					map[string]interface{}{"status": "open", "total": 20, "count": 1},
+				},
+				"stats": map[string]interface{}{},
+			})
+		},
+		"DELETE /api/functions/*": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	agg := client.Aggregate("orders").
+		Where(NewQueryBuilder().Gte("amount", 10)).
+		GroupBy("status").
+		Sum("amount").As("total").
+		Count().
+		Having(func(row Record) bool { return row.Float("total") > 1000 })
+
+	rows, err := agg.Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(rows) != 1 || rows[0].String("status") != "paid" || rows[0].Int("count") != 3 {
+		t.Errorf("unexpected rows %v", rows)
+	}
+	if len(saved.Functions) != 2 || saved.Functions[0]["type"] != "Query" || saved.Functions[1]["type"] != "Group" {
+		t.Fatalf("unexpected pipeline %+v", saved.Functions)
+	}
+	fns := saved.Functions[1]["functions"].([]interface{})
+	first := fns[0].(map[string]interface{})
+	if first["output_field"] != "total" || first["operation"] != "Sum" || first["input_field"] != "amount" {
+		t.Errorf("unexpected group function %v", first)
+	}
+
+	var totals []struct {
+		Status string  `json:"status"`
+		Total  float64 `json:"total"`
+	}
+	if err := agg.RunInto(&totals); err != nil {
+		t.Fatalf("RunInto failed: %v", err)
+	}
+	if len(totals) != 1 || totals[0].Status != "paid" || totals[0].Total != 1500.5 {
+		t.Errorf("unexpected totals %+v", totals)
+	}
+}
+
+func TestAggregateFunctionsErrors(t *testing.T) {
+	c := &Client{}
+	if _, err := c.Aggregate("orders").GroupBy("status").Functions(); err == nil {
+		t.Error("expected error with no aggregates")
+	}
+	if _, err := c.Aggregate("orders").As("x").Sum("a").Functions(); err == nil {
+		t.Error("expected error for As before an aggregate")
+	}
+	if _, err := c.Aggregate("orders").Sum("a").Sum("a").Functions(); err == nil {
+		t.Error("expected error for duplicate output field")
+	}
+	fns, err := c.Aggregate("orders").Avg("price").Functions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fns[0].Stage != "FindAll" || fns[1].Data["functions"].([]GroupFunctionConfig)[0].OutputField != "avg_price" {
+		t.Errorf("unexpected pipeline %+v", fns)
+	}
+}
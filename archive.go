@@ -0,0 +1,111 @@
+package ekodb
+
+import "fmt"
+
+// ArchiveOptions contains optional parameters for ArchiveWhere
+type ArchiveOptions struct {
+	// ChunkSize is the number of records moved per transaction (default: 500).
+	ChunkSize int
+	// IsolationLevel for each chunk's transaction (default: "READ_COMMITTED").
+	IsolationLevel string
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
+}
+
+// ArchiveWhere moves every record in srcCollection matching query into
+// archiveCollection and returns how many were moved. Records keep their IDs.
+//
+// Work is done in chunks: each chunk is read, inserted into the archive, and
+// deleted from the source inside a single transaction, so a record is never
+// lost or duplicated if a chunk fails — that chunk is rolled back and the
+// error is returned along with the count of records already archived by
+// earlier chunks. query accepts anything Find does (typically
+// QueryBuilder.Build()); its limit and skip are ignored.
+//
+// Example:
+//
+//	cutoff := time.Now().AddDate(0, -6, 0)
+//	query := NewQueryBuilder().Lt("created_at", cutoff.Format(time.RFC3339)).Build()
+//	moved, err := client.ArchiveWhere("events", query, "events_archive")
+func (c *Client) ArchiveWhere(srcCollection string, query interface{}, archiveCollection string, opts ...ArchiveOptions) (int, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	chunkSize := 500
+	isolation := "READ_COMMITTED"
+	if len(opts) > 0 {
+		if opts[0].ChunkSize > 0 {
+			chunkSize = opts[0].ChunkSize
+		}
+		if opts[0].IsolationLevel != "" {
+			isolation = opts[0].IsolationLevel
+		}
+	}
+
+	// Archived records are deleted from the source, so every chunk reads from
+	// the start of the (shrinking) result set.
+	skip := 0
+	archived := 0
+	for {
+		records, err := c.Find(srcCollection, query, FindOptions{Limit: &chunkSize, Skip: &skip})
+		if err != nil {
+			return archived, err
+		}
+		if len(records) == 0 {
+			return archived, nil
+		}
+
+		moved, err := c.archiveChunk(srcCollection, archiveCollection, records, isolation)
+		if err != nil {
+			return archived, err
+		}
+		archived += moved
+
+		if len(records) < chunkSize {
+			return archived, nil
+		}
+	}
+}
+
+// archiveChunk copies records into archiveCollection and deletes them from
+// srcCollection in one transaction, returning the number moved. The
+// transaction is rolled back unless every record is both inserted and
+// deleted.
+func (c *Client) archiveChunk(srcCollection, archiveCollection string, records []Record, isolation string) (int, error) {
+	ids := make([]string, 0, len(records))
+	for _, r := range records {
+		id := c.ExtractRecordID(srcCollection, r)
+		if id == "" {
+			return 0, fmt.Errorf("archive of %s: record without an id", srcCollection)
+		}
+		ids = append(ids, id)
+	}
+
+	txID, err := c.BeginTransaction(isolation)
+	if err != nil {
+		return 0, err
+	}
+	inserted, err := c.BatchInsertDetailed(archiveCollection, records, BatchInsertOptions{TransactionId: &txID})
+	if err == nil {
+		err = inserted.Err()
+	}
+	if err != nil {
+		_ = c.RollbackTransaction(txID)
+		return 0, fmt.Errorf("archive insert into %s failed: %w", archiveCollection, err)
+	}
+	deleted, err := c.BatchDeleteDetailed(srcCollection, ids, BatchDeleteOptions{TransactionId: &txID})
+	if err == nil {
+		err = deleted.Err()
+	}
+	if err == nil && len(deleted.Successful) != len(ids) {
+		err = fmt.Errorf("%d of %d records deleted", len(deleted.Successful), len(ids))
+	}
+	if err != nil {
+		_ = c.RollbackTransaction(txID)
+		return 0, fmt.Errorf("archive delete from %s failed: %w", srcCollection, err)
+	}
+	if err := c.CommitTransaction(txID); err != nil {
+		return 0, fmt.Errorf("archive commit failed: %w", err)
+	}
+	return len(ids), nil
+}
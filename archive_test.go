@@ -0,0 +1,174 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// archiveFakeServer models a source collection whose records are removed by
+// batch deletes, recording the transaction each write was staged in.
+type archiveFakeServer struct {
+	mu        sync.Mutex
+	source    []Record
+	archived  []Record
+	txWrites  map[string]int
+	committed int
+	rolled    int
+	failMove  bool
+	// failInsert is an ID the archive insert reports as failed.
+	failInsert string
+}
+
+func (f *archiveFakeServer) handlers(t *testing.T) map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"POST /api/find/events": func(w http.ResponseWriter, r *http.Request) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			limit := int(body["limit"].(float64))
+			if body["skip"].(float64) != 0 {
+				t.Errorf("expected skip 0, got %v", body["skip"])
+			}
+			n := limit
+			if n > len(f.source) {
+				n = len(f.source)
+			}
+			_ = json.NewEncoder(w).Encode(f.source[:n])
+		},
+		"POST /api/transactions": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"transaction_id": "tx"})
+		},
+		"POST /api/batch/insert/events_archive": func(w http.ResponseWriter, r *http.Request) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.txWrites[r.URL.Query().Get("transaction_id")]++
+			var body struct {
+				Inserts []struct {
+					Data Record `json:"data"`
+				} `json:"inserts"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			ids := []string{}
+			failed := []interface{}{}
+			for _, item := range body.Inserts {
+				if item.Data["id"] == f.failInsert {
+					failed = append(failed, map[string]interface{}{"id": f.failInsert, "error": "duplicate key"})
+					continue
+				}
+				f.archived = append(f.archived, item.Data)
+				ids = append(ids, item.Data["id"].(string))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": ids, "failed": failed})
+		},
+		"DELETE /api/batch/delete/events": func(w http.ResponseWriter, r *http.Request) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if f.failMove {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			f.txWrites[r.URL.Query().Get("transaction_id")]++
+			var body struct {
+				Deletes []struct {
+					ID string `json:"id"`
+				} `json:"deletes"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			gone := map[string]bool{}
+			ids := []string{}
+			for _, d := range body.Deletes {
+				gone[d.ID] = true
+				ids = append(ids, d.ID)
+			}
+			kept := f.source[:0]
+			for _, rec := range f.source {
+				if !gone[rec["id"].(string)] {
+					kept = append(kept, rec)
+				}
+			}
+			f.source = kept
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": ids, "failed": []interface{}{}})
+		},
+		"POST /api/transactions/*": func(w http.ResponseWriter, r *http.Request) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/commit"):
+				f.committed++
+			case strings.HasSuffix(r.URL.Path, "/rollback"):
+				f.rolled++
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	}
+}
+
+func TestArchiveWhereMovesInChunks(t *testing.T) {
+	fake := &archiveFakeServer{txWrites: map[string]int{}}
+	for _, id := range []string{"e1", "e2", "e3", "e4", "e5"} {
+		fake.source = append(fake.source, Record{"id": id, "kind": "old"})
+	}
+	server := createTestServer(t, fake.handlers(t))
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	query := NewQueryBuilder().Eq("kind", "old").Build()
+	moved, err := client.ArchiveWhere("events", query, "events_archive", ArchiveOptions{ChunkSize: 2})
+	if err != nil {
+		t.Fatalf("ArchiveWhere failed: %v", err)
+	}
+	if moved != 5 {
+		t.Errorf("moved = %d, want 5", moved)
+	}
+	if len(fake.source) != 0 || len(fake.archived) != 5 {
+		t.Errorf("source=%d archived=%d, want 0/5", len(fake.source), len(fake.archived))
+	}
+	if fake.committed != 3 {
+		t.Errorf("expected 3 committed chunk transactions, got %d", fake.committed)
+	}
+	if fake.txWrites[""] != 0 || fake.txWrites["tx"] != 6 {
+		t.Errorf("every write must be staged in the chunk transaction: %v", fake.txWrites)
+	}
+}
+
+func TestArchiveWhereRollsBackFailedChunk(t *testing.T) {
+	fake := &archiveFakeServer{txWrites: map[string]int{}, failMove: true}
+	fake.source = []Record{{"id": "e1"}}
+	server := createTestServer(t, fake.handlers(t))
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	moved, err := client.ArchiveWhere("events", nil, "events_archive")
+	if err == nil {
+		t.Fatal("expected error from failed delete")
+	}
+	if moved != 0 {
+		t.Errorf("moved = %d, want 0", moved)
+	}
+	if fake.rolled != 1 || fake.committed != 0 {
+		t.Errorf("expected rollback without commit, got rolled=%d committed=%d", fake.rolled, fake.committed)
+	}
+}
+
+func TestArchiveWhereRollsBackPartialInsert(t *testing.T) {
+	fake := &archiveFakeServer{txWrites: map[string]int{}, failInsert: "e2"}
+	fake.source = []Record{{"id": "e1"}, {"id": "e2"}}
+	server := createTestServer(t, fake.handlers(t))
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	moved, err := client.ArchiveWhere("events", nil, "events_archive")
+	if err == nil {
+		t.Fatal("expected error from a partly failed insert")
+	}
+	if moved != 0 || len(fake.source) != 2 {
+		t.Errorf("moved = %d, source = %d; no record may leave the source", moved, len(fake.source))
+	}
+	if fake.rolled != 1 || fake.committed != 0 {
+		t.Errorf("expected rollback without commit, got rolled=%d committed=%d", fake.rolled, fake.committed)
+	}
+}
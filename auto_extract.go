@@ -0,0 +1,72 @@
+package ekodb
+
+// WithAutoExtract turns ClientConfig.AutoExtract on or off for the derived
+// client.
+func WithAutoExtract(enabled bool) RequestOption {
+	return func(c *Client) {
+		c.autoExtract = enabled
+	}
+}
+
+// extractRecordInPlace replaces every wrapped field value in rec with its
+// plain value, leaving "id" untouched, as ExtractRecord does.
+func extractRecordInPlace(rec map[string]interface{}) {
+	for key, value := range rec {
+		if key != "id" {
+			rec[key] = GetValue(value)
+		}
+	}
+}
+
+// rewritesRecords reports whether returned records need finishRecord.
+func (c *Client) rewritesRecords() bool {
+	return c.autoExtract || c.packVectors
+}
+
+// finishRecord applies the client's read-side rewrites to a returned record:
+// packed vectors are unpacked first, so AutoExtract then sees plain arrays.
+func (c *Client) finishRecord(rec map[string]interface{}) {
+	if c.packVectors {
+		unpackVectorsInPlace(rec)
+	}
+	if c.autoExtract {
+		extractRecordInPlace(rec)
+	}
+}
+
+// finishDecoded applies finishRecord to a value decoded by unmarshal.
+func (c *Client) finishDecoded(v interface{}) {
+	if !c.rewritesRecords() {
+		return
+	}
+	switch v := v.(type) {
+	case *Record:
+		c.finishRecord(*v)
+	case *[]Record:
+		for _, rec := range *v {
+			c.finishRecord(rec)
+		}
+	}
+}
+
+// finishingRecordFunc wraps a FindEach callback so it sees finished records.
+func (c *Client) finishingRecordFunc(fn func(Record) error) func(Record) error {
+	if !c.rewritesRecords() {
+		return fn
+	}
+	return func(rec Record) error {
+		c.finishRecord(rec)
+		return fn(rec)
+	}
+}
+
+// finishingSearchFunc is finishingRecordFunc for SearchEach callbacks.
+func (c *Client) finishingSearchFunc(fn func(SearchResult) error) func(SearchResult) error {
+	if !c.rewritesRecords() {
+		return fn
+	}
+	return func(result SearchResult) error {
+		c.finishRecord(result.Record)
+		return fn(result)
+	}
+}
@@ -0,0 +1,68 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestAutoExtract(t *testing.T) {
+	wrapped := map[string]interface{}{
+		"id":   "u1",
+		"name": map[string]interface{}{"type": "String", "value": "Ada"},
+		"age":  map[string]interface{}{"type": "Integer", "value": 36},
+	}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode([]interface{}{wrapped})
+		},
+		"GET /api/find/users/u1": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(wrapped)
+		},
+		"POST /api/search/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"results": []interface{}{map[string]interface{}{"record": wrapped, "score": 1}},
+				"total":   1,
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	// Off by default.
+	rec, err := client.FindByID("users", "u1")
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if _, ok := rec["name"].(map[string]interface{}); !ok {
+		t.Errorf("expected a wrapped value without AutoExtract, got %v", rec["name"])
+	}
+
+	plain := client.With(WithAutoExtract(true))
+	rec, err = plain.FindByID("users", "u1")
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if rec["id"] != "u1" || rec["name"] != "Ada" || rec["age"] != float64(36) {
+		t.Errorf("FindByID not extracted: %v", rec)
+	}
+
+	records, err := plain.Find("users", nil)
+	if err != nil || len(records) != 1 || records[0]["name"] != "Ada" {
+		t.Errorf("Find not extracted: %v, %v", records, err)
+	}
+
+	var streamed string
+	err = plain.FindEach("users", nil, func(rec Record) error {
+		streamed, _ = rec["name"].(string)
+		return nil
+	})
+	if err != nil || streamed != "Ada" {
+		t.Errorf("FindEach not extracted: %q, %v", streamed, err)
+	}
+
+	results, err := plain.Search("users", SearchQuery{Query: "ada"})
+	if err != nil || results.Results[0].Record["name"] != "Ada" {
+		t.Errorf("Search not extracted: %+v, %v", results, err)
+	}
+}
@@ -0,0 +1,183 @@
+package ekodb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// defaultChunkSize is the chunk size of the chunked batch methods when
+// ChunkOptions.Size is unset.
+const defaultChunkSize = 1000
+
+// ProgressFunc reports the progress of a bulk helper: done items of total
+// (-1 if the total is not known up front), and the error of the step just
+// finished, if any. The helpers call it after each chunk, never
+// concurrently.
+type ProgressFunc func(done, total int, lastErr error)
+
+// ChunkOptions controls how BatchInsertChunked, BatchUpdateChunked, and
+// BatchDeleteChunked split their input.
+type ChunkOptions struct {
+	// Size is the number of items per batch request (default 1000).
+	Size int
+	// Parallelism caps the chunks in flight; 0 uses the client's
+	// MaxConcurrency.
+	Parallelism int
+	// ContinueOnError keeps going after a chunk's request fails, reporting
+	// each of its items in BatchResult.Failed. Otherwise the first failure
+	// cancels the chunks still running and is returned.
+	ContinueOnError bool
+	// Progress is called after each chunk completes.
+	Progress ProgressFunc
+}
+
+// runChunks calls fn for each chunk of n items through the client's fan-out
+// helper and merges the chunk results in order. A failed chunk is either
+// returned or, with ContinueOnError, recorded against ids (the IDs of the
+// items, or "" where unknown) with Index set to the item's position.
+// Cancelling the client's context stops chunks from starting.
+func (c *Client) runChunks(n int, ids []string, opts ChunkOptions, fn func(ctx context.Context, start, end int) (*BatchResult, error)) (*BatchResult, error) {
+	size := opts.Size
+	if size <= 0 {
+		size = defaultChunkSize
+	}
+	chunks := (n + size - 1) / size
+	results := make([]*BatchResult, chunks)
+	var mu sync.Mutex
+	var failed []BatchItemError
+	done := 0
+	report := func(items int, err error) {
+		if opts.Progress == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		done += items
+		opts.Progress(done, n, err)
+	}
+
+	runner := c
+	if opts.Parallelism > 0 {
+		runner = c.With(WithMaxConcurrency(opts.Parallelism))
+	}
+	err := runner.fanOut(chunks, func(ctx context.Context, i int) error {
+		start := i * size
+		end := min(start+size, n)
+		result, err := fn(ctx, start, end)
+		if err != nil {
+			err = fmt.Errorf("chunk %d (items %d-%d): %w", i, start, end-1, err)
+			report(end-start, err)
+			if !opts.ContinueOnError {
+				return err
+			}
+			mu.Lock()
+			for j := start; j < end; j++ {
+				failed = append(failed, BatchItemError{Index: j, ID: ids[j], Message: err.Error()})
+			}
+			mu.Unlock()
+			return nil
+		}
+		for k := range result.Failed {
+			if result.Failed[k].Index >= 0 {
+				result.Failed[k].Index += start
+			}
+		}
+		results[i] = result
+		report(end-start, result.Err())
+		return nil
+	})
+
+	merged := &BatchResult{}
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		merged.Records = append(merged.Records, r.Records...)
+		merged.Successful = append(merged.Successful, r.Successful...)
+		merged.Failed = append(merged.Failed, r.Failed...)
+	}
+	sort.SliceStable(failed, func(a, b int) bool { return failed[a].Index < failed[b].Index })
+	merged.Failed = append(merged.Failed, failed...)
+	return merged, err
+}
+
+// BatchInsertChunked inserts records in chunks of opts.Size, running the
+// chunks concurrently, and merges the results in input order. Failed indexes
+// refer to records. On an error (without ContinueOnError) the result still
+// holds the chunks that completed.
+//
+// The chunks are separate requests: without a transaction, a failure leaves
+// the earlier chunks inserted.
+func (c *Client) BatchInsertChunked(collection string, records []Record, opts ChunkOptions, insertOpts ...BatchInsertOptions) (*BatchResult, error) {
+	if len(insertOpts) > 0 {
+		c = c.withOptions(insertOpts[0].RequestOptions)
+		// c carries the request options now; don't apply them again per call.
+		o := insertOpts[0]
+		o.RequestOptions = nil
+		insertOpts = []BatchInsertOptions{o}
+	}
+	ids := make([]string, len(records))
+	for i, r := range records {
+		ids[i], _ = r["id"].(string)
+	}
+	result, err := c.runChunks(len(records), ids, opts, func(ctx context.Context, start, end int) (*BatchResult, error) {
+		chunkOpts := insertOpts
+		if len(insertOpts) > 0 {
+			// Per-record TTLs follow their records into the chunk.
+			o := insertOpts[0]
+			o.TTLs = o.TTLs[min(start, len(o.TTLs)):min(end, len(o.TTLs))]
+			chunkOpts = []BatchInsertOptions{o}
+		}
+		return c.WithContext(ctx).BatchInsertDetailed(collection, records[start:end], chunkOpts...)
+	})
+	result.client, result.retry = c, insertRetry(collection, records, insertOpts)
+	return result, err
+}
+
+// BatchUpdateChunked applies updates in chunks of opts.Size, in ID order,
+// running the chunks concurrently. See BatchInsertChunked.
+func (c *Client) BatchUpdateChunked(collection string, updates map[string]Record, opts ChunkOptions, updateOpts ...BatchUpdateOptions) (*BatchResult, error) {
+	if len(updateOpts) > 0 {
+		c = c.withOptions(updateOpts[0].RequestOptions)
+		// c carries the request options now; don't apply them again per call.
+		o := updateOpts[0]
+		o.RequestOptions = nil
+		updateOpts = []BatchUpdateOptions{o}
+	}
+	ids := make([]string, 0, len(updates))
+	for id := range updates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	result, err := c.runChunks(len(ids), ids, opts, func(ctx context.Context, start, end int) (*BatchResult, error) {
+		chunk := make(map[string]Record, end-start)
+		for _, id := range ids[start:end] {
+			chunk[id] = updates[id]
+		}
+		return c.WithContext(ctx).BatchUpdateDetailed(collection, chunk, updateOpts...)
+	})
+	for i := range result.Failed {
+		result.Failed[i].Index = -1
+	}
+	result.client, result.retry = c, updateRetry(collection, updates, updateOpts)
+	return result, err
+}
+
+// BatchDeleteChunked deletes ids in chunks of opts.Size, running the chunks
+// concurrently. See BatchInsertChunked.
+func (c *Client) BatchDeleteChunked(collection string, ids []string, opts ChunkOptions, deleteOpts ...BatchDeleteOptions) (*BatchResult, error) {
+	if len(deleteOpts) > 0 {
+		c = c.withOptions(deleteOpts[0].RequestOptions)
+		// c carries the request options now; don't apply them again per call.
+		o := deleteOpts[0]
+		o.RequestOptions = nil
+		deleteOpts = []BatchDeleteOptions{o}
+	}
+	result, err := c.runChunks(len(ids), ids, opts, func(ctx context.Context, start, end int) (*BatchResult, error) {
+		return c.WithContext(ctx).BatchDeleteDetailed(collection, ids[start:end], deleteOpts...)
+	})
+	result.client, result.retry = c, deleteRetry(collection, ids, deleteOpts)
+	return result, err
+}
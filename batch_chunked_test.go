@@ -0,0 +1,129 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBatchInsertChunked(t *testing.T) {
+	var requests atomic.Int32
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/batch/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			var body batchInsertQuery
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.Inserts[0].Data["name"] == "r4" {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error":"boom"}`))
+				return
+			}
+			ids := make([]string, len(body.Inserts))
+			for i, item := range body.Inserts {
+				ids[i] = fmt.Sprint(item.Data["name"])
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": ids, "failed": []interface{}{}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	records := make([]Record, 10)
+	for i := range records {
+		records[i] = Record{"name": fmt.Sprintf("r%d", i)}
+	}
+
+	result, err := client.BatchInsertChunked("users", records, ChunkOptions{Size: 2, Parallelism: 3, ContinueOnError: true})
+	if err != nil {
+		t.Fatalf("BatchInsertChunked failed: %v", err)
+	}
+	if requests.Load() != 5 {
+		t.Errorf("requests = %d, want 5", requests.Load())
+	}
+	want := []string{"r0", "r1", "r2", "r3", "r6", "r7", "r8", "r9"}
+	if fmt.Sprint(result.Successful) != fmt.Sprint(want) || len(result.Records) != len(want) {
+		t.Errorf("Successful = %v", result.Successful)
+	}
+	if len(result.Failed) != 2 || result.Failed[0].Index != 4 || result.Failed[1].Index != 5 {
+		t.Errorf("Failed = %+v", result.Failed)
+	}
+
+	if _, err := client.BatchInsertChunked("users", records, ChunkOptions{Size: 2, Parallelism: 1}); err == nil {
+		t.Error("expected the failed chunk's error without ContinueOnError")
+	}
+}
+
+func TestBatchDeleteChunked(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"DELETE /api/batch/delete/users": func(w http.ResponseWriter, r *http.Request) {
+			var body batchDeleteQuery
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			var ok []string
+			var failed []interface{}
+			for _, d := range body.Deletes {
+				if d.ID == "c" {
+					failed = append(failed, map[string]interface{}{"id": "c", "error": "locked"})
+					continue
+				}
+				ok = append(ok, d.ID)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": ok, "failed": failed})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	result, err := client.BatchDeleteChunked("users", []string{"a", "b", "c", "d", "e"}, ChunkOptions{Size: 2})
+	if err != nil {
+		t.Fatalf("BatchDeleteChunked failed: %v", err)
+	}
+	if len(result.Successful) != 4 || len(result.Failed) != 1 || result.Failed[0].Index != 2 {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestBatchChunkedProgressAndCancel(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"DELETE /api/batch/delete/users": func(w http.ResponseWriter, r *http.Request) {
+			var body batchDeleteQuery
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			ids := make([]string, len(body.Deletes))
+			for i, d := range body.Deletes {
+				ids[i] = d.ID
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": ids, "failed": []interface{}{}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+	ids := []string{"a", "b", "c", "d", "e"}
+
+	var done []int
+	_, err := client.BatchDeleteChunked("users", ids, ChunkOptions{
+		Size:        2,
+		Parallelism: 1,
+		Progress: func(n, total int, err error) {
+			if total != 5 || err != nil {
+				t.Errorf("progress total %d, error %v", total, err)
+			}
+			done = append(done, n)
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchDeleteChunked failed: %v", err)
+	}
+	if fmt.Sprint(done) != "[2 4 5]" {
+		t.Errorf("progress = %v", done)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := client.WithContext(ctx).BatchDeleteChunked("users", ids, ChunkOptions{Size: 2})
+	if !errors.Is(err, context.Canceled) || len(result.Successful) != 0 {
+		t.Errorf("cancelled delete = %v, %v", result.Successful, err)
+	}
+}
@@ -0,0 +1,301 @@
+package ekodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BatchItemError is one item a batch operation failed to write.
+type BatchItemError struct {
+	// Index is the item's position in the records or IDs passed in, or -1
+	// when it is unknown (always for BatchUpdate, whose items come from a map).
+	Index int
+	// ID is the record ID, when the server reports it or the item had one.
+	ID      string
+	Message string
+}
+
+func (e *BatchItemError) Error() string {
+	switch {
+	case e.ID != "":
+		return fmt.Sprintf("record %s: %s", e.ID, e.Message)
+	case e.Index >= 0:
+		return fmt.Sprintf("item %d: %s", e.Index, e.Message)
+	}
+	return e.Message
+}
+
+// BatchResult is the outcome of a BatchInsertDetailed, BatchUpdateDetailed,
+// or BatchDeleteDetailed call. A batch can partly succeed; Failed lists the
+// items that did not.
+type BatchResult struct {
+	// Records holds the written records as BatchInsert and BatchUpdate return
+	// them; it is empty for deletes.
+	Records    []Record
+	Successful []string
+	Failed     []BatchItemError
+
+	// client and retry resubmit failed items for RetryFailed.
+	client *Client
+	retry  batchRetry
+}
+
+// Err returns nil if every item succeeded, and otherwise an error joining a
+// *BatchItemError per failed item, which errors.As can extract:
+//
+//	if err := result.Err(); err != nil {
+//	    var item *ekodb.BatchItemError
+//	    if errors.As(err, &item) { ... }
+//	}
+func (r *BatchResult) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Failed))
+	for i := range r.Failed {
+		errs[i] = &r.Failed[i]
+	}
+	return errors.Join(errs...)
+}
+
+// FailedIDs returns the IDs of the failed items that have one, for retrying.
+func (r *BatchResult) FailedIDs() []string {
+	var ids []string
+	for _, f := range r.Failed {
+		if f.ID != "" {
+			ids = append(ids, f.ID)
+		}
+	}
+	return ids
+}
+
+// batchFailures converts the failed array of a batch response. The server
+// reports each failure as an object with an error message and the item's id
+// and/or index, or as a bare message; ids are the IDs sent, in order, used to
+// fill in whichever of id and index is missing.
+func batchFailures(failed []interface{}, ids []string) []BatchItemError {
+	if len(failed) == 0 {
+		return nil
+	}
+	position := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if id != "" {
+			position[id] = i
+		}
+	}
+	out := make([]BatchItemError, len(failed))
+	for i, f := range failed {
+		item := BatchItemError{Index: -1}
+		switch v := f.(type) {
+		case string:
+			item.Message = v
+		case map[string]interface{}:
+			item.ID = GetStringValue(v["id"])
+			if n, ok := codecInt(v["index"]); ok {
+				item.Index = int(n)
+			}
+			for _, key := range []string{"error", "message", "reason"} {
+				if msg := GetStringValue(v[key]); msg != "" {
+					item.Message = msg
+					break
+				}
+			}
+		default:
+			item.Message = fmt.Sprint(v)
+		}
+		if item.Index < 0 && item.ID != "" {
+			if p, ok := position[item.ID]; ok {
+				item.Index = p
+			}
+		}
+		if item.ID == "" && item.Index >= 0 && item.Index < len(ids) {
+			item.ID = ids[item.Index]
+		}
+		if item.Message == "" {
+			item.Message = "failed"
+		}
+		out[i] = item
+	}
+	return out
+}
+
+// batchRetry resubmits failed items of a batch through c. It returns the
+// outcome of the resubmitted items, with Failed indexes referring to the
+// original input, and the items it could not resubmit.
+type batchRetry func(c *Client, failed []BatchItemError) (*BatchResult, []BatchItemError, error)
+
+// RetryFailedOptions contains optional parameters for RetryFailed
+type RetryFailedOptions struct {
+	// MaxAttempts is the number of times failed items are resubmitted
+	// (default: 3).
+	MaxAttempts int
+	// ShouldRetry selects the failures worth resubmitting, such as those
+	// caused by a timeout rather than a duplicate key. Nil retries all.
+	ShouldRetry func(BatchItemError) bool
+}
+
+// RetryFailed resubmits only the failed items of the batch, with jittered
+// exponential backoff between attempts, and merges the outcome into r:
+// resubmitted items that succeed move to Successful (and Records), and
+// Failed is left with those that still failed. It returns r.Err().
+//
+//	result, err := client.BatchInsertDetailed("events", records)
+//	if err != nil {
+//	    return err
+//	}
+//	if err := result.RetryFailed(ctx); err != nil {
+//	    log.Printf("%d events not inserted: %v", len(result.Failed), err)
+//	}
+//
+// Failures RetryFailed cannot match to an input item (an insert reported
+// without an index or an ID) stay in Failed. Without a Transaction, a
+// resubmitted insert that had in fact succeeded is inserted twice; results
+// not returned by a batch method cannot be retried.
+func (r *BatchResult) RetryFailed(ctx context.Context, opts ...RetryFailedOptions) error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	if r.retry == nil {
+		return fmt.Errorf("retry failed: batch result has no request to resubmit")
+	}
+	var o RetryFailedOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 3
+	}
+	c := r.client.WithContext(ctx)
+
+	for attempt := 0; attempt < o.MaxAttempts; attempt++ {
+		var pending, kept []BatchItemError
+		for _, f := range r.Failed {
+			if o.ShouldRetry == nil || o.ShouldRetry(f) {
+				pending = append(pending, f)
+			} else {
+				kept = append(kept, f)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		timer := time.NewTimer(retryBackoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+
+		result, skipped, err := r.retry(c, pending)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// The whole request failed; the items stay failed for the next
+			// attempt.
+			continue
+		}
+		r.Records = append(r.Records, result.Records...)
+		r.Successful = append(r.Successful, result.Successful...)
+		r.Failed = append(append(kept, skipped...), result.Failed...)
+	}
+	return r.Err()
+}
+
+// insertRetry resubmits failed inserts by their index in records.
+func insertRetry(collection string, records []Record, opts []BatchInsertOptions) batchRetry {
+	return func(c *Client, failed []BatchItemError) (*BatchResult, []BatchItemError, error) {
+		var skipped []BatchItemError
+		var positions []int
+		var sub []Record
+		for _, f := range failed {
+			if f.Index < 0 || f.Index >= len(records) {
+				skipped = append(skipped, f)
+				continue
+			}
+			positions = append(positions, f.Index)
+			sub = append(sub, records[f.Index])
+		}
+		if len(sub) == 0 {
+			return &BatchResult{}, skipped, nil
+		}
+		subOpts := opts
+		if len(opts) > 0 && len(opts[0].TTLs) > 0 {
+			o := opts[0]
+			o.TTLs = make([]string, len(positions))
+			for i, p := range positions {
+				if p < len(opts[0].TTLs) {
+					o.TTLs[i] = opts[0].TTLs[p]
+				}
+			}
+			subOpts = []BatchInsertOptions{o}
+		}
+		result, err := c.BatchInsertDetailed(collection, sub, subOpts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range result.Failed {
+			if idx := result.Failed[i].Index; idx >= 0 && idx < len(positions) {
+				result.Failed[i].Index = positions[idx]
+			}
+		}
+		return result, skipped, nil
+	}
+}
+
+// updateRetry resubmits failed updates by ID.
+func updateRetry(collection string, updates map[string]Record, opts []BatchUpdateOptions) batchRetry {
+	return func(c *Client, failed []BatchItemError) (*BatchResult, []BatchItemError, error) {
+		var skipped []BatchItemError
+		sub := make(map[string]Record)
+		for _, f := range failed {
+			if rec, ok := updates[f.ID]; ok {
+				sub[f.ID] = rec
+			} else {
+				skipped = append(skipped, f)
+			}
+		}
+		if len(sub) == 0 {
+			return &BatchResult{}, skipped, nil
+		}
+		result, err := c.BatchUpdateDetailed(collection, sub, opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		return result, skipped, nil
+	}
+}
+
+// deleteRetry resubmits failed deletes by their index in ids.
+func deleteRetry(collection string, ids []string, opts []BatchDeleteOptions) batchRetry {
+	return func(c *Client, failed []BatchItemError) (*BatchResult, []BatchItemError, error) {
+		var skipped []BatchItemError
+		var positions []int
+		var sub []string
+		for _, f := range failed {
+			if f.Index < 0 || f.Index >= len(ids) {
+				skipped = append(skipped, f)
+				continue
+			}
+			positions = append(positions, f.Index)
+			sub = append(sub, ids[f.Index])
+		}
+		if len(sub) == 0 {
+			return &BatchResult{}, skipped, nil
+		}
+		result, err := c.BatchDeleteDetailed(collection, sub, opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range result.Failed {
+			if idx := result.Failed[i].Index; idx >= 0 && idx < len(positions) {
+				result.Failed[i].Index = positions[idx]
+			}
+		}
+		return result, skipped, nil
+	}
+}
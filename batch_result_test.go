@@ -0,0 +1,128 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestBatchDetailedFailures(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/batch/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"successful": []string{"a"},
+				"failed":     []interface{}{map[string]interface{}{"index": 1, "error": "duplicate email"}},
+			})
+		},
+		"PUT /api/batch/update/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"successful": []string{},
+				"failed":     []interface{}{map[string]interface{}{"id": "u9", "message": "not found"}},
+			})
+		},
+		"DELETE /api/batch/delete/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"successful": []string{"d1"},
+				"failed":     []interface{}{map[string]interface{}{"id": "d2", "error": "locked"}, "quota exceeded"},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	inserted, err := client.BatchInsertDetailed("users", []Record{{"name": "a"}, {"id": "b", "name": "b"}})
+	if err != nil {
+		t.Fatalf("BatchInsertDetailed failed: %v", err)
+	}
+	if len(inserted.Records) != 1 || len(inserted.Failed) != 1 {
+		t.Fatalf("unexpected result %+v", inserted)
+	}
+	if f := inserted.Failed[0]; f.Index != 1 || f.ID != "b" || f.Message != "duplicate email" {
+		t.Errorf("unexpected failure %+v", f)
+	}
+
+	updated, err := client.BatchUpdateDetailed("users", map[string]Record{"u9": {"name": "x"}})
+	if err != nil {
+		t.Fatalf("BatchUpdateDetailed failed: %v", err)
+	}
+	if ids := updated.FailedIDs(); len(ids) != 1 || ids[0] != "u9" || updated.Failed[0].Index != -1 {
+		t.Errorf("unexpected failures %+v", updated.Failed)
+	}
+
+	deleted, err := client.BatchDeleteDetailed("users", []string{"d1", "d2", "d3"})
+	if err != nil {
+		t.Fatalf("BatchDeleteDetailed failed: %v", err)
+	}
+	if len(deleted.Failed) != 2 || deleted.Failed[0].Index != 1 || deleted.Failed[1].Message != "quota exceeded" {
+		t.Errorf("unexpected failures %+v", deleted.Failed)
+	}
+	err = deleted.Err()
+	var item *BatchItemError
+	if !errors.As(err, &item) || item.ID != "d2" {
+		t.Errorf("Err() = %v, want a *BatchItemError for d2", err)
+	}
+	if err.Error() != "record d2: locked\nquota exceeded" {
+		t.Errorf("Err() = %q", err.Error())
+	}
+	if n, err := client.BatchDelete("users", []string{"d1", "d2", "d3"}); err != nil || n != 1 {
+		t.Errorf("BatchDelete = %d, %v", n, err)
+	}
+}
+
+func TestBatchResultRetryFailed(t *testing.T) {
+	var sent [][]string
+	attempts := 0
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/batch/insert/events": func(w http.ResponseWriter, r *http.Request) {
+			var body batchInsertQuery
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			var names []string
+			var ok []string
+			var failed []interface{}
+			for i, item := range body.Inserts {
+				name := item.Data["name"].(string)
+				names = append(names, name)
+				// "b" fails once, "c" always fails.
+				if name == "c" || (name == "b" && attempts == 0) {
+					failed = append(failed, map[string]interface{}{"index": i, "error": "timeout"})
+					continue
+				}
+				ok = append(ok, name)
+			}
+			attempts++
+			sent = append(sent, names)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": ok, "failed": failed})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	result, err := client.BatchInsertDetailed("events", []Record{{"name": "a"}, {"name": "b"}, {"name": "c"}})
+	if err != nil {
+		t.Fatalf("BatchInsertDetailed failed: %v", err)
+	}
+	err = result.RetryFailed(context.Background(), RetryFailedOptions{MaxAttempts: 2})
+	if err == nil {
+		t.Fatal("expected c to still fail")
+	}
+	if len(sent) != 3 || fmt.Sprint(sent[1]) != "[b c]" || fmt.Sprint(sent[2]) != "[c]" {
+		t.Errorf("requests = %v", sent)
+	}
+	if fmt.Sprint(result.Successful) != "[a b]" || len(result.Records) != 2 {
+		t.Errorf("Successful = %v", result.Successful)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Index != 2 {
+		t.Errorf("Failed = %+v", result.Failed)
+	}
+
+	result.Failed[0].Message = "duplicate"
+	err = result.RetryFailed(context.Background(), RetryFailedOptions{
+		ShouldRetry: func(f BatchItemError) bool { return f.Message == "timeout" },
+	})
+	if err == nil || len(sent) != 3 {
+		t.Errorf("ShouldRetry was ignored: %v after %d requests", err, len(sent))
+	}
+}
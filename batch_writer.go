@@ -0,0 +1,254 @@
+package ekodb
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrWriterClosed is returned by a Writer's methods after Close.
+var ErrWriterClosed = errors.New("writer is closed")
+
+// WriterOptions configures a Writer.
+type WriterOptions struct {
+	// MaxBatch flushes a collection's buffered writes once this many are
+	// waiting (default 500).
+	MaxBatch int
+	// Interval flushes all buffered writes this often (default 1s).
+	Interval time.Duration
+	// OnError receives the writes that failed in a background flush, or in a
+	// flush triggered by MaxBatch. Without it they are logged. It may run on
+	// the Writer's own goroutine.
+	OnError func(*WriterError)
+}
+
+// WriterError describes a batch of buffered writes that failed.
+type WriterError struct {
+	Collection string
+	Op         string   // "insert" or "delete"
+	Records    []Record // The inserts of the batch, for Op "insert"
+	IDs        []string // The deletes of the batch, for Op "delete"
+	// Result is the batch result when the request succeeded but some items
+	// failed; it is nil when the whole request failed.
+	Result *BatchResult
+	Err    error
+}
+
+func (e *WriterError) Error() string {
+	return fmt.Sprintf("buffered %s into %s: %v", e.Op, e.Collection, e.Err)
+}
+
+func (e *WriterError) Unwrap() error {
+	return e.Err
+}
+
+// Writer buffers single inserts and deletes and sends them as batch
+// requests, for high-frequency writes such as telemetry where a request per
+// record is too slow:
+//
+//	w := ekodb.NewWriter(client, ekodb.WriterOptions{
+//	    OnError: func(err *ekodb.WriterError) { log.Print(err) },
+//	})
+//	defer w.Close()
+//	for event := range events {
+//	    w.Insert("events", ekodb.Record{"type": event.Type})
+//	}
+//
+// Writes to a collection are sent in the order they were made; a run of
+// inserts becomes one BatchInsert and a run of deletes one BatchDelete.
+// Buffered writes are lost if the process exits without Flush or Close. A
+// Writer is safe for concurrent use.
+type Writer struct {
+	client *Client
+	opts   WriterOptions
+
+	mu      sync.Mutex
+	pending map[string][]writerRun
+	counts  map[string]int
+	closed  bool
+
+	// flushMu serializes flushes so that runs reach the server in order.
+	flushMu sync.Mutex
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// writerRun is a run of consecutive writes of one kind to a collection.
+type writerRun struct {
+	op      string
+	records []Record
+	ids     []string
+}
+
+// NewWriter starts a Writer over client.
+func NewWriter(client *Client, opts ...WriterOptions) *Writer {
+	var opt WriterOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.MaxBatch <= 0 {
+		opt.MaxBatch = 500
+	}
+	if opt.Interval <= 0 {
+		opt.Interval = time.Second
+	}
+	w := &Writer{
+		client:  client,
+		opts:    opt,
+		pending: make(map[string][]writerRun),
+		counts:  make(map[string]int),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *Writer) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.report(w.flush(nil))
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// Insert buffers an insert of record into collection.
+func (w *Writer) Insert(collection string, record Record) error {
+	return w.add(collection, "insert", record, "")
+}
+
+// Delete buffers a delete of record id from collection.
+func (w *Writer) Delete(collection, id string) error {
+	return w.add(collection, "delete", nil, id)
+}
+
+func (w *Writer) add(collection, op string, record Record, id string) error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrWriterClosed
+	}
+	runs := w.pending[collection]
+	if len(runs) == 0 || runs[len(runs)-1].op != op {
+		runs = append(runs, writerRun{op: op})
+	}
+	last := &runs[len(runs)-1]
+	if op == "insert" {
+		last.records = append(last.records, record)
+	} else {
+		last.ids = append(last.ids, id)
+	}
+	w.pending[collection] = runs
+	w.counts[collection]++
+	full := w.counts[collection] >= w.opts.MaxBatch
+	w.mu.Unlock()
+
+	if full {
+		w.report(w.flush([]string{collection}))
+	}
+	return nil
+}
+
+// Flush sends every buffered write and waits for the requests to finish,
+// returning the failures joined (each a *WriterError).
+func (w *Writer) Flush() error {
+	return joinWriterErrors(w.flush(nil))
+}
+
+// Close flushes the buffered writes and stops the Writer; later writes fail
+// with ErrWriterClosed.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mu.Unlock()
+	close(w.stop)
+	<-w.done
+	return w.Flush()
+}
+
+// flush sends the buffered writes of collections, or of all collections if
+// collections is nil.
+func (w *Writer) flush(collections []string) []*WriterError {
+	w.flushMu.Lock()
+	defer w.flushMu.Unlock()
+
+	w.mu.Lock()
+	if collections == nil {
+		for name := range w.pending {
+			collections = append(collections, name)
+		}
+		sort.Strings(collections)
+	}
+	taken := make(map[string][]writerRun, len(collections))
+	for _, name := range collections {
+		if runs := w.pending[name]; len(runs) > 0 {
+			taken[name] = runs
+			delete(w.pending, name)
+			delete(w.counts, name)
+		}
+	}
+	w.mu.Unlock()
+
+	var errs []*WriterError
+	for _, name := range collections {
+		for _, run := range taken[name] {
+			if err := w.send(name, run); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// send writes one run, in chunks of MaxBatch.
+func (w *Writer) send(collection string, run writerRun) *WriterError {
+	var result *BatchResult
+	var err error
+	if run.op == "insert" {
+		result, err = w.client.BatchInsertChunked(collection, run.records, ChunkOptions{Size: w.opts.MaxBatch, Parallelism: 1})
+	} else {
+		result, err = w.client.BatchDeleteChunked(collection, run.ids, ChunkOptions{Size: w.opts.MaxBatch, Parallelism: 1})
+	}
+	if err == nil {
+		if err = result.Err(); err == nil {
+			return nil
+		}
+	} else {
+		result = nil
+	}
+	return &WriterError{Collection: collection, Op: run.op, Records: run.records, IDs: run.ids, Result: result, Err: err}
+}
+
+// report passes the errors of a flush nobody waits for to OnError.
+func (w *Writer) report(errs []*WriterError) {
+	for _, err := range errs {
+		if w.opts.OnError != nil {
+			w.opts.OnError(err)
+		} else {
+			w.client.logf("Writer: %v", err)
+		}
+	}
+}
+
+func joinWriterErrors(errs []*WriterError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	joined := make([]error, len(errs))
+	for i, err := range errs {
+		joined[i] = err
+	}
+	return errors.Join(joined...)
+}
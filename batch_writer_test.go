@@ -0,0 +1,103 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWriter(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/batch/insert/events": func(w http.ResponseWriter, r *http.Request) {
+			var body batchInsertQuery
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			calls = append(calls, "insert")
+			mu.Unlock()
+			ids := make([]string, len(body.Inserts))
+			for i := range ids {
+				ids[i] = "e"
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": ids, "failed": []interface{}{}})
+		},
+		"DELETE /api/batch/delete/events": func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			calls = append(calls, "delete")
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"successful": []string{},
+				"failed":     []interface{}{map[string]interface{}{"id": "x", "error": "locked"}},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	var reported []*WriterError
+	w := NewWriter(client, WriterOptions{
+		MaxBatch: 3,
+		Interval: time.Hour,
+		OnError:  func(err *WriterError) { reported = append(reported, err) },
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := w.Insert("events", Record{"n": i}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+	mu.Lock()
+	if len(calls) != 1 {
+		t.Errorf("MaxBatch did not trigger a flush: %v", calls)
+	}
+	mu.Unlock()
+
+	_ = w.Insert("events", Record{"n": 3})
+	_ = w.Delete("events", "x")
+	err := w.Flush()
+	var werr *WriterError
+	if !errors.As(err, &werr) || werr.Op != "delete" || werr.IDs[0] != "x" || werr.Result == nil {
+		t.Errorf("Flush() = %v", err)
+	}
+	if len(reported) != 0 {
+		t.Errorf("Flush errors should be returned, not reported: %v", reported)
+	}
+
+	_ = w.Insert("events", Record{"n": 4})
+	if err := w.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	mu.Lock()
+	if got := len(calls); got != 4 || calls[1] != "insert" || calls[2] != "delete" || calls[3] != "insert" {
+		t.Errorf("calls = %v", calls)
+	}
+	mu.Unlock()
+	if err := w.Insert("events", Record{}); err != ErrWriterClosed {
+		t.Errorf("Insert after Close = %v", err)
+	}
+}
+
+func TestWriterInterval(t *testing.T) {
+	flushed := make(chan struct{}, 1)
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/batch/insert/events": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": []string{"e1"}, "failed": []interface{}{}})
+			flushed <- struct{}{}
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	w := NewWriter(client, WriterOptions{Interval: 10 * time.Millisecond})
+	defer w.Close()
+	_ = w.Insert("events", Record{"n": 1})
+	select {
+	case <-flushed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("buffered insert was not flushed on the interval")
+	}
+}
@@ -0,0 +1,346 @@
+package ekodb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CachedReader wraps a Client's Find and Search with an in-memory result
+// cache keyed by a hash of the collection and query, for read-heavy workloads
+// (e.g. dashboards) that repeat identical queries.
+//
+// Entries expire after the configured TTL and are swept as new results are
+// stored. Writes made through the CachedReader's own write methods invalidate
+// the affected collection; writes made elsewhere can be picked up by feeding a
+// subscription into Watch, or by calling Invalidate explicitly. A result
+// fetched while its collection is invalidated is returned but not cached. A
+// CachedReader is safe for concurrent use.
+type CachedReader struct {
+	client     *Client
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]cachedResult
+	// byCollection indexes cache keys per collection for Invalidate.
+	byCollection map[string]map[string]struct{}
+	// order lists stored keys oldest first, for sweeping and eviction. A
+	// slot is stale once its key was dropped or stored again.
+	order []cacheSlot
+	// generations counts the invalidations of each collection, and
+	// generation those of the whole cache, so a result fetched across an
+	// invalidation is not stored.
+	generations map[string]uint64
+	generation  uint64
+}
+
+// CachedReaderOptions contains optional parameters for NewCachedReader
+type CachedReaderOptions struct {
+	// MaxEntries caps the number of cached results, evicting the oldest
+	// first; zero means no cap.
+	MaxEntries int
+}
+
+type cachedResult struct {
+	collection string
+	records    []Record
+	search     *SearchResponse
+	storedAt   time.Time
+}
+
+type cacheSlot struct {
+	key      string
+	storedAt time.Time
+}
+
+// cacheGeneration identifies the invalidations seen by a read, checked
+// before its result is stored.
+type cacheGeneration struct {
+	all, collection uint64
+}
+
+// NewCachedReader creates a CachedReader over client whose entries live for
+// ttl (default: 30s when ttl <= 0).
+func NewCachedReader(client *Client, ttl time.Duration, opts ...CachedReaderOptions) *CachedReader {
+	var o CachedReaderOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if ttl <= 0 {
+		ttl = 30 * time.Second
+	}
+	return &CachedReader{
+		client:       client,
+		ttl:          ttl,
+		maxEntries:   max(o.MaxEntries, 0),
+		entries:      make(map[string]cachedResult),
+		byCollection: make(map[string]map[string]struct{}),
+		generations:  make(map[string]uint64),
+	}
+}
+
+// Find behaves like Client.Find, serving repeated identical queries from the
+// cache. Reads inside a transaction (FindOptions.TransactionId) or with
+// BypassCache set always go to the server.
+func (r *CachedReader) Find(collection string, query interface{}, opts ...FindOptions) ([]Record, error) {
+	if len(opts) > 0 && (opts[0].TransactionId != nil || (opts[0].BypassCache != nil && *opts[0].BypassCache)) {
+		return r.client.Find(collection, query, opts...)
+	}
+	key, err := cacheKey("find", collection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	if entry, ok := r.lookup(key); ok {
+		return copyRecords(entry.records), nil
+	}
+
+	gen := r.currentGeneration(collection)
+	records, err := r.client.Find(collection, query, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r.store(collection, key, gen, cachedResult{records: copyRecords(records)})
+	return records, nil
+}
+
+// Search behaves like Client.Search, serving repeated identical queries from
+// the cache.
+func (r *CachedReader) Search(collection string, searchQuery SearchQuery) (*SearchResponse, error) {
+	key, err := cacheKey("search", collection, searchQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	if entry, ok := r.lookup(key); ok {
+		return copySearchResponse(entry.search), nil
+	}
+
+	gen := r.currentGeneration(collection)
+	response, err := r.client.Search(collection, searchQuery)
+	if err != nil {
+		return nil, err
+	}
+	r.store(collection, key, gen, cachedResult{search: copySearchResponse(response)})
+	return response, nil
+}
+
+// Insert calls Client.Insert and invalidates the collection's cached results.
+func (r *CachedReader) Insert(collection string, record Record, opts ...InsertOptions) (Record, error) {
+	defer r.Invalidate(collection)
+	return r.client.Insert(collection, record, opts...)
+}
+
+// Update calls Client.Update and invalidates the collection's cached results.
+func (r *CachedReader) Update(collection, id string, record Record, opts ...UpdateOptions) (Record, error) {
+	defer r.Invalidate(collection)
+	return r.client.Update(collection, id, record, opts...)
+}
+
+// Delete calls Client.Delete and invalidates the collection's cached results.
+func (r *CachedReader) Delete(collection, id string, opts ...DeleteOptions) error {
+	defer r.Invalidate(collection)
+	return r.client.Delete(collection, id, opts...)
+}
+
+// BatchInsert calls Client.BatchInsert and invalidates the collection's
+// cached results.
+func (r *CachedReader) BatchInsert(collection string, records []Record, opts ...BatchInsertOptions) ([]Record, error) {
+	defer r.Invalidate(collection)
+	return r.client.BatchInsert(collection, records, opts...)
+}
+
+// BatchUpdate calls Client.BatchUpdate and invalidates the collection's
+// cached results.
+func (r *CachedReader) BatchUpdate(collection string, updates map[string]Record, opts ...BatchUpdateOptions) ([]Record, error) {
+	defer r.Invalidate(collection)
+	return r.client.BatchUpdate(collection, updates, opts...)
+}
+
+// BatchDelete calls Client.BatchDelete and invalidates the collection's
+// cached results.
+func (r *CachedReader) BatchDelete(collection string, ids []string, opts ...BatchDeleteOptions) (int, error) {
+	defer r.Invalidate(collection)
+	return r.client.BatchDelete(collection, ids, opts...)
+}
+
+// Watch invalidates cached results for each collection named by the
+// notifications received on events (from WebSocketClient.Subscribe or
+// SubscribeSSE), so writes made by other processes are reflected. It returns
+// when events is closed; run it in its own goroutine.
+func (r *CachedReader) Watch(events <-chan MutationNotification) {
+	for n := range events {
+		r.Invalidate(n.Collection)
+	}
+}
+
+// Invalidate drops every cached result for collection.
+func (r *CachedReader) Invalidate(collection string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key := range r.byCollection[collection] {
+		delete(r.entries, key)
+	}
+	delete(r.byCollection, collection)
+	r.generations[collection]++
+}
+
+// InvalidateAll drops every cached result.
+func (r *CachedReader) InvalidateAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = make(map[string]cachedResult)
+	r.byCollection = make(map[string]map[string]struct{})
+	r.order = nil
+	r.generation++
+}
+
+// Len returns the number of cached results, including expired entries not
+// yet evicted.
+func (r *CachedReader) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+func (r *CachedReader) lookup(key string) (cachedResult, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[key]
+	if !ok {
+		return cachedResult{}, false
+	}
+	if time.Since(entry.storedAt) > r.ttl {
+		r.drop(key)
+		return cachedResult{}, false
+	}
+	return entry, true
+}
+
+func (r *CachedReader) currentGeneration(collection string) cacheGeneration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return cacheGeneration{all: r.generation, collection: r.generations[collection]}
+}
+
+// store caches entry under key unless collection was invalidated since gen
+// was read, then sweeps expired entries and evicts the oldest over the cap.
+func (r *CachedReader) store(collection, key string, gen cacheGeneration, entry cachedResult) {
+	now := time.Now()
+	entry.collection = collection
+	entry.storedAt = now
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if gen != (cacheGeneration{all: r.generation, collection: r.generations[collection]}) {
+		return
+	}
+	r.entries[key] = entry
+	r.order = append(r.order, cacheSlot{key: key, storedAt: now})
+	keys, ok := r.byCollection[collection]
+	if !ok {
+		keys = make(map[string]struct{})
+		r.byCollection[collection] = keys
+	}
+	keys[key] = struct{}{}
+
+	i := 0
+	for ; i < len(r.order); i++ {
+		slot := r.order[i]
+		current, ok := r.entries[slot.key]
+		if !ok || !current.storedAt.Equal(slot.storedAt) {
+			continue
+		}
+		expired := now.Sub(slot.storedAt) > r.ttl
+		if !expired && (r.maxEntries == 0 || len(r.entries) <= r.maxEntries) {
+			break
+		}
+		r.drop(slot.key)
+	}
+	r.order = r.order[i:]
+	// Slots left stale by Invalidate or re-stores pile up behind live ones;
+	// compact once they outnumber the entries.
+	if len(r.order) > 2*len(r.entries)+16 {
+		live := make([]cacheSlot, 0, len(r.entries))
+		for _, slot := range r.order {
+			if current, ok := r.entries[slot.key]; ok && current.storedAt.Equal(slot.storedAt) {
+				live = append(live, slot)
+			}
+		}
+		r.order = live
+	}
+}
+
+// drop removes key's entry; r.mu must be held.
+func (r *CachedReader) drop(key string) {
+	entry, ok := r.entries[key]
+	if !ok {
+		return
+	}
+	delete(r.entries, key)
+	if keys := r.byCollection[entry.collection]; keys != nil {
+		delete(keys, key)
+		if len(keys) == 0 {
+			delete(r.byCollection, entry.collection)
+		}
+	}
+}
+
+// cacheKey hashes the operation, collection, and query. encoding/json sorts
+// map keys, so equal queries built in any order hash identically. A
+// *QueryBuilder is built first, since it has no exported fields to marshal.
+func cacheKey(op, collection string, query interface{}, opts interface{}) (string, error) {
+	query, err := builtQuery(query)
+	if err != nil {
+		return "", err
+	}
+	raw, err := json.Marshal([]interface{}{op, collection, query, opts})
+	if err != nil {
+		return "", fmt.Errorf("query is not cacheable: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// copyRecords returns a copy of records whose top-level maps are fresh, so
+// callers adding or removing fields don't alter the cached result. Nested
+// values are shared.
+func copyRecords(records []Record) []Record {
+	if records == nil {
+		return nil
+	}
+	out := make([]Record, len(records))
+	for i, rec := range records {
+		out[i] = copyRecord(rec)
+	}
+	return out
+}
+
+// copyRecord returns a copy of rec's top-level map; nested values are shared.
+func copyRecord(rec Record) Record {
+	out := make(Record, len(rec))
+	for k, v := range rec {
+		out[k] = v
+	}
+	return out
+}
+
+func copySearchResponse(resp *SearchResponse) *SearchResponse {
+	if resp == nil {
+		return nil
+	}
+	cp := *resp
+	cp.Results = make([]SearchResult, len(resp.Results))
+	for i, result := range resp.Results {
+		cp.Results[i] = result
+		if result.Record != nil {
+			rec := make(map[string]interface{}, len(result.Record))
+			for k, v := range result.Record {
+				rec[k] = v
+			}
+			cp.Results[i].Record = rec
+		}
+	}
+	return &cp
+}
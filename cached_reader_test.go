@@ -0,0 +1,220 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachedReaderFindHitsCache(t *testing.T) {
+	var finds int32
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&finds, 1)
+			_ = json.NewEncoder(w).Encode([]Record{{"id": "u1", "name": "Ann"}})
+		},
+	})
+	defer server.Close()
+
+	reader := NewCachedReader(createTestClient(t, server), time.Minute)
+	q1 := NewQueryBuilder().Eq("name", "Ann").Limit(5).Build()
+	q2 := NewQueryBuilder().Limit(5).Eq("name", "Ann").Build()
+
+	first, err := reader.Find("users", q1)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	first[0]["name"] = "mutated by caller"
+
+	second, err := reader.Find("users", q2)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&finds); got != 1 {
+		t.Errorf("expected 1 server find for identical queries, got %d", got)
+	}
+	if second[0]["name"] != "Ann" {
+		t.Errorf("cached result was altered by caller mutation: %v", second[0]["name"])
+	}
+
+	if _, err := reader.Find("users", NewQueryBuilder().Eq("name", "Bob").Limit(5).Build()); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&finds); got != 2 {
+		t.Errorf("a different query must miss the cache, got %d finds", got)
+	}
+
+	txID := "tx-1"
+	if _, err := reader.Find("users", q1, FindOptions{TransactionId: &txID}); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&finds); got != 3 {
+		t.Errorf("transactional reads must bypass the cache, got %d finds", got)
+	}
+}
+
+func TestCachedReaderInvalidation(t *testing.T) {
+	var finds int32
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&finds, 1)
+			_ = json.NewEncoder(w).Encode([]Record{{"id": "u1"}})
+		},
+		"POST /api/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(Record{"id": "u2"})
+		},
+	})
+	defer server.Close()
+
+	reader := NewCachedReader(createTestClient(t, server), time.Minute)
+	query := NewQueryBuilder().Limit(5).Build()
+
+	_, _ = reader.Find("users", query)
+	if _, err := reader.Insert("users", Record{"name": "new"}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	_, _ = reader.Find("users", query)
+	if got := atomic.LoadInt32(&finds); got != 2 {
+		t.Errorf("write through the reader must invalidate, got %d finds", got)
+	}
+
+	events := make(chan MutationNotification, 1)
+	events <- MutationNotification{Collection: "users", Event: "update"}
+	close(events)
+	reader.Watch(events)
+	_, _ = reader.Find("users", query)
+	if got := atomic.LoadInt32(&finds); got != 3 {
+		t.Errorf("a CDC notification must invalidate, got %d finds", got)
+	}
+	if reader.Len() != 1 {
+		t.Errorf("expected 1 cached entry, got %d", reader.Len())
+	}
+	reader.InvalidateAll()
+	if reader.Len() != 0 {
+		t.Errorf("expected empty cache, got %d", reader.Len())
+	}
+}
+
+func TestCachedReaderExpiry(t *testing.T) {
+	var searches int32
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/search/docs": func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&searches, 1)
+			_ = json.NewEncoder(w).Encode(SearchResponse{Results: []SearchResult{{Record: map[string]interface{}{"id": "d1"}, Score: 1}}, Total: 1})
+		},
+	})
+	defer server.Close()
+
+	reader := NewCachedReader(createTestClient(t, server), 20*time.Millisecond)
+	query := NewSearchQueryBuilder("hello").Build()
+
+	_, _ = reader.Search("docs", query)
+	_, _ = reader.Search("docs", query)
+	if got := atomic.LoadInt32(&searches); got != 1 {
+		t.Errorf("expected cached search, got %d", got)
+	}
+	time.Sleep(30 * time.Millisecond)
+	resp, err := reader.Search("docs", query)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&searches); got != 2 {
+		t.Errorf("expected expired entry to be refetched, got %d", got)
+	}
+	if resp.Total != 1 || resp.Results[0].Record["id"] != "d1" {
+		t.Errorf("unexpected search response: %+v", resp)
+	}
+}
+
+func TestCachedReaderSweepsAndCaps(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode([]Record{{"id": "u1"}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	reader := NewCachedReader(client, 20*time.Millisecond)
+	for i := 0; i < 3; i++ {
+		_, _ = reader.Find("users", NewQueryBuilder().Limit(i+1).Build())
+	}
+	time.Sleep(30 * time.Millisecond)
+	_, _ = reader.Find("users", NewQueryBuilder().Limit(10).Build())
+	if got := reader.Len(); got != 1 {
+		t.Errorf("expected expired entries to be swept, got %d cached", got)
+	}
+
+	capped := NewCachedReader(client, time.Minute, CachedReaderOptions{MaxEntries: 2})
+	for i := 0; i < 5; i++ {
+		_, _ = capped.Find("users", NewQueryBuilder().Limit(i+1).Build())
+	}
+	if got := capped.Len(); got != 2 {
+		t.Errorf("expected the cache to be capped at 2, got %d", got)
+	}
+}
+
+func TestCachedReaderSkipsResultRacingInvalidate(t *testing.T) {
+	var finds int32
+	var reader *CachedReader
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&finds, 1) == 1 {
+				// A write lands while the first find is in flight.
+				reader.Invalidate("users")
+			}
+			_ = json.NewEncoder(w).Encode([]Record{{"id": "u1"}})
+		},
+	})
+	defer server.Close()
+
+	reader = NewCachedReader(createTestClient(t, server), time.Minute)
+	query := NewQueryBuilder().Limit(5).Build()
+	if _, err := reader.Find("users", query); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if got := reader.Len(); got != 0 {
+		t.Errorf("a result fetched across Invalidate must not be cached, got %d entries", got)
+	}
+	_, _ = reader.Find("users", query)
+	_, _ = reader.Find("users", query)
+	if got := atomic.LoadInt32(&finds); got != 2 {
+		t.Errorf("expected 2 server finds, got %d", got)
+	}
+}
+
+func TestCachedReaderKeysBuilderQueries(t *testing.T) {
+	var finds int32
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&finds, 1)
+			_ = json.NewEncoder(w).Encode([]Record{{"id": fmt.Sprintf("u%d", n)}})
+		},
+	})
+	defer server.Close()
+
+	reader := NewCachedReader(createTestClient(t, server), time.Minute)
+	ann, err := reader.Find("users", NewQueryBuilder().Eq("name", "Ann"))
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	bob, err := reader.Find("users", NewQueryBuilder().Eq("name", "Bob"))
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if got := atomic.LoadInt32(&finds); got != 2 || ann[0]["id"] == bob[0]["id"] {
+		t.Errorf("different builder queries shared a cache entry: %d finds, %v and %v", got, ann, bob)
+	}
+	if reader.Len() != 2 {
+		t.Errorf("expected 2 cache entries, got %d", reader.Len())
+	}
+
+	var verr *QueryValidationError
+	if _, err := reader.Find("users", NewQueryBuilder().Limit(-1)); !errors.As(err, &verr) {
+		t.Errorf("expected *QueryValidationError, got %v", err)
+	}
+}
@@ -140,6 +140,11 @@ type ChatSession struct {
 	AgentID      *string            `json:"agent_id,omitempty"`
 	Title        *string            `json:"title,omitempty"`
 	MessageCount int                `json:"message_count"`
+	// ParentID and BranchPointIdx are set on sessions created by
+	// BranchChatSession: the session it was branched from, and the index of
+	// the parent's message the branch diverges after.
+	ParentID       *string `json:"parent_id,omitempty"`
+	BranchPointIdx *int    `json:"branch_point_idx,omitempty"`
 }
 
 // ChatSessionResponse represents a response containing session details
@@ -205,6 +210,43 @@ type MergeSessionsRequest struct {
 	TargetChatID  string        `json:"target_chat_id"`
 	MergeStrategy MergeStrategy `json:"merge_strategy"`
 	BypassRipple  *bool         `json:"bypass_ripple,omitempty"`
+	// DryRun computes the merge report without modifying any session.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// MergeConflict describes a message the server could not merge cleanly.
+type MergeConflict struct {
+	SourceChatID string `json:"source_chat_id"`
+	MessageID    string `json:"message_id,omitempty"`
+	Reason       string `json:"reason"`
+}
+
+// MergeReport details what a merge did, or would do for a dry run.
+type MergeReport struct {
+	// MessagesMerged counts the messages taken from each source session,
+	// keyed by chat ID.
+	MessagesMerged    map[string]int  `json:"messages_merged,omitempty"`
+	DuplicatesSkipped int             `json:"duplicates_skipped"`
+	SummaryGenerated  bool            `json:"summary_generated"`
+	Conflicts         []MergeConflict `json:"conflicts,omitempty"`
+}
+
+// TotalMerged returns the number of messages merged across all sources.
+func (r *MergeReport) TotalMerged() int {
+	total := 0
+	for _, n := range r.MessagesMerged {
+		total += n
+	}
+	return total
+}
+
+// MergeSessionsResponse is the result of MergeChatSessions: the target
+// session and a report of the merge. Report is zero when the server does not
+// provide one.
+type MergeSessionsResponse struct {
+	ChatSessionResponse
+	Report MergeReport `json:"report"`
+	DryRun bool        `json:"dry_run,omitempty"`
 }
 
 // CompactChatRequest is the request body for POST /api/chat/{chat_id}/compact.
@@ -273,7 +315,8 @@ type RawCompletionResponse struct {
 //	    SystemPrompt: "You are a helpful assistant.",
 //	    Message:      "Summarize this in JSON.",
 //	})
-func (c *Client) RawCompletion(request RawCompletionRequest) (*RawCompletionResponse, error) {
+func (c *Client) RawCompletion(request RawCompletionRequest, opts ...RequestOption) (*RawCompletionResponse, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", "/api/chat/complete", request)
 	if err != nil {
 		return nil, err
@@ -292,17 +335,19 @@ func (c *Client) RawCompletion(request RawCompletionRequest) (*RawCompletionResp
 // Same as RawCompletion but uses Server-Sent Events to keep the connection alive.
 // Preferred for deployed instances where reverse proxies may kill idle HTTP
 // connections before the LLM responds.
-func (c *Client) RawCompletionStream(request RawCompletionRequest) (*RawCompletionResponse, error) {
+func (c *Client) RawCompletionStream(request RawCompletionRequest, opts ...RequestOption) (*RawCompletionResponse, error) {
+	c = c.withOptions(opts)
 	// Serialize request body as JSON
 	bodyBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", c.baseURL+"/api/chat/complete/stream", bytes.NewReader(bodyBytes))
+	req, err := http.NewRequestWithContext(c.context(), "POST", c.baseURL+"/api/chat/complete/stream", bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	c.applyHeaders(req)
 	token := c.getToken()
 	if token == "" {
 		if err := c.refreshToken(); err != nil {
@@ -366,16 +411,18 @@ func (c *Client) RawCompletionStream(request RawCompletionRequest) (*RawCompleti
 //
 // Same as RawCompletionStream but provides incremental progress via the callback,
 // allowing callers to show real-time output during long-running LLM calls.
-func (c *Client) RawCompletionStreamWithProgress(request RawCompletionRequest, onToken func(string)) (*RawCompletionResponse, error) {
+func (c *Client) RawCompletionStreamWithProgress(request RawCompletionRequest, onToken func(string), opts ...RequestOption) (*RawCompletionResponse, error) {
+	c = c.withOptions(opts)
 	bodyBytes, err := json.Marshal(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", c.baseURL+"/api/chat/complete/stream", bytes.NewReader(bodyBytes))
+	req, err := http.NewRequestWithContext(c.context(), "POST", c.baseURL+"/api/chat/complete/stream", bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	c.applyHeaders(req)
 	token := c.getToken()
 	if token == "" {
 		if err := c.refreshToken(); err != nil {
@@ -438,7 +485,8 @@ func (c *Client) RawCompletionStreamWithProgress(request RawCompletionRequest, o
 
 // SubmitChatToolResult submits a client tool result for an in-flight SSE chat stream.
 // This unblocks ekoDB's tool loop so it can feed the result to the LLM.
-func (c *Client) SubmitChatToolResult(sessionID, callID string, success bool, result interface{}, errMsg string) error {
+func (c *Client) SubmitChatToolResult(sessionID, callID string, success bool, result interface{}, errMsg string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	body := map[string]interface{}{
 		"call_id": callID,
 		"success": success,
@@ -460,7 +508,8 @@ func (c *Client) SubmitChatToolResult(sessionID, callID string, success bool, re
 // tool doesn't get the turn timed out mid-response. Send it periodically while the
 // tool is still working, then call SubmitChatToolResult once when it completes
 // (pairs with ekoDB#530).
-func (c *Client) SubmitChatToolKeepalive(sessionID, callID string) error {
+func (c *Client) SubmitChatToolKeepalive(sessionID, callID string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	body := map[string]interface{}{
 		"call_id":   callID,
 		"keepalive": true,
@@ -493,7 +542,8 @@ type ExecuteToolResult struct {
 //
 // Returns the tool result if executed, nil if the server doesn't
 // support the endpoint (older ekoDB versions), or an error.
-func (c *Client) ExecuteTool(toolName string, params map[string]interface{}, sessionID string) (map[string]interface{}, error) {
+func (c *Client) ExecuteTool(toolName string, params map[string]interface{}, sessionID string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	if params == nil {
 		params = map[string]interface{}{}
 	}
@@ -532,7 +582,8 @@ func (c *Client) ExecuteTool(toolName string, params map[string]interface{}, ses
 // GetChatTools retrieves all built-in server-side chat tool definitions.
 // Returns a slice of tool objects with name, description, and parameters fields.
 // Used by planning agents to discover available tools dynamically.
-func (c *Client) GetChatTools() ([]map[string]interface{}, error) {
+func (c *Client) GetChatTools(opts ...RequestOption) ([]map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/chat/tools", nil)
 	if err != nil {
 		return nil, err
@@ -547,7 +598,8 @@ func (c *Client) GetChatTools() ([]map[string]interface{}, error) {
 }
 
 // GetChatModels retrieves all available chat models from all providers
-func (c *Client) GetChatModels() (*ChatModels, error) {
+func (c *Client) GetChatModels(opts ...RequestOption) (*ChatModels, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/chat_models", nil)
 	if err != nil {
 		return nil, err
@@ -562,7 +614,8 @@ func (c *Client) GetChatModels() (*ChatModels, error) {
 }
 
 // GetChatModel retrieves available models for a specific provider
-func (c *Client) GetChatModel(providerName string) ([]string, error) {
+func (c *Client) GetChatModel(providerName string, opts ...RequestOption) ([]string, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/chat_models/%s", url.PathEscape(providerName)), nil)
 	if err != nil {
 		return nil, err
@@ -577,7 +630,8 @@ func (c *Client) GetChatModel(providerName string) ([]string, error) {
 }
 
 // CreateChatSession creates a new chat session
-func (c *Client) CreateChatSession(request CreateChatSessionRequest) (*ChatResponse, error) {
+func (c *Client) CreateChatSession(request CreateChatSessionRequest, opts ...RequestOption) (*ChatResponse, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", "/api/chat", request)
 	if err != nil {
 		return nil, err
@@ -592,7 +646,8 @@ func (c *Client) CreateChatSession(request CreateChatSessionRequest) (*ChatRespo
 }
 
 // ChatMessage sends a message in an existing chat session
-func (c *Client) ChatMessage(sessionID string, request ChatMessageRequest) (*ChatResponse, error) {
+func (c *Client) ChatMessage(sessionID string, request ChatMessageRequest, opts ...RequestOption) (*ChatResponse, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/%s/messages", url.PathEscape(sessionID)), request)
 	if err != nil {
 		return nil, err
@@ -607,7 +662,8 @@ func (c *Client) ChatMessage(sessionID string, request ChatMessageRequest) (*Cha
 }
 
 // GetChatSession gets a chat session by ID
-func (c *Client) GetChatSession(sessionID string) (*ChatSessionResponse, error) {
+func (c *Client) GetChatSession(sessionID string, opts ...RequestOption) (*ChatSessionResponse, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/chat/%s", url.PathEscape(sessionID)), nil)
 	if err != nil {
 		return nil, err
@@ -622,7 +678,8 @@ func (c *Client) GetChatSession(sessionID string) (*ChatSessionResponse, error)
 }
 
 // ListChatSessions lists all chat sessions
-func (c *Client) ListChatSessions(query *ListSessionsQuery) (*ListSessionsResponse, error) {
+func (c *Client) ListChatSessions(query *ListSessionsQuery, opts ...RequestOption) (*ListSessionsResponse, error) {
+	c = c.withOptions(opts)
 	path := "/api/chat"
 
 	if query != nil {
@@ -655,7 +712,8 @@ func (c *Client) ListChatSessions(query *ListSessionsQuery) (*ListSessionsRespon
 }
 
 // GetChatSessionMessages gets messages from a chat session
-func (c *Client) GetChatSessionMessages(sessionID string, query *GetMessagesQuery) (*GetMessagesResponse, error) {
+func (c *Client) GetChatSessionMessages(sessionID string, query *GetMessagesQuery, opts ...RequestOption) (*GetMessagesResponse, error) {
+	c = c.withOptions(opts)
 	path := fmt.Sprintf("/api/chat/%s/messages", url.PathEscape(sessionID))
 
 	if query != nil {
@@ -688,7 +746,8 @@ func (c *Client) GetChatSessionMessages(sessionID string, query *GetMessagesQuer
 }
 
 // UpdateChatSession updates a chat session
-func (c *Client) UpdateChatSession(sessionID string, request UpdateSessionRequest) (*ChatSessionResponse, error) {
+func (c *Client) UpdateChatSession(sessionID string, request UpdateSessionRequest, opts ...RequestOption) (*ChatSessionResponse, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("PUT", fmt.Sprintf("/api/chat/%s", url.PathEscape(sessionID)), request)
 	if err != nil {
 		return nil, err
@@ -703,7 +762,8 @@ func (c *Client) UpdateChatSession(sessionID string, request UpdateSessionReques
 }
 
 // BranchChatSession branches a chat session
-func (c *Client) BranchChatSession(request CreateChatSessionRequest) (*ChatResponse, error) {
+func (c *Client) BranchChatSession(request CreateChatSessionRequest, opts ...RequestOption) (*ChatResponse, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", "/api/chat/branch", request)
 	if err != nil {
 		return nil, err
@@ -718,13 +778,15 @@ func (c *Client) BranchChatSession(request CreateChatSessionRequest) (*ChatRespo
 }
 
 // DeleteChatSession deletes a chat session
-func (c *Client) DeleteChatSession(sessionID string) error {
+func (c *Client) DeleteChatSession(sessionID string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", fmt.Sprintf("/api/chat/%s", url.PathEscape(sessionID)), nil)
 	return err
 }
 
 // RegenerateChatMessage regenerates an AI response message
-func (c *Client) RegenerateChatMessage(sessionID, messageID string) (*ChatResponse, error) {
+func (c *Client) RegenerateChatMessage(sessionID, messageID string, opts ...RequestOption) (*ChatResponse, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/%s/messages/%s/regenerate", url.PathEscape(sessionID), url.PathEscape(messageID)), nil)
 	if err != nil {
 		return nil, err
@@ -739,14 +801,16 @@ func (c *Client) RegenerateChatMessage(sessionID, messageID string) (*ChatRespon
 }
 
 // UpdateChatMessage updates a specific message
-func (c *Client) UpdateChatMessage(sessionID, messageID, content string) error {
+func (c *Client) UpdateChatMessage(sessionID, messageID, content string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	request := map[string]string{"content": content}
 	_, err := c.makeRequest("PUT", fmt.Sprintf("/api/chat/%s/messages/%s", url.PathEscape(sessionID), url.PathEscape(messageID)), request)
 	return err
 }
 
 // GetChatMessage gets a specific message by ID
-func (c *Client) GetChatMessage(sessionID, messageID string) (Record, error) {
+func (c *Client) GetChatMessage(sessionID, messageID string, opts ...RequestOption) (Record, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/chat/%s/messages/%s", url.PathEscape(sessionID), url.PathEscape(messageID)), nil)
 	if err != nil {
 		return nil, err
@@ -761,26 +825,31 @@ func (c *Client) GetChatMessage(sessionID, messageID string) (Record, error) {
 }
 
 // DeleteChatMessage deletes a specific message
-func (c *Client) DeleteChatMessage(sessionID, messageID string) error {
+func (c *Client) DeleteChatMessage(sessionID, messageID string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", fmt.Sprintf("/api/chat/%s/messages/%s", url.PathEscape(sessionID), url.PathEscape(messageID)), nil)
 	return err
 }
 
 // ToggleForgottenMessage toggles the "forgotten" status of a message
-func (c *Client) ToggleForgottenMessage(sessionID, messageID string, forgotten bool) error {
+func (c *Client) ToggleForgottenMessage(sessionID, messageID string, forgotten bool, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	request := map[string]bool{"forgotten": forgotten}
 	_, err := c.makeRequest("PATCH", fmt.Sprintf("/api/chat/%s/messages/%s/forgotten", url.PathEscape(sessionID), url.PathEscape(messageID)), request)
 	return err
 }
 
-// MergeChatSessions merges multiple chat sessions into one
-func (c *Client) MergeChatSessions(request MergeSessionsRequest) (*ChatSessionResponse, error) {
+// MergeChatSessions merges multiple chat sessions into one and reports what
+// was merged. Set request.DryRun, or use PreviewMergeChatSessions, to get the
+// report without changing any session.
+func (c *Client) MergeChatSessions(request MergeSessionsRequest, opts ...RequestOption) (*MergeSessionsResponse, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", "/api/chat/merge", request)
 	if err != nil {
 		return nil, err
 	}
 
-	var result ChatSessionResponse
+	var result MergeSessionsResponse
 	if err := json.Unmarshal(respBody, &result); err != nil {
 		return nil, err
 	}
@@ -788,13 +857,26 @@ func (c *Client) MergeChatSessions(request MergeSessionsRequest) (*ChatSessionRe
 	return &result, nil
 }
 
+// PreviewMergeChatSessions returns the report MergeChatSessions would
+// produce for request without performing the merge.
+func (c *Client) PreviewMergeChatSessions(request MergeSessionsRequest, opts ...RequestOption) (*MergeReport, error) {
+	c = c.withOptions(opts)
+	request.DryRun = true
+	result, err := c.MergeChatSessions(request)
+	if err != nil {
+		return nil, err
+	}
+	return &result.Report, nil
+}
+
 // CompactChat compacts a chat session's history on demand, folding older
 // messages into a summary while keeping the most recent ones intact.
 // Calls POST /api/chat/{chat_id}/compact.
 //
 // keepRecent optionally overrides how many recent messages to keep verbatim;
 // pass nil to use the server default.
-func (c *Client) CompactChat(sessionID string, keepRecent *int) (*CompactChatResponse, error) {
+func (c *Client) CompactChat(sessionID string, keepRecent *int, opts ...RequestOption) (*CompactChatResponse, error) {
+	c = c.withOptions(opts)
 	request := CompactChatRequest{KeepRecent: keepRecent}
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/%s/compact", url.PathEscape(sessionID)), request)
 	if err != nil {
@@ -837,7 +919,8 @@ func (c *Client) CompactChat(sessionID string, keepRecent *int) (*CompactChatRes
 //	        fmt.Println("Error:", event.Error)
 //	    }
 //	}
-func (c *Client) ChatMessageStream(ctx context.Context, sessionID string, request ChatMessageRequest) (chan ChatStreamEvent, error) {
+func (c *Client) ChatMessageStream(ctx context.Context, sessionID string, request ChatMessageRequest, opts ...RequestOption) (chan ChatStreamEvent, error) {
+	c = c.withOptions(opts)
 	// Guard against a nil context: NewRequestWithContext and the send helper's
 	// ctx.Done() would both panic on nil. Treat it as Background().
 	if ctx == nil {
@@ -853,6 +936,7 @@ func (c *Client) ChatMessageStream(ctx context.Context, sessionID string, reques
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	c.applyHeaders(req)
 	token := c.getToken()
 	if token == "" {
 		if err := c.refreshToken(); err != nil {
@@ -977,7 +1061,8 @@ type SSESubscription struct {
 //
 // Use this when WebSocket connections aren't available (e.g. behind reverse
 // proxies that block WS upgrades).
-func (c *Client) SubscribeSSE(ctx context.Context, collection string, opts *SubscribeSSEOptions) (*SSESubscription, error) {
+func (c *Client) SubscribeSSE(ctx context.Context, collection string, opts *SubscribeSSEOptions, reqOpts ...RequestOption) (*SSESubscription, error) {
+	c = c.withOptions(reqOpts)
 	sseURL := c.baseURL + "/api/subscribe/" + url.PathEscape(collection)
 	if opts != nil {
 		params := url.Values{}
@@ -1004,6 +1089,7 @@ func (c *Client) SubscribeSSE(ctx context.Context, collection string, opts *Subs
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SSE request: %w", err)
 	}
+	c.applyHeaders(req)
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Authorization", "Bearer "+token)
 
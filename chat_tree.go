@@ -0,0 +1,192 @@
+package ekodb
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+)
+
+// ChatSessionNode is a session in a branch hierarchy returned by
+// GetChatSessionTree.
+type ChatSessionNode struct {
+	Session ChatSession `json:"session"`
+	// Parent is nil for the root of the tree.
+	Parent *ChatSessionNode `json:"-"`
+	// Children are the sessions branched from this one, oldest first.
+	Children []*ChatSessionNode `json:"children,omitempty"`
+}
+
+// ID returns the session's chat ID.
+func (n *ChatSessionNode) ID() string {
+	return n.Session.ChatID
+}
+
+// Title returns the session's title, or "" when it has none.
+func (n *ChatSessionNode) Title() string {
+	if n.Session.Title == nil {
+		return ""
+	}
+	return *n.Session.Title
+}
+
+// BranchPoint returns the index of the parent's message this session
+// branched after, and false for the root.
+func (n *ChatSessionNode) BranchPoint() (int, bool) {
+	if n.Parent == nil || n.Session.BranchPointIdx == nil {
+		return 0, false
+	}
+	return *n.Session.BranchPointIdx, true
+}
+
+// Find returns the node for chatID within this subtree, or nil.
+func (n *ChatSessionNode) Find(chatID string) *ChatSessionNode {
+	if n.Session.ChatID == chatID {
+		return n
+	}
+	for _, child := range n.Children {
+		if found := child.Find(chatID); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Walk calls fn for every node of the subtree in depth-first order, with the
+// node's depth below n (n itself is 0).
+func (n *ChatSessionNode) Walk(fn func(node *ChatSessionNode, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *ChatSessionNode) walk(fn func(*ChatSessionNode, int), depth int) {
+	fn(n, depth)
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// chatTreePageSize is the page size used to list sessions and messages when
+// assembling trees and diffs.
+const chatTreePageSize = 100
+
+// GetChatSessionTree returns the branch hierarchy under rootID: every session
+// created from it with BranchChatSession, recursively, linked through their
+// parent IDs and branch points. It lists all chat sessions to find the
+// branches, so it is meant for building navigation UIs rather than hot
+// paths.
+//
+// Example — print an indented outline:
+//
+//	tree, err := client.GetChatSessionTree(chatID)
+//	tree.Walk(func(n *ChatSessionNode, depth int) {
+//	    fmt.Printf("%s%s %q\n", strings.Repeat("  ", depth), n.ID(), n.Title())
+//	})
+func (c *Client) GetChatSessionTree(rootID string, opts ...RequestOption) (*ChatSessionNode, error) {
+	c = c.withOptions(opts)
+	var sessions []ChatSession
+	for skip := 0; ; skip += chatTreePageSize {
+		limit, offset := chatTreePageSize, skip
+		page, err := c.ListChatSessions(&ListSessionsQuery{Limit: &limit, Skip: &offset})
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, page.Sessions...)
+		if len(page.Sessions) < chatTreePageSize || (page.Total > 0 && len(sessions) >= page.Total) {
+			break
+		}
+	}
+	return buildChatSessionTree(rootID, sessions)
+}
+
+// buildChatSessionTree links sessions by parent ID and returns the subtree
+// rooted at rootID.
+func buildChatSessionTree(rootID string, sessions []ChatSession) (*ChatSessionNode, error) {
+	nodes := make(map[string]*ChatSessionNode, len(sessions))
+	for _, s := range sessions {
+		nodes[s.ChatID] = &ChatSessionNode{Session: s}
+	}
+	root, ok := nodes[rootID]
+	if !ok {
+		return nil, &HTTPError{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("chat session %s not found", rootID),
+		}
+	}
+
+	for _, s := range sessions {
+		if s.ParentID == nil || s.ChatID == rootID {
+			continue
+		}
+		node, parent := nodes[s.ChatID], nodes[*s.ParentID]
+		if parent == nil {
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+	root.Parent = nil
+
+	for _, node := range nodes {
+		sort.SliceStable(node.Children, func(i, j int) bool {
+			return node.Children[i].Session.CreatedAt < node.Children[j].Session.CreatedAt
+		})
+	}
+	return root, nil
+}
+
+// ChatBranchDiff compares the message histories of two sessions, typically
+// two branches of the same conversation.
+type ChatBranchDiff struct {
+	// Common is the shared history, as stored in the first session.
+	Common []Record
+	// OnlyA and OnlyB are the messages after the sessions diverge.
+	OnlyA []Record
+	OnlyB []Record
+}
+
+// DiffChatBranches returns the messages chatA and chatB share and the ones
+// unique to each. Branches hold copies of their parent's messages, so
+// messages are matched by role and content rather than by ID, and the shared
+// history is the longest common prefix.
+func (c *Client) DiffChatBranches(chatA, chatB string, opts ...RequestOption) (*ChatBranchDiff, error) {
+	c = c.withOptions(opts)
+	a, err := c.allChatMessages(chatA)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.allChatMessages(chatB)
+	if err != nil {
+		return nil, err
+	}
+	return diffChatMessages(a, b), nil
+}
+
+// allChatMessages pages through every message of a session.
+func (c *Client) allChatMessages(chatID string) ([]Record, error) {
+	var messages []Record
+	for skip := 0; ; skip += chatTreePageSize {
+		limit, offset := chatTreePageSize, skip
+		page, err := c.GetChatSessionMessages(chatID, &GetMessagesQuery{Limit: &limit, Skip: &offset})
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, page.Messages...)
+		if len(page.Messages) < chatTreePageSize || (page.Total > 0 && len(messages) >= page.Total) {
+			return messages, nil
+		}
+	}
+}
+
+func diffChatMessages(a, b []Record) *ChatBranchDiff {
+	n := 0
+	for n < len(a) && n < len(b) && sameChatMessage(a[n], b[n]) {
+		n++
+	}
+	return &ChatBranchDiff{Common: a[:n], OnlyA: a[n:], OnlyB: b[n:]}
+}
+
+// sameChatMessage reports whether two messages have the same role and
+// content.
+func sameChatMessage(a, b Record) bool {
+	return reflect.DeepEqual(a["role"], b["role"]) && reflect.DeepEqual(a["content"], b["content"])
+}
@@ -0,0 +1,138 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetChatSessionTree(t *testing.T) {
+	sessions := []map[string]interface{}{
+		{"chat_id": "root", "title": "Trip ideas", "created_at": "2026-01-01T00:00:00Z"},
+		{"chat_id": "b2", "parent_id": "root", "branch_point_idx": 3, "created_at": "2026-01-03T00:00:00Z"},
+		{"chat_id": "b1", "parent_id": "root", "branch_point_idx": 1, "created_at": "2026-01-02T00:00:00Z"},
+		{"chat_id": "b1a", "parent_id": "b1", "branch_point_idx": 2, "created_at": "2026-01-04T00:00:00Z"},
+		{"chat_id": "other", "created_at": "2026-01-01T00:00:00Z"},
+	}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/chat": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("skip") != "0" {
+				t.Errorf("expected a single page, got skip=%s", r.URL.Query().Get("skip"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"sessions": sessions, "total": len(sessions)})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	tree, err := client.GetChatSessionTree("root")
+	if err != nil {
+		t.Fatalf("GetChatSessionTree failed: %v", err)
+	}
+	if tree.Title() != "Trip ideas" || tree.Parent != nil {
+		t.Errorf("unexpected root: %+v", tree)
+	}
+	var outline []string
+	tree.Walk(func(n *ChatSessionNode, depth int) {
+		outline = append(outline, strings.Repeat("-", depth)+n.ID())
+	})
+	if got := strings.Join(outline, " "); got != "root -b1 --b1a -b2" {
+		t.Errorf("outline = %q", got)
+	}
+
+	b1a := tree.Find("b1a")
+	if b1a == nil || b1a.Parent.ID() != "b1" {
+		t.Fatalf("b1a not linked to b1: %+v", b1a)
+	}
+	if idx, ok := b1a.BranchPoint(); !ok || idx != 2 {
+		t.Errorf("BranchPoint = %d, %v", idx, ok)
+	}
+	if _, ok := tree.BranchPoint(); ok {
+		t.Error("root should have no branch point")
+	}
+
+	if _, err := client.GetChatSessionTree("missing"); err == nil {
+		t.Error("expected an error for an unknown root")
+	} else if httpErr, ok := err.(*HTTPError); !ok || !httpErr.IsNotFound() {
+		t.Errorf("expected HTTPError 404, got %v", err)
+	}
+}
+
+func TestDiffChatBranches(t *testing.T) {
+	msg := func(role, content string) map[string]interface{} {
+		return map[string]interface{}{"role": role, "content": content}
+	}
+	messagesHandler := func(messages ...map[string]interface{}) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"messages": messages, "total": len(messages)})
+		}
+	}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/chat/main/messages": messagesHandler(
+			msg("user", "Plan a trip"), msg("assistant", "Where to?"), msg("user", "Japan"),
+		),
+		"GET /api/chat/branch/messages": messagesHandler(
+			msg("user", "Plan a trip"), msg("assistant", "Where to?"), msg("user", "Italy"), msg("assistant", "Rome it is"),
+		),
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	diff, err := client.DiffChatBranches("main", "branch")
+	if err != nil {
+		t.Fatalf("DiffChatBranches failed: %v", err)
+	}
+	if len(diff.Common) != 2 || len(diff.OnlyA) != 1 || len(diff.OnlyB) != 2 {
+		t.Fatalf("unexpected diff sizes: common=%d a=%d b=%d", len(diff.Common), len(diff.OnlyA), len(diff.OnlyB))
+	}
+	if diff.OnlyA[0]["content"] != "Japan" || diff.OnlyB[0]["content"] != "Italy" {
+		t.Errorf("unexpected divergence: %v / %v", diff.OnlyA[0], diff.OnlyB[0])
+	}
+}
+
+func TestMergeChatSessionsReport(t *testing.T) {
+	var dryRuns []bool
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/chat/merge": func(w http.ResponseWriter, r *http.Request) {
+			var req MergeSessionsRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			dryRuns = append(dryRuns, req.DryRun)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"session":       map[string]interface{}{"chat_id": "target"},
+				"message_count": 7,
+				"dry_run":       req.DryRun,
+				"report": map[string]interface{}{
+					"messages_merged":    map[string]int{"a": 3, "b": 2},
+					"duplicates_skipped": 1,
+					"summary_generated":  false,
+					"conflicts": []map[string]string{
+						{"source_chat_id": "b", "message_id": "m9", "reason": "tool call without result"},
+					},
+				},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	request := MergeSessionsRequest{SourceChatIDs: []string{"a", "b"}, TargetChatID: "target", MergeStrategy: MergeStrategyChronological}
+	report, err := client.PreviewMergeChatSessions(request)
+	if err != nil {
+		t.Fatalf("PreviewMergeChatSessions failed: %v", err)
+	}
+	if report.TotalMerged() != 5 || report.DuplicatesSkipped != 1 || len(report.Conflicts) != 1 || report.Conflicts[0].MessageID != "m9" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	result, err := client.MergeChatSessions(request)
+	if err != nil {
+		t.Fatalf("MergeChatSessions failed: %v", err)
+	}
+	if result.MessageCount != 7 || result.Session["chat_id"] != "target" || result.DryRun {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(dryRuns) != 2 || !dryRuns[0] || dryRuns[1] {
+		t.Errorf("dry_run sent as %v, want [true false]", dryRuns)
+	}
+}
@@ -3,6 +3,8 @@ package ekodb
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -81,6 +83,27 @@ func (e *HTTPError) IsNotFound() bool {
 	return e.StatusCode == 404
 }
 
+// IsConflict checks if the error is a 409 Conflict error (e.g. a unique index
+// violation or a transaction commit conflict)
+func (e *HTTPError) IsConflict() bool {
+	return e.StatusCode == 409
+}
+
+// AlreadyExistsError is returned by InsertUnique when a record with the same
+// natural key is already stored in the collection.
+type AlreadyExistsError struct {
+	Collection string
+	KeyFields  []string
+	ExistingID string // ID of the record already holding the key ("" if unknown)
+}
+
+func (e *AlreadyExistsError) Error() string {
+	if e.ExistingID != "" {
+		return fmt.Sprintf("record with key (%s) already exists in %s: %s", strings.Join(e.KeyFields, ", "), e.Collection, e.ExistingID)
+	}
+	return fmt.Sprintf("record with key (%s) already exists in %s", strings.Join(e.KeyFields, ", "), e.Collection)
+}
+
 // ClientConfig contains configuration options for the client
 type ClientConfig struct {
 	BaseURL     string              // Base URL of the ekoDB server
@@ -89,6 +112,136 @@ type ClientConfig struct {
 	MaxRetries  int                 // Maximum number of retry attempts (default: 3)
 	Timeout     time.Duration       // Request timeout (default: 30s)
 	Format      SerializationFormat // Serialization format (default: MessagePack for best performance, use JSON for debugging)
+
+	// ContextHeaders maps an outbound header name to a context key. When a
+	// request runs under a context (see Client.WithContext) carrying a value for
+	// that key, the value is sent as the named header — e.g. propagating a
+	// user ID, trace ID, or locale from an HTTP handler into every ekoDB call.
+	// Keys with no value in the context are skipped.
+	ContextHeaders map[string]interface{}
+
+	// DefaultHeaders are sent with every HTTP request and the WebSocket
+	// handshake, e.g. a tenant or deployment identifier for server-side logs
+	// and WAF rules. Every request also carries "User-Agent:
+	// ekodb-client-go/<version>"; setting User-Agent here replaces it.
+	// Authorization, Content-Type, and Accept are managed by the client and
+	// cannot be overridden.
+	DefaultHeaders map[string]string
+
+	// Transport tuning. Zero values keep the net/http defaults
+	// (http.DefaultTransport), so only set what you need to change.
+	MaxIdleConns        int           // Idle connections kept across all hosts (default: 100)
+	MaxIdleConnsPerHost int           // Idle connections kept per host (default: 2)
+	MaxConnsPerHost     int           // Cap on dialing+active+idle connections per host (default: unlimited)
+	IdleConnTimeout     time.Duration // How long an idle connection stays pooled (default: 90s)
+	DisableKeepAlives   bool          // Open a new connection for every request
+	DisableHTTP2        bool          // Negotiate HTTP/1.1 only (HTTP/2 is attempted over TLS by default)
+
+	// RetryClassifier customizes which failed requests are retried (see
+	// RetryClassifier). Nil keeps the built-in policy: network errors, 429,
+	// and 503.
+	RetryClassifier RetryClassifier
+
+	// RateLimitQueueDepth enables a shared queue for rate limiting. When the
+	// server answers 429, or reports no requests remaining in the current
+	// window, every request made through the client (and clients derived from
+	// it) waits until the limit resets instead of being sent, and retries of
+	// the rate-limited requests wait with them rather than sleeping on their
+	// own. At most this many requests wait at once; further requests fail
+	// immediately with ErrQueueFull. Zero (the default) disables the queue.
+	RateLimitQueueDepth int
+
+	// MaxConcurrency caps the requests a fan-out helper (such as
+	// SearchCollections) has in flight at once (default: 4). It does not limit
+	// concurrent calls made by the application itself.
+	MaxConcurrency int
+
+	// AutoExtract unwraps typed field values ({"type": ..., "value": ...}) in
+	// every record the client returns, as ExtractRecord does, so application
+	// code sees plain values. It applies to records from the CRUD and Find
+	// methods, Search, FindEach, and SearchEach. WithAutoExtract overrides it
+	// per derived client.
+	AutoExtract bool
+
+	// UseNumber decodes numbers in JSON record responses (CRUD, Find, Search,
+	// FindEach, and SearchEach) as json.Number instead of float64, so large
+	// int64 values such as IDs keep their exact value. The Get*Value helpers,
+	// Record accessors, and UnmarshalRecord all accept json.Number, and
+	// records read this way can be written back unchanged. MessagePack
+	// responses already keep integers exact.
+	UseNumber bool
+
+	// CompressVectors sends Vector fields, search query vectors, and function
+	// parameters as base64-packed float32s instead of float64 arrays, and
+	// unpacks packed vectors in responses transparently. The client asks the
+	// server at construction time and only enables this if the server
+	// advertises the "packed_vectors" feature. Packing narrows elements to
+	// float32.
+	CompressVectors bool
+
+	// ValidateVectors checks the dimension of Vector fields in Insert, Update,
+	// Upsert, BatchInsert, and BatchUpdate records, and of Search query
+	// vectors, against the dimensions configured on the collection's vector
+	// indexes (see FieldTypeSchemaBuilder.Dimensions). A mismatch fails with a
+	// *VectorDimensionError before anything is sent. Index dimensions are
+	// fetched with GetSchema and cached for five minutes per collection.
+	ValidateVectors bool
+
+	// ValidateRecords checks Insert, Update, Upsert, BatchInsert, and
+	// BatchUpdate records against the collection's schema (required fields,
+	// enums, min/max, and regex) before sending them, failing with a
+	// *ValidationError that lists every violation instead of the server's
+	// 400. Updates are partial, so missing required fields are only reported
+	// for inserts. Schemas are cached as for ValidateVectors; collections
+	// without a schema are not checked.
+	ValidateRecords bool
+
+	// ValidateRequests is a development mode that checks every outgoing
+	// request's path, query parameters, and body against the server's OpenAPI
+	// spec before sending it, failing with a *RequestValidationError that
+	// lists each mismatch. It catches client/server version drift early;
+	// leave it off in production, where it only adds overhead. The spec is
+	// OpenAPISpec if set, otherwise it is fetched from the server when the
+	// client is created.
+	ValidateRequests bool
+
+	// OpenAPISpec is the OpenAPI 3 JSON document ValidateRequests checks
+	// against, e.g. one bundled with the application for offline tests.
+	OpenAPISpec []byte
+
+	// CleanupOrphansOnStart, when positive, runs CleanupOrphans in the
+	// background as soon as the client is created, removing temporary
+	// collections and functions older than this that earlier processes left
+	// behind.
+	CleanupOrphansOnStart time.Duration
+
+	// Logger receives the client's diagnostic lines (retries, rate-limit
+	// warnings, token refreshes). Nil uses the standard log package; use
+	// log.New(io.Discard, "", 0) to silence them.
+	Logger *log.Logger
+
+	// Token seeds the client with a previously issued JWT, e.g. one cached by
+	// another process via OnTokenRefresh. If it is not about to expire, the
+	// client skips fetching a token at construction; an expired or rejected
+	// token is replaced using APIKey as usual.
+	Token string
+
+	// OnTokenRefresh is called with every token the client obtains from the
+	// server, and its expiry, so it can be cached or shared. It is not called
+	// for a seeded Token.
+	OnTokenRefresh func(token string, expiresAt time.Time)
+
+	// OnAuthFailure is called when authentication fails permanently: the
+	// server rejects the API key (401/403), or a request is still unauthorized
+	// after a token refresh. Transient failures such as network errors do not
+	// trigger it.
+	OnAuthFailure func(err error)
+
+	// TLSConfig customizes TLS for HTTPS and WSS connections: client
+	// certificates for mutual TLS, a private root CA pool, or ServerName for
+	// SNI. It is cloned, so later changes to the caller's value have no effect.
+	// Nil uses the system defaults.
+	TLSConfig *tls.Config
 }
 
 // Client represents an ekoDB client
@@ -104,8 +257,34 @@ type Client struct {
 	maxRetries    int
 	format        SerializationFormat
 	rateLimitInfo *RateLimitInfo
-	rateLimitMu   sync.RWMutex // Guards rateLimitInfo (written per response, read by callers)
-	schemaCache   *SchemaCache // Optional schema cache for primary_key_alias resolution
+	rateLimitMu   sync.RWMutex     // Guards rateLimitInfo (written per response, read by callers)
+	schemaCache   *SchemaCache     // Optional schema cache for primary_key_alias resolution
+	softSchema    *softSchemaState // Set by EnableSoftSchema; nil keeps strict behavior
+
+	contextHeaders    map[string]interface{}  // Header name -> context key (see ClientConfig.ContextHeaders)
+	defaultHeaders    map[string]string       // Static headers sent on every request (see ClientConfig.DefaultHeaders)
+	logger            *log.Logger             // Nil means the standard logger
+	retryClassifier   RetryClassifier         // Nil means the built-in retry policy
+	maxConcurrency    int                     // Fan-out limit; 0 means defaultMaxConcurrency
+	autoExtract       bool                    // Unwrap typed values in returned records
+	useNumber         bool                    // Decode JSON record numbers as json.Number
+	packVectors       bool                    // Server accepted packed vectors (see ClientConfig.CompressVectors)
+	validationSchemas *validationSchemas      // Set by ValidateVectors or ValidateRecords; shared with derived clients
+	validateVectors   bool                    // See ClientConfig.ValidateVectors
+	validateRecords   bool                    // See ClientConfig.ValidateRecords
+	validator         *openAPIValidator       // Set by ClientConfig.ValidateRequests
+	rateLimitQueue    *rateLimitQueue         // Shared with derived clients; nil when disabled
+	onTokenRefresh    func(string, time.Time) // See ClientConfig.OnTokenRefresh
+	onAuthFailure     func(error)             // See ClientConfig.OnAuthFailure
+	tlsConfig         *tls.Config             // TLS settings shared by HTTP transports and the WebSocket dialer
+	ctx               context.Context         // Context bound by WithContext; nil means context.Background()
+
+	featuresMu sync.Mutex  // Guards features; used on the root client only
+	features   *ServerInfo // Fetched by serverFeature
+
+	// parent is the client this one was derived from (WithContext). Derived
+	// clients share the parent's token and rate-limit state; see root().
+	parent *Client
 }
 
 // Record represents a document in ekoDB
@@ -147,30 +326,202 @@ func NewClientWithConfig(config ClientConfig) (*Client, error) {
 		shouldRetry: config.ShouldRetry,
 		maxRetries:  config.MaxRetries,
 		format:      config.Format, // Default is MessagePack (0 value = MessagePack)
+
+		contextHeaders:  config.ContextHeaders,
+		defaultHeaders:  copyHeaders(config.DefaultHeaders),
+		logger:          config.Logger,
+		retryClassifier: config.RetryClassifier,
+		maxConcurrency:  config.MaxConcurrency,
+		autoExtract:     config.AutoExtract,
+		useNumber:       config.UseNumber,
+		rateLimitQueue:  newRateLimitQueue(config.RateLimitQueueDepth),
+		onTokenRefresh:  config.OnTokenRefresh,
+		onAuthFailure:   config.OnAuthFailure,
+		tlsConfig:       config.TLSConfig.Clone(),
 		httpClient: &http.Client{
-			Timeout: config.Timeout,
+			Transport: newTransport(config, nil),
+			Timeout:   config.Timeout,
 		},
 		// streamClient has no request Timeout so SSE streams aren't killed
 		// mid-flight. Only the TCP dial phase is bounded.
 		streamClient: &http.Client{
-			Transport: &http.Transport{
-				DialContext: (&net.Dialer{
-					Timeout: config.Timeout,
-				}).DialContext,
-			},
+			Transport: newTransport(config, &net.Dialer{
+				Timeout: config.Timeout,
+			}),
 		},
 	}
 
-	// Automatically get token
-	if err := client.refreshToken(); err != nil {
-		return nil, fmt.Errorf("failed to get auth token: %w", err)
+	// Use a seeded token while it is still valid; otherwise get one now
+	if config.Token != "" {
+		client.SetToken(config.Token)
+	}
+	if client.needsToken() {
+		if err := client.refreshToken(); err != nil {
+			return nil, fmt.Errorf("failed to get auth token: %w", err)
+		}
+	}
+	if config.CompressVectors {
+		client.negotiateVectorPacking()
+	}
+	if config.ValidateVectors || config.ValidateRecords {
+		client.validationSchemas = newValidationSchemas()
+		client.validateVectors = config.ValidateVectors
+		client.validateRecords = config.ValidateRecords
+	}
+	if config.ValidateRequests {
+		validator, err := client.loadOpenAPIValidator(config.OpenAPISpec)
+		if err != nil {
+			return nil, err
+		}
+		client.validator = validator
+	}
+	if config.CleanupOrphansOnStart > 0 {
+		client.cleanupOrphansInBackground(config.CleanupOrphansOnStart)
 	}
 
 	return client, nil
 }
 
+// root returns the client that owns the shared auth and rate-limit state: the
+// client itself, or the client it was derived from.
+func (c *Client) root() *Client {
+	if c.parent != nil {
+		return c.parent
+	}
+	return c
+}
+
+// derive returns a new client with the same configuration as c that shares
+// the root client's token, rate-limit state, and HTTP transports. Callers
+// override the per-call settings on the returned copy.
+func (c *Client) derive() *Client {
+	return &Client{
+		parent:            c.root(),
+		baseURL:           c.baseURL,
+		apiKey:            c.apiKey,
+		httpClient:        c.httpClient,
+		streamClient:      c.streamClient,
+		shouldRetry:       c.shouldRetry,
+		maxRetries:        c.maxRetries,
+		format:            c.format,
+		schemaCache:       c.schemaCache,
+		softSchema:        c.softSchema,
+		contextHeaders:    c.contextHeaders,
+		defaultHeaders:    c.defaultHeaders,
+		logger:            c.logger,
+		retryClassifier:   c.retryClassifier,
+		maxConcurrency:    c.maxConcurrency,
+		autoExtract:       c.autoExtract,
+		useNumber:         c.useNumber,
+		packVectors:       c.packVectors,
+		validationSchemas: c.validationSchemas,
+		validateVectors:   c.validateVectors,
+		validateRecords:   c.validateRecords,
+		validator:         c.validator,
+		rateLimitQueue:    c.rateLimitQueue,
+		onTokenRefresh:    c.onTokenRefresh,
+		onAuthFailure:     c.onAuthFailure,
+		tlsConfig:         c.tlsConfig,
+		ctx:               c.ctx,
+	}
+}
+
+// WithContext returns a derived client whose requests run under ctx: they are
+// cancelled when ctx is, and any values mapped by ClientConfig.ContextHeaders
+// are sent as headers. The derived client is cheap to create and shares the
+// parent's authentication token and rate-limit state, so it is intended to be
+// created per inbound request:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    db := client.WithContext(r.Context())
+//	    user, err := db.FindByID("users", id)
+//	    ...
+//	}
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	derived := c.derive()
+	derived.ctx = ctx
+	return derived
+}
+
+// context returns the context requests from this client run under.
+func (c *Client) context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
+}
+
+// applyHeaders sets the User-Agent, ClientConfig.DefaultHeaders, and the
+// values configured in ContextHeaders from the request's context. Callers set
+// Authorization and content headers afterwards so those always win.
+func (c *Client) applyHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", UserAgent)
+	for header, value := range c.defaultHeaders {
+		req.Header.Set(header, value)
+	}
+	if c.packVectors {
+		req.Header.Set(VectorEncodingHeader, PackedVectorEncoding)
+	}
+	c.applyContextHeaders(req)
+}
+
+// applyContextHeaders copies the values configured in ContextHeaders from the
+// request's context onto its headers. Values are formatted with fmt.Sprint so
+// strings, numbers, and fmt.Stringer values all work.
+func (c *Client) applyContextHeaders(req *http.Request) {
+	if len(c.contextHeaders) == 0 {
+		return
+	}
+	ctx := req.Context()
+	for header, key := range c.contextHeaders {
+		v := ctx.Value(key)
+		if v == nil {
+			continue
+		}
+		if s := fmt.Sprint(v); s != "" {
+			req.Header.Set(header, s)
+		}
+	}
+}
+
+// logf writes a diagnostic line to the configured Logger, or to the standard
+// logger when none is set.
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// websocketHeaders returns the User-Agent and DefaultHeaders for a WebSocket
+// handshake.
+func (c *Client) websocketHeaders() http.Header {
+	header := http.Header{}
+	header.Set("User-Agent", UserAgent)
+	for name, value := range c.defaultHeaders {
+		header.Set(name, value)
+	}
+	return header
+}
+
+func copyHeaders(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(headers))
+	for k, v := range headers {
+		out[k] = v
+	}
+	return out
+}
+
 // GetRateLimitInfo returns the current rate limit information
 func (c *Client) GetRateLimitInfo() *RateLimitInfo {
+	c = c.root()
 	c.rateLimitMu.RLock()
 	defer c.rateLimitMu.RUnlock()
 	return c.rateLimitInfo
@@ -178,6 +529,7 @@ func (c *Client) GetRateLimitInfo() *RateLimitInfo {
 
 // IsNearRateLimit checks if approaching rate limit
 func (c *Client) IsNearRateLimit() bool {
+	c = c.root()
 	c.rateLimitMu.RLock()
 	defer c.rateLimitMu.RUnlock()
 	if c.rateLimitInfo == nil {
@@ -190,6 +542,7 @@ func (c *Client) IsNearRateLimit() bool {
 // If the cached token is about to expire (within 60 seconds), it proactively
 // refreshes to avoid returning a token that will expire mid-request.
 func (c *Client) getToken() string {
+	c = c.root()
 	c.tokenMu.RLock()
 	token := c.token
 	expiry := c.tokenExpiry
@@ -199,9 +552,9 @@ func (c *Client) getToken() string {
 	if token != "" && expiry > 0 {
 		now := time.Now().Unix()
 		if now+60 >= expiry {
-			log.Printf("Token expiring soon (%ds left), refreshing proactively", expiry-now)
+			c.logf("Token expiring soon (%ds left), refreshing proactively", expiry-now)
 			if err := c.refreshToken(); err != nil {
-				log.Printf("Proactive token refresh failed: %v (returning existing token)", err)
+				c.logf("Proactive token refresh failed: %v (returning existing token)", err)
 				return token // Return existing token as fallback
 			}
 			c.tokenMu.RLock()
@@ -222,39 +575,68 @@ func (c *Client) refreshToken() error {
 // Pass the stale token that caused the 401; if another goroutine already refreshed, this is a no-op.
 // Pass "" to force a refresh (used at init).
 func (c *Client) refreshTokenIfStale(staleToken string) error {
+	c = c.root()
+	token, expiry, err := c.fetchTokenIfStale(staleToken)
+	if err != nil {
+		if _, rejected := err.(*tokenRejectedError); rejected {
+			c.notifyAuthFailure(err)
+		}
+		return err
+	}
+	// Hooks run after tokenMu is released so they may call back into the client.
+	if token != "" && c.onTokenRefresh != nil {
+		c.onTokenRefresh(token, time.Unix(expiry, 0))
+	}
+	return nil
+}
+
+// fetchTokenIfStale does the work of refreshTokenIfStale under tokenMu. It
+// returns the new token and its expiry, or an empty token when another
+// goroutine already refreshed it.
+func (c *Client) fetchTokenIfStale(staleToken string) (string, int64, error) {
 	c.tokenMu.Lock()
 	defer c.tokenMu.Unlock()
 
 	// Double-check: if another goroutine already refreshed the token, skip the HTTP call
 	if staleToken != "" && c.token != staleToken {
-		return nil
+		return "", 0, nil
 	}
 
 	authReq := map[string]string{"api_key": c.apiKey}
 	body, err := json.Marshal(authReq)
 	if err != nil {
-		return err
+		return "", 0, err
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL+"/api/auth/token", "application/json", bytes.NewBuffer(body))
+	req, err := http.NewRequest("POST", c.baseURL+"/api/auth/token", bytes.NewBuffer(body))
 	if err != nil {
-		return err
+		return "", 0, err
+	}
+	c.applyHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("auth failed with status: %d, body: %s", resp.StatusCode, string(bodyBytes))
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return "", 0, &tokenRejectedError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+		}
+		return "", 0, fmt.Errorf("auth failed with status: %d, body: %s", resp.StatusCode, string(bodyBytes))
 	}
 
 	var result map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return err
+		return "", 0, err
 	}
 
 	token, ok := result["token"].(string)
 	if !ok {
-		return fmt.Errorf("invalid token response")
+		return "", 0, fmt.Errorf("invalid token response")
 	}
 
 	c.token = token
@@ -266,7 +648,7 @@ func (c *Client) refreshTokenIfStale(staleToken string) error {
 		c.tokenExpiry = time.Now().Unix() + 3600
 	}
 
-	return nil
+	return c.token, c.tokenExpiry, nil
 }
 
 // extractJWTExpiry decodes the JWT payload (middle segment, URL-safe base64 no-pad)
@@ -307,6 +689,7 @@ func extractJWTExpiry(token string) (int64, bool) {
 // ClearTokenCache clears the cached authentication token, forcing a fresh
 // token to be fetched on the next request.
 func (c *Client) ClearTokenCache() {
+	c = c.root()
 	c.tokenMu.Lock()
 	defer c.tokenMu.Unlock()
 	c.token = ""
@@ -321,6 +704,7 @@ func (c *Client) RefreshToken() (string, error) {
 	if err := c.refreshToken(); err != nil {
 		return "", err
 	}
+	c = c.root()
 	c.tokenMu.RLock()
 	defer c.tokenMu.RUnlock()
 	return c.token, nil
@@ -370,13 +754,18 @@ func (c *Client) extractRateLimitInfo(resp *http.Response) {
 			Reset:     reset,
 		}
 
-		c.rateLimitMu.Lock()
-		c.rateLimitInfo = info
-		c.rateLimitMu.Unlock()
+		root := c.root()
+		root.rateLimitMu.Lock()
+		root.rateLimitInfo = info
+		root.rateLimitMu.Unlock()
+
+		if c.rateLimitQueue != nil && info.IsExceeded() && reset > 0 {
+			c.rateLimitQueue.holdUntil(time.Unix(reset, 0))
+		}
 
 		// Log warning if approaching rate limit
 		if info.IsNearLimit() {
-			log.Printf("Warning: Approaching rate limit: %d/%d remaining (%.1f%%)",
+			c.logf("Warning: Approaching rate limit: %d/%d remaining (%.1f%%)",
 				info.Remaining, info.Limit, info.RemainingPercentage())
 		}
 	}
@@ -384,11 +773,21 @@ func (c *Client) extractRateLimitInfo(resp *http.Response) {
 
 // makeRequest makes an HTTP request to the ekoDB API with retry logic
 func (c *Client) makeRequest(method, path string, data interface{}) ([]byte, error) {
-	return c.makeRequestWithRetry(method, path, data, 0)
+	return c.makeRequestWithRetry(method, path, data, 0, nil)
 }
 
-// makeRequestWithRetry makes an HTTP request with retry logic
-func (c *Client) makeRequestWithRetry(method, path string, data interface{}, attempt int) ([]byte, error) {
+// makeStreamingRequest is makeRequest for large responses: on success the
+// response body is handed to sink as it arrives instead of being buffered.
+// Errors, retries, and token refresh behave as in makeRequest; a failure
+// inside sink is returned as-is and never retried.
+func (c *Client) makeStreamingRequest(method, path string, data interface{}, sink func(io.Reader) error) error {
+	_, err := c.makeRequestWithRetry(method, path, data, 0, sink)
+	return err
+}
+
+// makeRequestWithRetry makes an HTTP request with retry logic. When sink is
+// non-nil a successful body is streamed to it and nil bytes are returned.
+func (c *Client) makeRequestWithRetry(method, path string, data interface{}, attempt int, sink func(io.Reader) error) ([]byte, error) {
 	var body io.Reader
 	var contentType string
 
@@ -402,28 +801,37 @@ func (c *Client) makeRequestWithRetry(method, path string, data interface{}, att
 		contentType = "application/json"
 	}
 
-	if data != nil {
-		var serializedData []byte
-		var err error
-
-		if !forceJSON && c.format == MessagePack {
-			// Serialize to MessagePack
-			serializedData, err = msgpack.Marshal(data)
-		} else {
-			// Serialize to JSON (default)
-			serializedData, err = json.Marshal(data)
+	// Serialize once; retries pass the encoded body back in.
+	payload, encoded := data.(encodedBody)
+	if !encoded {
+		if data != nil && c.packVectors {
+			data = packRequestVectors(data)
 		}
-
-		if err != nil {
-			return nil, err
+		if attempt == 0 && c.validator != nil {
+			if err := c.validator.validate(method, path, data); err != nil {
+				return nil, err
+			}
 		}
-		body = bytes.NewBuffer(serializedData)
+		if data != nil {
+			var err error
+			if payload, err = encodeRequestBody(data, !forceJSON && c.format == MessagePack); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if payload != nil {
+		body = bytes.NewReader(payload)
 	}
 
-	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err := c.admit(attempt > 0); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(c.context(), method, c.baseURL+path, body)
 	if err != nil {
 		return nil, err
 	}
+	c.applyHeaders(req)
 
 	// Capture the token used for this request so we can pass it to refreshTokenIfStale
 	usedToken := c.getToken()
@@ -436,17 +844,22 @@ func (c *Client) makeRequestWithRetry(method, path string, data interface{}, att
 		// Handle network errors with retry, using exponential backoff with full
 		// jitter (instead of a fixed delay) so concurrent clients don't retry in
 		// lockstep and a flapping server isn't hammered.
-		if c.shouldRetry && attempt < c.maxRetries {
+		if c.classifyRetry(nil, err) != NoRetry && c.shouldRetry && attempt < c.maxRetries && c.context().Err() == nil {
 			retryDelay := retryBackoff(attempt)
-			log.Printf("Network error, retrying after %v...", retryDelay)
+			c.logf("Network error, retrying after %v...", retryDelay)
 			time.Sleep(retryDelay)
-			return c.makeRequestWithRetry(method, path, data, attempt+1)
+			return c.makeRequestWithRetry(method, path, payload, attempt+1, sink)
 		}
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	responseBody, err := io.ReadAll(resp.Body)
+	if sink != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		c.extractRateLimitInfo(resp)
+		return nil, sink(resp.Body)
+	}
+
+	responseBody, err := readResponseBody(resp.Body, resp.ContentLength)
 	if err != nil {
 		return nil, err
 	}
@@ -457,66 +870,134 @@ func (c *Client) makeRequestWithRetry(method, path string, data interface{}, att
 		return responseBody, nil
 	}
 
-	// Handle rate limiting (429)
-	if resp.StatusCode == http.StatusTooManyRequests {
-		retryAfterStr := resp.Header.Get("Retry-After")
-		retryAfter := 60 // default
-		if retryAfterStr != "" {
-			if val, err := strconv.Atoi(retryAfterStr); err == nil {
-				retryAfter = val
+	authFailure := resp.StatusCode == http.StatusUnauthorized ||
+		(resp.StatusCode == http.StatusInternalServerError && bytes.Contains(responseBody, []byte("Invalid token")))
+
+	// A RetryClassifier overrides the policy below for everything but auth
+	// failures, which always go through token refresh.
+	if !authFailure && c.retryClassifier != nil {
+		resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+		switch c.classifyRetry(resp, nil) {
+		case RetryRequest:
+			if c.shouldRetry && attempt < c.maxRetries {
+				retryDelay := classifiedRetryDelay(resp, attempt)
+				c.logf("Request failed with status %d, retrying after %v...", resp.StatusCode, retryDelay)
+				time.Sleep(retryDelay)
+				return c.makeRequestWithRetry(method, path, payload, attempt+1, sink)
 			}
+		case NoRetry:
+			return nil, responseError(resp, responseBody)
 		}
+	}
 
-		if c.shouldRetry && attempt < c.maxRetries {
-			retryDelay := time.Duration(retryAfter) * time.Second
-			log.Printf("Rate limited, retrying after %v...", retryDelay)
-			time.Sleep(retryDelay)
-			return c.makeRequestWithRetry(method, path, data, attempt+1)
+	// Handle rate limiting (429)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := retryAfterSecs(resp)
+		retryDelay := time.Duration(retryAfter) * time.Second
+		if c.rateLimitQueue != nil {
+			c.rateLimitQueue.holdUntil(time.Now().Add(retryDelay))
 		}
-
-		return nil, &RateLimitError{
-			RetryAfterSecs: retryAfter,
-			Message:        string(responseBody),
+		if c.shouldRetry && attempt < c.maxRetries {
+			c.logf("Rate limited, retrying after %v...", retryDelay)
+			if c.rateLimitQueue == nil {
+				time.Sleep(retryDelay)
+			}
+			return c.makeRequestWithRetry(method, path, payload, attempt+1, sink)
 		}
+		return nil, responseError(resp, responseBody)
 	}
 
 	// Handle unauthorized (401) or token errors - try refreshing token
-	if resp.StatusCode == http.StatusUnauthorized ||
-		(resp.StatusCode == http.StatusInternalServerError && bytes.Contains(responseBody, []byte("Invalid token"))) {
+	if authFailure {
 		if attempt == 0 { // Only try token refresh once
-			log.Printf("Authentication failed, refreshing token...")
+			c.logf("Authentication failed, refreshing token...")
 			if err := c.refreshTokenIfStale(usedToken); err != nil {
 				return nil, fmt.Errorf("failed to refresh token: %w", err)
 			}
 			// Retry with new token
-			return c.makeRequestWithRetry(method, path, data, attempt+1)
+			return c.makeRequestWithRetry(method, path, payload, attempt+1, sink)
 		}
 		// Authentication is still failing after a token refresh attempt; return a clear auth error.
-		return nil, fmt.Errorf("authentication failed after token refresh (status %d): %s", resp.StatusCode, string(responseBody))
+		err := fmt.Errorf("authentication failed after token refresh (status %d): %s", resp.StatusCode, string(responseBody))
+		c.root().notifyAuthFailure(err)
+		return nil, err
 	}
 
 	// Handle service unavailable (503)
 	if resp.StatusCode == http.StatusServiceUnavailable && c.shouldRetry && attempt < c.maxRetries {
 		retryDelay := 10 * time.Second
-		log.Printf("Service unavailable, retrying after %v...", retryDelay)
+		c.logf("Service unavailable, retrying after %v...", retryDelay)
 		time.Sleep(retryDelay)
-		return c.makeRequestWithRetry(method, path, data, attempt+1)
+		return c.makeRequestWithRetry(method, path, payload, attempt+1, sink)
 	}
 
 	// Handle other errors
-	return nil, &HTTPError{
+	return nil, responseError(resp, responseBody)
+}
+
+// retryAfterSecs returns the response's Retry-After in seconds (default: 60).
+func retryAfterSecs(resp *http.Response) int {
+	if val, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return val
+	}
+	return 60
+}
+
+// responseError converts a failed response into a *RateLimitError (429) or
+// an *HTTPError.
+func responseError(resp *http.Response, body []byte) error {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{
+			RetryAfterSecs: retryAfterSecs(resp),
+			Message:        string(body),
+		}
+	}
+	return &HTTPError{
 		StatusCode: resp.StatusCode,
-		Message:    string(responseBody),
+		Message:    string(body),
 	}
 }
 
 // unmarshal deserializes data based on the client's format and path
 func (c *Client) unmarshal(path string, data []byte, v interface{}) error {
+	var err error
 	// Use JSON if the path requires it or if client is set to JSON
 	if shouldUseJSON(path) || c.format == JSON {
+		err = c.unmarshalJSON(data, v)
+	} else {
+		err = msgpack.Unmarshal(data, v)
+	}
+	if err != nil {
+		return err
+	}
+	c.finishDecoded(v)
+	return nil
+}
+
+// unmarshalJSON decodes a JSON response body, keeping numbers as json.Number
+// when ClientConfig.UseNumber is set.
+func (c *Client) unmarshalJSON(data []byte, v interface{}) error {
+	if !c.useNumber {
 		return json.Unmarshal(data, v)
 	}
-	return msgpack.Unmarshal(data, v)
+	dec := c.jsonDecoder(bytes.NewReader(data))
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("invalid JSON: unexpected data after the top-level value")
+	}
+	return nil
+}
+
+// jsonDecoder returns a decoder for a streamed JSON response, honoring
+// ClientConfig.UseNumber.
+func (c *Client) jsonDecoder(r io.Reader) *json.Decoder {
+	dec := json.NewDecoder(r)
+	if c.useNumber {
+		dec.UseNumber()
+	}
+	return dec
 }
 
 // shouldUseJSON determines if a path should use JSON
@@ -551,6 +1032,8 @@ type InsertOptions struct {
 	BypassRipple  *bool
 	TransactionId *string
 	BypassCache   *bool
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
 }
 
 // Insert inserts a document into a collection
@@ -560,6 +1043,19 @@ type InsertOptions struct {
 //	Insert(collection, record, InsertOptions{TTL: "1h"})          // with TTL
 //	Insert(collection, record, InsertOptions{BypassRipple: &t})   // bypass ripple
 func (c *Client) Insert(collection string, record Record, opts ...InsertOptions) (Record, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	c.softSchemaObserve(collection, record)
+	if err := c.validateWrite(collection, false, record); err != nil {
+		return nil, err
+	}
+	return c.insert(collection, record, opts...)
+}
+
+// insert is Insert without soft schema and validation, for callers that ran
+// them already.
+func (c *Client) insert(collection string, record Record, opts ...InsertOptions) (Record, error) {
 	// Add TTL if provided
 	if len(opts) > 0 && opts[0].TTL != "" {
 		record["ttl"] = opts[0].TTL
@@ -617,11 +1113,56 @@ type FindOptions struct {
 	// else the committed store — and recorded in its read set for commit-time
 	// conflict detection. Nil for an ordinary committed read.
 	TransactionId *string
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
 }
 
-// Find finds documents in a collection
+// Find finds documents in a collection. query may be a built query map or a
+// *QueryBuilder, which is validated first.
 func (c *Client) Find(collection string, query interface{}, opts ...FindOptions) ([]Record, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	query, err := builtQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := query.(map[string]interface{}); ok && m["vector_near"] != nil {
+		return c.findVectorNear(collection, query, opts)
+	}
+	n, sampled, err := querySample(query)
+	if err != nil {
+		return nil, err
+	}
+	if sampled && !c.serverFeature(randomSampleFeature) {
+		return c.findSample(collection, query.(map[string]interface{}), n, opts)
+	}
+	path, body, err := c.findRequest(collection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := c.makeRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Record
+	if err := c.unmarshal(path, respBody, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// findRequest builds the path (with query parameters) and body for a
+// POST /api/find request.
+func (c *Client) findRequest(collection string, query interface{}, opts []FindOptions) (string, interface{}, error) {
 	path := "/api/find/" + url.PathEscape(collection)
+	query, err := builtQuery(query)
+	if err != nil {
+		return "", nil, err
+	}
 
 	// Default: send the caller's query unchanged, so a non-map query (e.g. a
 	// struct relying on msgpack tags — /api/find is MessagePack by default) keeps
@@ -632,7 +1173,7 @@ func (c *Client) Find(collection string, query interface{}, opts ...FindOptions)
 	if findOptionsHaveBodyFields(opts) {
 		merged, err := c.mergeFindOptions(path, query, opts)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		body = merged
 	}
@@ -673,18 +1214,7 @@ func (c *Client) Find(collection string, query interface{}, opts ...FindOptions)
 	if encoded := params.Encode(); encoded != "" {
 		path += "?" + encoded
 	}
-
-	respBody, err := c.makeRequest("POST", path, body)
-	if err != nil {
-		return nil, err
-	}
-
-	var results []Record
-	if err := c.unmarshal(path, respBody, &results); err != nil {
-		return nil, err
-	}
-
-	return results, nil
+	return path, body, nil
 }
 
 // findOptionsHaveBodyFields reports whether opts sets any field that Find merges
@@ -755,6 +1285,10 @@ func (c *Client) mergeFindOptions(path string, query interface{}, opts []FindOpt
 // e.g. its msgpack tags are honored on a MessagePack find rather than silently
 // replaced by its json tags.
 func (c *Client) queryToBodyMap(path string, query interface{}) (map[string]interface{}, error) {
+	query, err := builtQuery(query)
+	if err != nil {
+		return nil, err
+	}
 	if query == nil {
 		return map[string]interface{}{}, nil
 	}
@@ -797,11 +1331,16 @@ type FindByIDOptions struct {
 	// leaves it off.
 	BypassRipple  *bool
 	TransactionId *string
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
 }
 
 // FindByID finds a document by ID. Pass FindByIDOptions to project fields or to
 // read within a transaction (read-your-writes).
 func (c *Client) FindByID(collection, id string, opts ...FindByIDOptions) (Record, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
 	path := fmt.Sprintf("/api/find/%s/%s", url.PathEscape(collection), url.PathEscape(id))
 	if len(opts) > 0 {
 		params := url.Values{}
@@ -837,7 +1376,8 @@ func (c *Client) FindByID(collection, id string, opts ...FindByIDOptions) (Recor
 // FindByIDWithProjection finds a document by ID with field projection
 // selectFields: only return these fields (plus 'id')
 // excludeFields: exclude these fields from results
-func (c *Client) FindByIDWithProjection(collection, id string, selectFields, excludeFields []string) (Record, error) {
+func (c *Client) FindByIDWithProjection(collection, id string, selectFields, excludeFields []string, opts ...RequestOption) (Record, error) {
+	c = c.withOptions(opts)
 	// Build query with projection using Find endpoint
 	query := NewQueryBuilder().Eq("id", id).Limit(1)
 
@@ -871,10 +1411,25 @@ type UpdateOptions struct {
 	BypassCache   *bool
 	SelectFields  []string
 	ExcludeFields []string
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
 }
 
 // Update updates a document
 func (c *Client) Update(collection, id string, record Record, opts ...UpdateOptions) (Record, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	c.softSchemaObserve(collection, record)
+	if err := c.validateWrite(collection, true, record); err != nil {
+		return nil, err
+	}
+	return c.update(collection, id, record, opts...)
+}
+
+// update is Update without soft schema and validation, for callers that ran
+// them already.
+func (c *Client) update(collection, id string, record Record, opts ...UpdateOptions) (Record, error) {
 	// Build query parameters
 	path := fmt.Sprintf("/api/update/%s/%s", url.PathEscape(collection), url.PathEscape(id))
 	if len(opts) > 0 {
@@ -928,7 +1483,8 @@ type UpdateWithActionBody struct {
 //
 // Supported actions: increment, decrement, multiply, divide, modulo,
 // push, pop, shift, unshift, remove, append, clear.
-func (c *Client) UpdateWithAction(collection, id, action, field string, value interface{}) (Record, error) {
+func (c *Client) UpdateWithAction(collection, id, action, field string, value interface{}, opts ...RequestOption) (Record, error) {
+	c = c.withOptions(opts)
 	path := fmt.Sprintf("/api/update/%s/%s/action/%s", url.PathEscape(collection), url.PathEscape(id), url.PathEscape(action))
 	body := UpdateWithActionBody{Field: field, Value: value}
 	respBody, err := c.makeRequest("PUT", path, body)
@@ -950,7 +1506,8 @@ func (c *Client) UpdateWithAction(collection, id, action, field string, value in
 // single update.
 //
 // Each action is a 3-element slice: [action, field, value].
-func (c *Client) UpdateWithActionSequence(collection, id string, actions [][3]interface{}) (Record, error) {
+func (c *Client) UpdateWithActionSequence(collection, id string, actions [][3]interface{}, opts ...RequestOption) (Record, error) {
+	c = c.withOptions(opts)
 	path := fmt.Sprintf("/api/update/sequence/%s/%s", url.PathEscape(collection), url.PathEscape(id))
 	respBody, err := c.makeRequest("PUT", path, actions)
 	if err != nil {
@@ -969,10 +1526,18 @@ func (c *Client) UpdateWithActionSequence(collection, id string, actions [][3]in
 type DeleteOptions struct {
 	BypassRipple  *bool
 	TransactionId *string
+	// Permanent deletes the record outright instead of moving it to the
+	// trash, so it cannot be restored with RestoreRecord
+	Permanent bool
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
 }
 
 // Delete deletes a document
 func (c *Client) Delete(collection, id string, opts ...DeleteOptions) error {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
 	// Build query parameters
 	path := fmt.Sprintf("/api/delete/%s/%s", url.PathEscape(collection), url.PathEscape(id))
 	if len(opts) > 0 {
@@ -983,6 +1548,9 @@ func (c *Client) Delete(collection, id string, opts ...DeleteOptions) error {
 		if opts[0].TransactionId != nil {
 			params.Add("transaction_id", *opts[0].TransactionId)
 		}
+		if opts[0].Permanent {
+			params.Add("permanent", "true")
+		}
 		if len(params) > 0 {
 			path = fmt.Sprintf("%s?%s", path, params.Encode())
 		}
@@ -1004,82 +1572,185 @@ func (c *Client) Delete(collection, id string, opts ...DeleteOptions) error {
 type BatchInsertOptions struct {
 	BypassRipple  *bool
 	TransactionId *string
+
+	// TTL is the default time-to-live (as for InsertOptions.TTL) for records
+	// that have no TTL of their own
+	TTL string
+	// TTLs gives per-record TTLs by index; "" falls back to TTL. A record
+	// that already has a "ttl" field keeps it.
+	TTLs []string
+
+	// ReturnRecords returns the complete stored records, including
+	// server-generated fields, instead of ID-only stubs
+	ReturnRecords bool
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
 }
 
-// BatchInsert inserts multiple documents
+// Server wire formats for the batch endpoints. These have hand-written
+// MessagePack encoders in msgpack_codec.go.
+type batchInsertItem struct {
+	Data         Record `json:"data" msgpack:"data"`
+	BypassRipple *bool  `json:"bypass_ripple,omitempty" msgpack:"bypass_ripple,omitempty"`
+}
+
+type batchInsertQuery struct {
+	Inserts []batchInsertItem `json:"inserts" msgpack:"inserts"`
+}
+
+type batchUpdateItem struct {
+	ID           string `json:"id" msgpack:"id"`
+	Data         Record `json:"data" msgpack:"data"`
+	BypassRipple *bool  `json:"bypass_ripple,omitempty" msgpack:"bypass_ripple,omitempty"`
+}
+
+type batchUpdateQuery struct {
+	Updates []batchUpdateItem `json:"updates" msgpack:"updates"`
+}
+
+type batchDeleteItem struct {
+	ID           string `json:"id" msgpack:"id"`
+	BypassRipple *bool  `json:"bypass_ripple,omitempty" msgpack:"bypass_ripple,omitempty"`
+}
+
+type batchDeleteQuery struct {
+	Deletes []batchDeleteItem `json:"deletes" msgpack:"deletes"`
+}
+
+// BatchInsert inserts multiple documents. Records the server fails to insert
+// are left out of the result; use BatchInsertDetailed to find out which.
 func (c *Client) BatchInsert(collection string, records []Record, opts ...BatchInsertOptions) ([]Record, error) {
+	result, err := c.BatchInsertDetailed(collection, records, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return result.Records, nil
+}
+
+// BatchInsertDetailed inserts multiple documents like BatchInsert, and also
+// reports the records that failed, by their index in records.
+func (c *Client) BatchInsertDetailed(collection string, records []Record, opts ...BatchInsertOptions) (*BatchResult, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	c.softSchemaObserve(collection, records...)
+	if err := c.validateWrite(collection, false, records...); err != nil {
+		return nil, err
+	}
+
 	var bypassRipple *bool
 	if len(opts) > 0 {
 		bypassRipple = opts[0].BypassRipple
+		if len(opts[0].TTLs) > len(records) {
+			return nil, fmt.Errorf("batch insert: %d TTLs for %d records", len(opts[0].TTLs), len(records))
+		}
 	}
 	// Convert to server format
-	type batchInsertItem struct {
-		Data         Record `json:"data" msgpack:"data"`
-		BypassRipple *bool  `json:"bypass_ripple,omitempty" msgpack:"bypass_ripple,omitempty"`
-	}
-	type batchInsertQuery struct {
-		Inserts []batchInsertItem `json:"inserts" msgpack:"inserts"`
-	}
-
 	inserts := make([]batchInsertItem, len(records))
 	for i, r := range records {
+		if len(opts) > 0 {
+			r = withBatchTTL(r, i, opts[0])
+		}
 		inserts[i] = batchInsertItem{Data: r, BypassRipple: bypassRipple}
 	}
 
 	query := batchInsertQuery{Inserts: inserts}
 
-	path := "/api/batch/insert/" + url.PathEscape(collection)
-	if len(opts) > 0 && opts[0].TransactionId != nil {
-		params := url.Values{}
-		params.Add("transaction_id", *opts[0].TransactionId)
-		path = fmt.Sprintf("%s?%s", path, params.Encode())
+	var txID *string
+	var returnRecords bool
+	if len(opts) > 0 {
+		txID, returnRecords = opts[0].TransactionId, opts[0].ReturnRecords
 	}
+	path := batchPath("/api/batch/insert/"+url.PathEscape(collection), txID, returnRecords)
 	respBody, err := c.makeRequest("POST", path, query)
 	if err != nil {
 		return nil, err
 	}
 
-	type batchResult struct {
-		Successful []string      `json:"successful" msgpack:"successful"`
-		Failed     []interface{} `json:"failed" msgpack:"failed"`
-	}
-
 	var result batchResult
 	if err := c.unmarshal(path, respBody, &result); err != nil {
 		return nil, err
 	}
 
-	// Convert IDs to Records
-	results := make([]Record, len(result.Successful))
-	for i, id := range result.Successful {
-		results[i] = Record{"id": id}
+	written, err := c.batchResultRecords(collection, &result, txID, returnRecords)
+	if err != nil {
+		return nil, err
 	}
+	ids := make([]string, len(records))
+	for i, r := range records {
+		ids[i], _ = r["id"].(string)
+	}
+	return &BatchResult{
+		Records:    written,
+		Successful: result.Successful,
+		Failed:     batchFailures(result.Failed, ids),
+		client:     c,
+		retry:      insertRetry(collection, records, opts),
+	}, nil
+}
 
-	return results, nil
+// withBatchTTL returns record i with the TTL opts gives it, copying the
+// record rather than modifying the caller's.
+func withBatchTTL(record Record, i int, opts BatchInsertOptions) Record {
+	if _, ok := record["ttl"]; ok {
+		return record
+	}
+	ttl := opts.TTL
+	if i < len(opts.TTLs) && opts.TTLs[i] != "" {
+		ttl = opts.TTLs[i]
+	}
+	if ttl == "" {
+		return record
+	}
+	record = copyRecord(record)
+	record["ttl"] = ttl
+	return record
 }
 
 // BatchUpdateOptions contains optional parameters for BatchUpdate
 type BatchUpdateOptions struct {
 	BypassRipple  *bool
 	TransactionId *string
+
+	// ReturnRecords returns the complete updated records instead of ID-only
+	// stubs
+	ReturnRecords bool
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
 }
 
-// BatchUpdate updates multiple documents
+// BatchUpdate updates multiple documents. Records the server fails to update
+// are left out of the result; use BatchUpdateDetailed to find out which.
 func (c *Client) BatchUpdate(collection string, updates map[string]Record, opts ...BatchUpdateOptions) ([]Record, error) {
-	var bypassRipple *bool
+	result, err := c.BatchUpdateDetailed(collection, updates, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return result.Records, nil
+}
+
+// BatchUpdateDetailed updates multiple documents like BatchUpdate, and also
+// reports the records that failed, by ID.
+func (c *Client) BatchUpdateDetailed(collection string, updates map[string]Record, opts ...BatchUpdateOptions) (*BatchResult, error) {
 	if len(opts) > 0 {
-		bypassRipple = opts[0].BypassRipple
+		c = c.withOptions(opts[0].RequestOptions)
 	}
-	// Convert to server format
-	type batchUpdateItem struct {
-		ID           string `json:"id" msgpack:"id"`
-		Data         Record `json:"data" msgpack:"data"`
-		BypassRipple *bool  `json:"bypass_ripple,omitempty" msgpack:"bypass_ripple,omitempty"`
+	if c.softSchema != nil {
+		records := make([]Record, 0, len(updates))
+		for _, r := range updates {
+			records = append(records, r)
+		}
+		c.softSchemaObserve(collection, records...)
 	}
-	type batchUpdateQuery struct {
-		Updates []batchUpdateItem `json:"updates" msgpack:"updates"`
+	if err := c.validateUpdates(collection, updates); err != nil {
+		return nil, err
 	}
 
+	var bypassRipple *bool
+	if len(opts) > 0 {
+		bypassRipple = opts[0].BypassRipple
+	}
+	// Convert to server format
 	items := make([]batchUpdateItem, 0, len(updates))
 	for id, data := range updates {
 		items = append(items, batchUpdateItem{ID: id, Data: data, BypassRipple: bypassRipple})
@@ -1087,33 +1758,98 @@ func (c *Client) BatchUpdate(collection string, updates map[string]Record, opts
 
 	query := batchUpdateQuery{Updates: items}
 
-	path := "/api/batch/update/" + url.PathEscape(collection)
-	if len(opts) > 0 && opts[0].TransactionId != nil {
-		params := url.Values{}
-		params.Add("transaction_id", *opts[0].TransactionId)
-		path = fmt.Sprintf("%s?%s", path, params.Encode())
+	var txID *string
+	var returnRecords bool
+	if len(opts) > 0 {
+		txID, returnRecords = opts[0].TransactionId, opts[0].ReturnRecords
 	}
+	path := batchPath("/api/batch/update/"+url.PathEscape(collection), txID, returnRecords)
 	respBody, err := c.makeRequest("PUT", path, query)
 	if err != nil {
 		return nil, err
 	}
 
-	type batchResult struct {
-		Successful []string      `json:"successful" msgpack:"successful"`
-		Failed     []interface{} `json:"failed" msgpack:"failed"`
-	}
-
 	var result batchResult
 	if err := c.unmarshal(path, respBody, &result); err != nil {
 		return nil, err
 	}
 
-	// Convert IDs to Records
+	written, err := c.batchResultRecords(collection, &result, txID, returnRecords)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	failed := batchFailures(result.Failed, ids)
+	for i := range failed {
+		// Positions in the request mean nothing to the caller's map.
+		failed[i].Index = -1
+	}
+	return &BatchResult{
+		Records:    written,
+		Successful: result.Successful,
+		Failed:     failed,
+		client:     c,
+		retry:      updateRetry(collection, updates, opts),
+	}, nil
+}
+
+// batchResult is the response of the batch insert and update endpoints.
+// Records is only filled when return_records was requested and the server
+// supports it.
+type batchResult struct {
+	Successful []string      `json:"successful" msgpack:"successful"`
+	Failed     []interface{} `json:"failed" msgpack:"failed"`
+	Records    []Record      `json:"records,omitempty" msgpack:"records,omitempty"`
+}
+
+// batchPath adds the batch endpoints' query parameters to path.
+func batchPath(path string, txID *string, returnRecords bool) string {
+	params := url.Values{}
+	if txID != nil {
+		params.Add("transaction_id", *txID)
+	}
+	if returnRecords {
+		params.Add("return_records", "true")
+	}
+	if len(params) > 0 {
+		path = fmt.Sprintf("%s?%s", path, params.Encode())
+	}
+	return path
+}
+
+// batchResultRecords converts a batch response into the records returned to
+// the caller: ID stubs, or with returnRecords the stored records. Those come
+// from the response when the server includes them and are otherwise fetched
+// with FindByIDs (in the same transaction, if any).
+func (c *Client) batchResultRecords(collection string, result *batchResult, txID *string, returnRecords bool) ([]Record, error) {
+	if !returnRecords {
+		results := make([]Record, len(result.Successful))
+		for i, id := range result.Successful {
+			results[i] = Record{"id": id}
+		}
+		return results, nil
+	}
+	if len(result.Records) > 0 || len(result.Successful) == 0 {
+		for _, rec := range result.Records {
+			c.finishRecord(rec)
+		}
+		return result.Records, nil
+	}
+
+	found, missing, err := c.FindByIDs(collection, result.Successful, FindByIDOptions{TransactionId: txID})
+	if err != nil {
+		return nil, fmt.Errorf("fetch written records: %w", err)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("fetch written records: %d not found, e.g. %s", len(missing), missing[0])
+	}
 	results := make([]Record, len(result.Successful))
 	for i, id := range result.Successful {
-		results[i] = Record{"id": id}
+		results[i] = found[id]
 	}
-
 	return results, nil
 }
 
@@ -1121,23 +1857,34 @@ func (c *Client) BatchUpdate(collection string, updates map[string]Record, opts
 type BatchDeleteOptions struct {
 	BypassRipple  *bool
 	TransactionId *string
+	// Permanent deletes the records outright instead of moving them to the
+	// trash
+	Permanent bool
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
 }
 
-// BatchDelete deletes multiple documents
+// BatchDelete deletes multiple documents and returns how many were deleted.
+// Use BatchDeleteDetailed to find out which IDs failed.
 func (c *Client) BatchDelete(collection string, ids []string, opts ...BatchDeleteOptions) (int, error) {
+	result, err := c.BatchDeleteDetailed(collection, ids, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return len(result.Successful), nil
+}
+
+// BatchDeleteDetailed deletes multiple documents like BatchDelete, and also
+// reports the IDs that failed.
+func (c *Client) BatchDeleteDetailed(collection string, ids []string, opts ...BatchDeleteOptions) (*BatchResult, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
 	var bypassRipple *bool
 	if len(opts) > 0 {
 		bypassRipple = opts[0].BypassRipple
 	}
 	// Convert to server format
-	type batchDeleteItem struct {
-		ID           string `json:"id" msgpack:"id"`
-		BypassRipple *bool  `json:"bypass_ripple,omitempty" msgpack:"bypass_ripple,omitempty"`
-	}
-	type batchDeleteQuery struct {
-		Deletes []batchDeleteItem `json:"deletes" msgpack:"deletes"`
-	}
-
 	deletes := make([]batchDeleteItem, len(ids))
 	for i, id := range ids {
 		deletes[i] = batchDeleteItem{ID: id, BypassRipple: bypassRipple}
@@ -1146,27 +1893,34 @@ func (c *Client) BatchDelete(collection string, ids []string, opts ...BatchDelet
 	query := batchDeleteQuery{Deletes: deletes}
 
 	path := "/api/batch/delete/" + url.PathEscape(collection)
-	if len(opts) > 0 && opts[0].TransactionId != nil {
+	if len(opts) > 0 {
 		params := url.Values{}
-		params.Add("transaction_id", *opts[0].TransactionId)
-		path = fmt.Sprintf("%s?%s", path, params.Encode())
+		if opts[0].TransactionId != nil {
+			params.Add("transaction_id", *opts[0].TransactionId)
+		}
+		if opts[0].Permanent {
+			params.Add("permanent", "true")
+		}
+		if len(params) > 0 {
+			path = fmt.Sprintf("%s?%s", path, params.Encode())
+		}
 	}
 	respBody, err := c.makeRequest("DELETE", path, query)
 	if err != nil {
-		return 0, err
-	}
-
-	type batchResult struct {
-		Successful []string      `json:"successful" msgpack:"successful"`
-		Failed     []interface{} `json:"failed" msgpack:"failed"`
+		return nil, err
 	}
 
 	var result batchResult
 	if err := c.unmarshal(path, respBody, &result); err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return len(result.Successful), nil
+	return &BatchResult{
+		Successful: result.Successful,
+		Failed:     batchFailures(result.Failed, ids),
+		client:     c,
+		retry:      deleteRetry(collection, ids, opts),
+	}, nil
 }
 
 // ========== Convenience Methods ==========
@@ -1177,11 +1931,16 @@ type UpsertOptions struct {
 	BypassRipple  *bool
 	TransactionId *string
 	BypassCache   *bool
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
 }
 
 // Upsert inserts or updates a document (atomic insert-or-update)
 // Attempts to update first. If the record doesn't exist (404), it will be inserted.
 func (c *Client) Upsert(collection, id string, record Record, opts ...UpsertOptions) (Record, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
 	var bypassRipple *bool
 	var transactionId *string
 	var bypassCache *bool
@@ -1221,7 +1980,8 @@ func (c *Client) Upsert(collection, id string, record Record, opts ...UpsertOpti
 
 // FindOne finds a single record by field value
 // Returns nil if no record matches, or the first matching record.
-func (c *Client) FindOne(collection, field string, value interface{}) (Record, error) {
+func (c *Client) FindOne(collection, field string, value interface{}, opts ...RequestOption) (Record, error) {
+	c = c.withOptions(opts)
 	query := NewQueryBuilder().Eq(field, value).Limit(1).Build()
 
 	results, err := c.Find(collection, query)
@@ -1236,9 +1996,70 @@ func (c *Client) FindOne(collection, field string, value interface{}) (Record, e
 	return results[0], nil
 }
 
+// InsertUnique inserts a record unless another record in the collection
+// already has the same values for keyFields (its natural key, e.g. "email").
+// If one does, it returns an *AlreadyExistsError carrying the existing ID and
+// nothing is written.
+//
+// The pre-insert lookup alone cannot stop two concurrent callers from both
+// inserting; declare a Unique constraint on the key field(s) in the schema so
+// the server rejects the loser with 409 Conflict, which InsertUnique also
+// reports as an *AlreadyExistsError. Since its key fields are variadic, it
+// takes RequestOptions only through a derived client (see With).
+//
+// Example:
+//
+//	user, err := client.InsertUnique("users", Record{"email": email, "name": name}, "email")
+//	var exists *AlreadyExistsError
+//	if errors.As(err, &exists) {
+//	    log.Printf("already signed up as %s", exists.ExistingID)
+//	}
+func (c *Client) InsertUnique(collection string, record Record, keyFields ...string) (Record, error) {
+	if len(keyFields) == 0 {
+		return nil, fmt.Errorf("InsertUnique: at least one key field is required")
+	}
+	qb := NewQueryBuilder().Limit(1).SelectFields("id")
+	for _, field := range keyFields {
+		value, ok := record[field]
+		if !ok {
+			return nil, fmt.Errorf("InsertUnique: record is missing key field %q", field)
+		}
+		qb.Eq(field, value)
+	}
+	query := qb.Build()
+
+	existing, err := c.Find(collection, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, &AlreadyExistsError{
+			Collection: collection,
+			KeyFields:  keyFields,
+			ExistingID: c.ExtractRecordID(collection, existing[0]),
+		}
+	}
+
+	result, err := c.Insert(collection, record)
+	if err != nil {
+		// A concurrent insert won the race and the server's unique index
+		// rejected ours; look the winner up so the caller gets its ID.
+		if httpErr, ok := err.(*HTTPError); ok && httpErr.IsConflict() {
+			existsErr := &AlreadyExistsError{Collection: collection, KeyFields: keyFields}
+			if winner, findErr := c.Find(collection, query); findErr == nil && len(winner) > 0 {
+				existsErr.ExistingID = c.ExtractRecordID(collection, winner[0])
+			}
+			return nil, existsErr
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
 // Exists checks if a record exists by ID
 // Returns true if the record exists, false if it doesn't.
-func (c *Client) Exists(collection, id string) (bool, error) {
+func (c *Client) Exists(collection, id string, opts ...RequestOption) (bool, error) {
+	c = c.withOptions(opts)
 	_, err := c.FindByID(collection, id)
 	if err != nil {
 		// Check if it's a 404 Not Found error
@@ -1254,7 +2075,8 @@ func (c *Client) Exists(collection, id string) (bool, error) {
 // Paginate retrieves records with pagination (1-indexed page numbers)
 // Page 1 = first page, Page 2 = second page, etc.
 // Returns an error if page < 1 or pageSize < 1.
-func (c *Client) Paginate(collection string, page, pageSize int) ([]Record, error) {
+func (c *Client) Paginate(collection string, page, pageSize int, opts ...RequestOption) ([]Record, error) {
+	c = c.withOptions(opts)
 	// Validate input parameters
 	if page < 1 {
 		return nil, fmt.Errorf("page must be >= 1, got %d", page)
@@ -1271,15 +2093,87 @@ func (c *Client) Paginate(collection string, page, pageSize int) ([]Record, erro
 	return c.Find(collection, query)
 }
 
+// KVSetOptions contains optional parameters for KVSet
+type KVSetOptions struct {
+	// TTL expires the key after this long. It is sent in whole seconds,
+	// rounded up.
+	TTL time.Duration
+	// ExpiresAt expires the key at this time; it takes precedence over TTL.
+	ExpiresAt time.Time
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
+}
+
 // KVSet sets a key-value pair
-func (c *Client) KVSet(key string, value interface{}) error {
+// Usage:
+//
+//	KVSet(key, value)                                  // no expiry
+//	KVSet(key, value, KVSetOptions{TTL: time.Hour})    // expires in an hour
+func (c *Client) KVSet(key string, value interface{}, opts ...KVSetOptions) error {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	ttl, err := kvSetTTL("KVSet", key, opts)
+	if err != nil {
+		return err
+	}
 	data := map[string]interface{}{"value": value}
-	_, err := c.makeRequest("POST", "/api/kv/set/"+url.PathEscape(key), data)
+	if ttl > 0 {
+		data["ttl"] = kvTTLSeconds(ttl)
+	}
+	_, err = c.makeRequest("POST", "/api/kv/set/"+url.PathEscape(key), data)
 	return err
 }
 
+// kvSetTTL returns the TTL requested by opts, zero for none.
+func kvSetTTL(op, key string, opts []KVSetOptions) (time.Duration, error) {
+	if len(opts) == 0 {
+		return 0, nil
+	}
+	ttl := opts[0].TTL
+	if !opts[0].ExpiresAt.IsZero() {
+		ttl = time.Until(opts[0].ExpiresAt)
+		if ttl <= 0 {
+			return 0, fmt.Errorf("%s %s: expiry %s is in the past", op, key, opts[0].ExpiresAt.Format(time.RFC3339))
+		}
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("%s %s: negative TTL %v", op, key, ttl)
+	}
+	return ttl, nil
+}
+
+// KVSetWithTTL sets a key-value pair that expires after ttl
+func (c *Client) KVSetWithTTL(key string, value interface{}, ttl time.Duration, opts ...RequestOption) error {
+	c = c.withOptions(opts)
+	if ttl <= 0 {
+		return fmt.Errorf("KVSetWithTTL %s: TTL must be positive, got %v", key, ttl)
+	}
+	return c.KVSet(key, value, KVSetOptions{TTL: ttl})
+}
+
+// KVSetWithExpiry sets a key-value pair that expires at expiresAt
+func (c *Client) KVSetWithExpiry(key string, value interface{}, expiresAt time.Time, opts ...RequestOption) error {
+	c = c.withOptions(opts)
+	if expiresAt.IsZero() {
+		return fmt.Errorf("KVSetWithExpiry %s: zero expiry time", key)
+	}
+	return c.KVSet(key, value, KVSetOptions{ExpiresAt: expiresAt})
+}
+
+// kvTTLSeconds converts a TTL to the whole seconds the KV endpoints take,
+// rounding up so that a key never expires early.
+func kvTTLSeconds(ttl time.Duration) int64 {
+	secs := int64(ttl / time.Second)
+	if ttl%time.Second != 0 {
+		secs++
+	}
+	return secs
+}
+
 // KVGet gets a value by key
-func (c *Client) KVGet(key string) (interface{}, error) {
+func (c *Client) KVGet(key string, opts ...RequestOption) (interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/kv/get/"+url.PathEscape(key), nil)
 	if err != nil {
 		return nil, err
@@ -1294,19 +2188,22 @@ func (c *Client) KVGet(key string) (interface{}, error) {
 }
 
 // KVDelete deletes a key
-func (c *Client) KVDelete(key string) error {
+func (c *Client) KVDelete(key string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", "/api/kv/delete/"+url.PathEscape(key), nil)
 	return err
 }
 
 // KVClear removes every key-value entry from the store (clears the KV namespace).
-func (c *Client) KVClear() error {
+func (c *Client) KVClear(opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", "/api/kv/clear", nil)
 	return err
 }
 
 // KVBatchGet retrieves multiple keys in a single request
-func (c *Client) KVBatchGet(keys []string) ([]map[string]interface{}, error) {
+func (c *Client) KVBatchGet(keys []string, opts ...RequestOption) ([]map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	data := map[string]interface{}{
 		"keys": keys,
 	}
@@ -1326,7 +2223,8 @@ func (c *Client) KVBatchGet(keys []string) ([]map[string]interface{}, error) {
 
 // KVBatchSet sets multiple key-value pairs in a single request.
 // TTL from the first entry with a valid TTL is applied to all entries (server limitation).
-func (c *Client) KVBatchSet(entries []map[string]interface{}) ([][]interface{}, error) {
+func (c *Client) KVBatchSet(entries []map[string]interface{}, opts ...RequestOption) ([][]interface{}, error) {
+	c = c.withOptions(opts)
 	keys := make([]string, len(entries))
 	values := make([]map[string]interface{}, len(entries))
 	var ttl *int64
@@ -1376,7 +2274,8 @@ func (c *Client) KVBatchSet(entries []map[string]interface{}) ([][]interface{},
 }
 
 // KVBatchDelete deletes multiple keys in a single request
-func (c *Client) KVBatchDelete(keys []string) ([][]interface{}, error) {
+func (c *Client) KVBatchDelete(keys []string, opts ...RequestOption) ([][]interface{}, error) {
+	c = c.withOptions(opts)
 	data := map[string]interface{}{
 		"keys": keys,
 	}
@@ -1395,7 +2294,8 @@ func (c *Client) KVBatchDelete(keys []string) ([][]interface{}, error) {
 }
 
 // KVExists checks if a key exists
-func (c *Client) KVExists(key string) (bool, error) {
+func (c *Client) KVExists(key string, opts ...RequestOption) (bool, error) {
+	c = c.withOptions(opts)
 	_, err := c.KVGet(key)
 	if err != nil {
 		// Check if it's a "not found" error using structured error type
@@ -1411,7 +2311,8 @@ func (c *Client) KVExists(key string) (bool, error) {
 // simple wildcards where '*' matches any sequence of characters in a key.
 // If includeExpired is true, results will also include entries that are past
 // their configured TTL but may still be present in the store.
-func (c *Client) KVFind(pattern string, includeExpired bool) ([]map[string]interface{}, error) {
+func (c *Client) KVFind(pattern string, includeExpired bool, opts ...RequestOption) ([]map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	data := map[string]interface{}{
 		"include_expired": includeExpired,
 	}
@@ -1433,7 +2334,8 @@ func (c *Client) KVFind(pattern string, includeExpired bool) ([]map[string]inter
 }
 
 // KVQuery is an alias for KVFind - queries KV store with pattern
-func (c *Client) KVQuery(pattern string, includeExpired bool) ([]map[string]interface{}, error) {
+func (c *Client) KVQuery(pattern string, includeExpired bool, opts ...RequestOption) ([]map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	return c.KVFind(pattern, includeExpired)
 }
 
@@ -1445,7 +2347,14 @@ func (c *Client) KVQuery(pattern string, includeExpired bool) ([]map[string]inte
 // The isolationLevel parameter must be one of: "READ_UNCOMMITTED", "READ_COMMITTED",
 // "REPEATABLE_READ", or "SERIALIZABLE". It returns the server-assigned transaction ID
 // as a string, or an error if the transaction could not be created.
-func (c *Client) BeginTransaction(isolationLevel string) (string, error) {
+func (c *Client) BeginTransaction(isolationLevel string, opts ...RequestOption) (string, error) {
+	c = c.withOptions(opts)
+	return c.beginTransaction(isolationLevel, 0)
+}
+
+// beginTransaction starts a transaction that the server discards after
+// timeoutSecs idle seconds, or its default when timeoutSecs is zero.
+func (c *Client) beginTransaction(isolationLevel string, timeoutSecs int64) (string, error) {
 	// Map user-friendly uppercase format to server's PascalCase format
 	isolationMap := map[string]string{
 		"READ_UNCOMMITTED": "ReadUncommitted",
@@ -1462,6 +2371,9 @@ func (c *Client) BeginTransaction(isolationLevel string) (string, error) {
 	data := map[string]interface{}{
 		"isolation_level": serverIsolation,
 	}
+	if timeoutSecs > 0 {
+		data["timeout_secs"] = timeoutSecs
+	}
 	respBody, err := c.makeRequest("POST", "/api/transactions", data)
 	if err != nil {
 		return "", err
@@ -1478,7 +2390,8 @@ func (c *Client) BeginTransaction(isolationLevel string) (string, error) {
 }
 
 // GetTransactionStatus gets the status of a transaction
-func (c *Client) GetTransactionStatus(transactionID string) (map[string]interface{}, error) {
+func (c *Client) GetTransactionStatus(transactionID string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/transactions/"+url.PathEscape(transactionID), nil)
 	if err != nil {
 		return nil, err
@@ -1501,21 +2414,24 @@ func (c *Client) GetTransactionStatus(transactionID string) (map[string]interfac
 // reads also carry the transaction ID. Commit may fail with an HTTP 409 conflict
 // if a record this transaction read or wrote was changed by another committed
 // transaction — retry the transaction in that case.
-func (c *Client) CommitTransaction(transactionID string) error {
+func (c *Client) CommitTransaction(transactionID string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("POST", "/api/transactions/"+url.PathEscape(transactionID)+"/commit", nil)
 	return err
 }
 
 // RollbackTransaction rolls back a transaction, discarding all staged writes
 // (nothing was applied).
-func (c *Client) RollbackTransaction(transactionID string) error {
+func (c *Client) RollbackTransaction(transactionID string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("POST", "/api/transactions/"+url.PathEscape(transactionID)+"/rollback", nil)
 	return err
 }
 
 // CreateSavepoint creates a named savepoint within a transaction. A later
 // RollbackToSavepoint discards everything staged after it.
-func (c *Client) CreateSavepoint(transactionID, name string) error {
+func (c *Client) CreateSavepoint(transactionID, name string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	data := map[string]interface{}{"name": name}
 	_, err := c.makeRequest("POST", "/api/transactions/"+url.PathEscape(transactionID)+"/savepoints", data)
 	return err
@@ -1523,19 +2439,22 @@ func (c *Client) CreateSavepoint(transactionID, name string) error {
 
 // RollbackToSavepoint rolls the transaction back to a savepoint, discarding
 // writes staged after it.
-func (c *Client) RollbackToSavepoint(transactionID, name string) error {
+func (c *Client) RollbackToSavepoint(transactionID, name string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("POST", "/api/transactions/"+url.PathEscape(transactionID)+"/savepoints/"+url.PathEscape(name)+"/rollback", nil)
 	return err
 }
 
 // ReleaseSavepoint releases (forgets) a savepoint. Staged work is unaffected.
-func (c *Client) ReleaseSavepoint(transactionID, name string) error {
+func (c *Client) ReleaseSavepoint(transactionID, name string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", "/api/transactions/"+url.PathEscape(transactionID)+"/savepoints/"+url.PathEscape(name), nil)
 	return err
 }
 
 // ListCollections lists all collections
-func (c *Client) ListCollections() ([]string, error) {
+func (c *Client) ListCollections(opts ...RequestOption) ([]string, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/collections", nil)
 	if err != nil {
 		return nil, err
@@ -1555,7 +2474,8 @@ func (c *Client) ListCollections() ([]string, error) {
 // ListUserCollections lists only user-created collections, excluding internal
 // chat/system collections the server maintains. It mirrors the other clients'
 // list_user_collections by passing the server's exclude_internal=true filter.
-func (c *Client) ListUserCollections() ([]string, error) {
+func (c *Client) ListUserCollections(opts ...RequestOption) ([]string, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/collections?exclude_internal=true", nil)
 	if err != nil {
 		return nil, err
@@ -1573,13 +2493,15 @@ func (c *Client) ListUserCollections() ([]string, error) {
 }
 
 // DeleteCollection deletes a collection
-func (c *Client) DeleteCollection(collection string) error {
+func (c *Client) DeleteCollection(collection string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", "/api/collections/"+collection, nil)
 	return err
 }
 
 // CollectionExists checks if a collection exists
-func (c *Client) CollectionExists(collection string) (bool, error) {
+func (c *Client) CollectionExists(collection string, opts ...RequestOption) (bool, error) {
+	c = c.withOptions(opts)
 	collections, err := c.ListCollections()
 	if err != nil {
 		return false, err
@@ -1594,8 +2516,12 @@ func (c *Client) CollectionExists(collection string) (bool, error) {
 	return false, nil
 }
 
-// CountDocuments counts the number of documents in a collection
-func (c *Client) CountDocuments(collection string) (int, error) {
+// CountDocuments counts the number of documents in a collection by fetching
+// them (up to 100,000).
+//
+// Deprecated: Use Count, which counts on the server.
+func (c *Client) CountDocuments(collection string, opts ...RequestOption) (int, error) {
+	c = c.withOptions(opts)
 	query := NewQueryBuilder().Limit(100000).Build()
 	records, err := c.Find(collection, query)
 	if err != nil {
@@ -1607,17 +2533,27 @@ func (c *Client) CountDocuments(collection string) (int, error) {
 
 // RestoreRecord restores a deleted record from trash
 // Records remain in trash for 30 days before permanent deletion
-func (c *Client) RestoreRecord(collection, id string) error {
+//
+// By default a restore fails with a 409 conflict (see HTTPError.IsConflict)
+// if a live record has the same ID; RestoreOptions chooses another behavior
+// or a different target collection.
+func (c *Client) RestoreRecord(collection, id string, opts ...RestoreOptions) error {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
 	path := fmt.Sprintf("/api/trash/%s/%s", url.PathEscape(collection), url.PathEscape(id))
-	_, err := c.makeRequest("POST", path, nil)
+	_, err := c.makeRequest("POST", restorePath(path, opts), nil)
 	return err
 }
 
 // RestoreCollection restores all deleted records in a collection from trash
 // Records remain in trash for 30 days before permanent deletion
-func (c *Client) RestoreCollection(collection string) (int, error) {
+func (c *Client) RestoreCollection(collection string, opts ...RestoreOptions) (int, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
 	path := fmt.Sprintf("/api/trash/%s", url.PathEscape(collection))
-	respBody, err := c.makeRequest("POST", path, nil)
+	respBody, err := c.makeRequest("POST", restorePath(path, opts), nil)
 	if err != nil {
 		return 0, err
 	}
@@ -1635,7 +2571,8 @@ func (c *Client) RestoreCollection(collection string) (int, error) {
 }
 
 // Health checks if the ekoDB server is healthy
-func (c *Client) Health() error {
+func (c *Client) Health(opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/health", nil)
 	if err != nil {
 		return err
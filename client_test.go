@@ -307,6 +307,66 @@ func TestHealthFailure(t *testing.T) {
 	}
 }
 
+func TestDefaultHeadersAndUserAgent(t *testing.T) {
+	var tokenUA, gotUA, gotTenant, gotAuth string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			gotUA = r.Header.Get("User-Agent")
+			gotTenant = r.Header.Get("X-Tenant")
+			gotAuth = r.Header.Get("Authorization")
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	})
+	defer server.Close()
+
+	wrapped := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth/token" {
+			tokenUA = r.Header.Get("User-Agent")
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer wrapped.Close()
+
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL: wrapped.URL,
+		APIKey:  "test-api-key",
+		Format:  JSON,
+		DefaultHeaders: map[string]string{
+			"X-Tenant":      "acme",
+			"Authorization": "ignored",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+	if err := client.Health(); err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+
+	wantUA := "ekodb-client-go/" + Version
+	if tokenUA != wantUA {
+		t.Errorf("token request User-Agent = %q, want %q", tokenUA, wantUA)
+	}
+	if gotUA != wantUA {
+		t.Errorf("User-Agent = %q, want %q", gotUA, wantUA)
+	}
+	if gotTenant != "acme" {
+		t.Errorf("X-Tenant = %q, want acme", gotTenant)
+	}
+	if gotAuth != "Bearer test-jwt-token" {
+		t.Errorf("DefaultHeaders must not override Authorization, got %q", gotAuth)
+	}
+
+	// Derived clients keep the default headers.
+	gotTenant = ""
+	if err := client.With(WithNoRetry()).Health(); err != nil {
+		t.Fatalf("Health via derived client failed: %v", err)
+	}
+	if gotTenant != "acme" {
+		t.Errorf("derived client X-Tenant = %q, want acme", gotTenant)
+	}
+}
+
 // ============================================================================
 // Insert Tests
 // ============================================================================
@@ -629,6 +689,140 @@ func TestBatchInsertSuccess(t *testing.T) {
 	}
 }
 
+func TestBatchReturnRecords(t *testing.T) {
+	var insertQuery, updateQuery string
+	handlers := map[string]http.HandlerFunc{
+		"POST /api/batch/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			insertQuery = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"successful": []string{"id_1"},
+				"failed":     []interface{}{},
+				"records":    []interface{}{map[string]interface{}{"id": "id_1", "name": "A", "created_at": "2024-01-01T00:00:00Z"}},
+			})
+		},
+		"PUT /api/batch/update/users": func(w http.ResponseWriter, r *http.Request) {
+			updateQuery = r.URL.RawQuery
+			// An older server ignores return_records.
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"successful": []string{"id_2", "id_1"},
+				"failed":     []interface{}{},
+			})
+		},
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("transaction_id") != "tx1" {
+				t.Errorf("fallback find outside the transaction: %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": "id_1", "name": "A2"},
+				{"id": "id_2", "name": "B2"},
+			})
+		},
+	}
+	server := createTestServer(t, handlers)
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	inserted, err := client.BatchInsert("users", []Record{{"name": "A"}}, BatchInsertOptions{ReturnRecords: true})
+	if err != nil {
+		t.Fatalf("BatchInsert failed: %v", err)
+	}
+	if insertQuery != "return_records=true" || len(inserted) != 1 || inserted[0]["created_at"] == nil {
+		t.Errorf("query %q, records %v", insertQuery, inserted)
+	}
+
+	tx := "tx1"
+	updated, err := client.BatchUpdate("users", map[string]Record{"id_1": {"name": "A2"}, "id_2": {"name": "B2"}},
+		BatchUpdateOptions{ReturnRecords: true, TransactionId: &tx})
+	if err != nil {
+		t.Fatalf("BatchUpdate failed: %v", err)
+	}
+	if !strings.Contains(updateQuery, "return_records=true") || len(updated) != 2 ||
+		updated[0]["name"] != "B2" || updated[1]["name"] != "A2" {
+		t.Errorf("query %q, records %v", updateQuery, updated)
+	}
+}
+
+func TestBatchInsertTTL(t *testing.T) {
+	var body struct {
+		Inserts []struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"inserts"`
+	}
+	handlers := map[string]http.HandlerFunc{
+		"POST /api/batch/insert/sessions": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"successful": []string{"s1", "s2", "s3", "s4"},
+				"failed":     []interface{}{},
+			})
+		},
+	}
+	server := createTestServer(t, handlers)
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	records := []Record{
+		{"n": 1},
+		{"n": 2},
+		{"n": 3, "ttl": "5m"},
+		{"n": 4},
+	}
+	_, err := client.BatchInsert("sessions", records, BatchInsertOptions{TTL: "1h", TTLs: []string{"", "30s", "10s"}})
+	if err != nil {
+		t.Fatalf("BatchInsert failed: %v", err)
+	}
+	want := []interface{}{"1h", "30s", "5m", "1h"}
+	for i, ins := range body.Inserts {
+		if ins.Data["ttl"] != want[i] {
+			t.Errorf("record %d ttl = %v, want %v", i, ins.Data["ttl"], want[i])
+		}
+	}
+	if _, ok := records[0]["ttl"]; ok {
+		t.Error("BatchInsert modified the caller's record")
+	}
+
+	if _, err := client.BatchInsert("sessions", records[:1], BatchInsertOptions{TTLs: []string{"1m", "2m"}}); err == nil {
+		t.Error("expected an error for more TTLs than records")
+	}
+}
+
+func TestDeletePermanent(t *testing.T) {
+	var deleteQuery, batchQuery string
+	handlers := map[string]http.HandlerFunc{
+		"DELETE /api/delete/users/user_1": func(w http.ResponseWriter, r *http.Request) {
+			deleteQuery = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "user_1"})
+		},
+		"DELETE /api/batch/delete/users": func(w http.ResponseWriter, r *http.Request) {
+			batchQuery = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"successful": []string{"user_1", "user_2"},
+				"failed":     []interface{}{},
+			})
+		},
+	}
+	server := createTestServer(t, handlers)
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	if err := client.Delete("users", "user_1", DeleteOptions{Permanent: true}); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if deleteQuery != "permanent=true" {
+		t.Errorf("Delete query = %q", deleteQuery)
+	}
+	tx := "tx1"
+	if _, err := client.BatchDelete("users", []string{"user_1", "user_2"}, BatchDeleteOptions{Permanent: true, TransactionId: &tx}); err != nil {
+		t.Fatalf("BatchDelete failed: %v", err)
+	}
+	if batchQuery != "permanent=true&transaction_id=tx1" {
+		t.Errorf("BatchDelete query = %q", batchQuery)
+	}
+	if err := client.Delete("users", "user_1"); err != nil || deleteQuery != "" {
+		t.Errorf("plain Delete sent %q (err %v)", deleteQuery, err)
+	}
+}
+
 func TestBatchDeleteSuccess(t *testing.T) {
 	handlers := map[string]http.HandlerFunc{
 		"DELETE /api/batch/delete/users": func(w http.ResponseWriter, r *http.Request) {
@@ -701,6 +895,47 @@ func TestKVSetSuccess(t *testing.T) {
 	}
 }
 
+func TestKVSetTTL(t *testing.T) {
+	var bodies []map[string]interface{}
+	handlers := map[string]http.HandlerFunc{
+		"POST /api/kv/set/session": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			bodies = append(bodies, body)
+			_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+		},
+	}
+	server := createTestServer(t, handlers)
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	if err := client.KVSetWithTTL("session", "abc", 1500*time.Millisecond); err != nil {
+		t.Fatalf("KVSetWithTTL failed: %v", err)
+	}
+	if err := client.KVSetWithExpiry("session", "abc", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("KVSetWithExpiry failed: %v", err)
+	}
+	if err := client.KVSet("session", "abc"); err != nil {
+		t.Fatalf("KVSet failed: %v", err)
+	}
+	if len(bodies) != 3 || bodies[0]["ttl"] != float64(2) || bodies[0]["value"] != "abc" {
+		t.Fatalf("bodies = %v", bodies)
+	}
+	if ttl, _ := bodies[1]["ttl"].(float64); ttl < 3599 || ttl > 3600 {
+		t.Errorf("expiry TTL = %v, want about 3600", bodies[1]["ttl"])
+	}
+	if _, ok := bodies[2]["ttl"]; ok {
+		t.Error("KVSet without options sent a TTL")
+	}
+
+	if err := client.KVSetWithExpiry("session", "abc", time.Now().Add(-time.Minute)); err == nil {
+		t.Error("expected an error for an expiry in the past")
+	}
+	if err := client.KVSetWithTTL("session", "abc", 0); err == nil {
+		t.Error("expected an error for a zero TTL")
+	}
+}
+
 func TestKVGetSuccess(t *testing.T) {
 	handlers := map[string]http.HandlerFunc{
 		"GET /api/kv/get/my_key": func(w http.ResponseWriter, r *http.Request) {
@@ -1573,6 +1808,55 @@ func TestSearchQueryBuilderFiltersOmittedWhenUnset(t *testing.T) {
 	}
 }
 
+func TestSearchSnapshotPagination(t *testing.T) {
+	var requests []SearchQuery
+	handlers := map[string]http.HandlerFunc{
+		"POST /api/search/documents": func(w http.ResponseWriter, r *http.Request) {
+			var q SearchQuery
+			_ = json.NewDecoder(r.Body).Decode(&q)
+			requests = append(requests, q)
+			offset := 0
+			if q.Offset != nil {
+				offset = *q.Offset
+			}
+			var results []map[string]interface{}
+			for i := offset; i < offset+*q.Limit && i < 5; i++ {
+				results = append(results, map[string]interface{}{"record": map[string]interface{}{"id": fmt.Sprint(i)}, "score": 1})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"results":        results,
+				"total":          5,
+				"snapshot_token": "snap-1",
+			})
+		},
+	}
+	server := createTestServer(t, handlers)
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	q := NewSearchQueryBuilder("terms").Limit(2).Build()
+	var ids []string
+	for {
+		resp, err := client.Search("documents", q)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		for _, r := range resp.Results {
+			ids = append(ids, r.Record["id"].(string))
+		}
+		var more bool
+		if q, more = q.NextPage(resp); !more {
+			break
+		}
+	}
+	if strings.Join(ids, ",") != "0,1,2,3,4" {
+		t.Errorf("paged ids = %v", ids)
+	}
+	if len(requests) != 3 || requests[0].SnapshotToken != "" || requests[1].SnapshotToken != "snap-1" || *requests[2].Offset != 4 {
+		t.Errorf("unexpected requests: %+v", requests)
+	}
+}
+
 // ============================================================================
 // KV Find/Query Tests
 // ============================================================================
@@ -1990,6 +2274,46 @@ func TestFindAllSuccess(t *testing.T) {
 	}
 }
 
+func TestFindAllLimitedAndPages(t *testing.T) {
+	var limits []int
+	handlers := map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Limit int `json:"limit"`
+				Skip  int `json:"skip"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			limits = append(limits, body.Limit)
+			page := []map[string]interface{}{}
+			for i := body.Skip; i < 5 && i < body.Skip+body.Limit; i++ {
+				page = append(page, map[string]interface{}{"id": fmt.Sprintf("user_%d", i)})
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		},
+	}
+	server := createTestServer(t, handlers)
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	records, truncated, err := client.FindAllLimited("users", 3)
+	if err != nil || len(records) != 3 || !truncated || limits[0] != 4 {
+		t.Errorf("FindAllLimited(3) = %d records, truncated %v, err %v (limit sent %v)", len(records), truncated, err, limits)
+	}
+	records, truncated, err = client.FindAllLimited("users", 5)
+	if err != nil || len(records) != 5 || truncated {
+		t.Errorf("FindAllLimited(5) = %d records, truncated %v, err %v", len(records), truncated, err)
+	}
+
+	limits = nil
+	records, err = client.FindAllPages("users", 2)
+	if err != nil {
+		t.Fatalf("FindAllPages failed: %v", err)
+	}
+	if len(records) != 5 || records[4]["id"] != "user_4" || len(limits) != 3 {
+		t.Errorf("FindAllPages = %v in %d requests", records, len(limits))
+	}
+}
+
 func TestKVQuerySuccess(t *testing.T) {
 	handlers := map[string]http.HandlerFunc{
 		"POST /api/kv/find": func(w http.ResponseWriter, r *http.Request) {
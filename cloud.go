@@ -0,0 +1,195 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// CloudClient manages hosted ekoDB instances through the ekoDB Cloud control
+// plane: listing and provisioning instances, creating and destroying
+// databases, and rotating instance API keys. Use Connect to obtain a data
+// Client for a specific instance.
+//
+// A CloudClient authenticates with an account-level API key and otherwise
+// behaves like a Client (token refresh, retries, rate limiting, transport
+// settings), since it is built from the same ClientConfig.
+type CloudClient struct {
+	client *Client
+}
+
+// Instance describes a hosted ekoDB instance.
+type Instance struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Region    string `json:"region,omitempty"`
+	Plan      string `json:"plan,omitempty"`
+	Status    string `json:"status,omitempty"` // e.g. "provisioning", "running", "deleting"
+	URL       string `json:"url,omitempty"`    // Base URL for data clients
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// CreateInstanceRequest is the body for CloudClient.CreateInstance.
+type CreateInstanceRequest struct {
+	Name   string `json:"name"`
+	Region string `json:"region,omitempty"`
+	Plan   string `json:"plan,omitempty"`
+}
+
+// Database describes a database hosted on an instance.
+type Database struct {
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// InstanceKey is a newly issued instance API key returned by
+// RotateInstanceKey. The key is only returned once.
+type InstanceKey struct {
+	InstanceID string `json:"instance_id"`
+	APIKey     string `json:"api_key"`
+	ExpiresAt  string `json:"expires_at,omitempty"` // When the previous key stops working, if a grace period applies
+}
+
+// NewCloudClient creates a control-plane client. config.BaseURL is the
+// control-plane URL and config.APIKey an account API key; every other
+// ClientConfig field applies as it does for NewClientWithConfig.
+func NewCloudClient(config ClientConfig) (*CloudClient, error) {
+	client, err := NewClientWithConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudClient{client: client}, nil
+}
+
+// ListInstances lists the instances visible to the account.
+func (cc *CloudClient) ListInstances() ([]Instance, error) {
+	respBody, err := cc.client.makeRequest("GET", "/api/instances", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Instances []Instance `json:"instances"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, err
+	}
+	return result.Instances, nil
+}
+
+// GetInstance retrieves an instance by ID.
+func (cc *CloudClient) GetInstance(instanceID string) (*Instance, error) {
+	respBody, err := cc.client.makeRequest("GET", fmt.Sprintf("/api/instances/%s", url.PathEscape(instanceID)), nil)
+	if err != nil {
+		return nil, err
+	}
+	var instance Instance
+	if err := json.Unmarshal(respBody, &instance); err != nil {
+		return nil, err
+	}
+	return &instance, nil
+}
+
+// CreateInstance provisions a new instance. The returned instance is usually
+// still "provisioning"; poll GetInstance until its Status is "running".
+func (cc *CloudClient) CreateInstance(req CreateInstanceRequest) (*Instance, error) {
+	if req.Name == "" {
+		return nil, fmt.Errorf("instance name is required")
+	}
+	respBody, err := cc.client.makeRequest("POST", "/api/instances", req)
+	if err != nil {
+		return nil, err
+	}
+	var instance Instance
+	if err := json.Unmarshal(respBody, &instance); err != nil {
+		return nil, err
+	}
+	return &instance, nil
+}
+
+// DeleteInstance destroys an instance and all of its data.
+func (cc *CloudClient) DeleteInstance(instanceID string) error {
+	_, err := cc.client.makeRequest("DELETE", fmt.Sprintf("/api/instances/%s", url.PathEscape(instanceID)), nil)
+	return err
+}
+
+// ListDatabases lists the databases on an instance.
+func (cc *CloudClient) ListDatabases(instanceID string) ([]Database, error) {
+	respBody, err := cc.client.makeRequest("GET", fmt.Sprintf("/api/instances/%s/databases", url.PathEscape(instanceID)), nil)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Databases []Database `json:"databases"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, err
+	}
+	return result.Databases, nil
+}
+
+// CreateDatabase creates a database on an instance.
+func (cc *CloudClient) CreateDatabase(instanceID, name string) (*Database, error) {
+	path := fmt.Sprintf("/api/instances/%s/databases", url.PathEscape(instanceID))
+	respBody, err := cc.client.makeRequest("POST", path, map[string]string{"name": name})
+	if err != nil {
+		return nil, err
+	}
+	var db Database
+	if err := json.Unmarshal(respBody, &db); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+// DeleteDatabase destroys a database on an instance.
+func (cc *CloudClient) DeleteDatabase(instanceID, name string) error {
+	path := fmt.Sprintf("/api/instances/%s/databases/%s", url.PathEscape(instanceID), url.PathEscape(name))
+	_, err := cc.client.makeRequest("DELETE", path, nil)
+	return err
+}
+
+// RotateInstanceKey issues a new API key for an instance. Clients connected
+// with the old key keep working until it expires (InstanceKey.ExpiresAt);
+// reconnect them with the new key via Connect.
+func (cc *CloudClient) RotateInstanceKey(instanceID string) (*InstanceKey, error) {
+	path := fmt.Sprintf("/api/instances/%s/keys/rotate", url.PathEscape(instanceID))
+	respBody, err := cc.client.makeRequest("POST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var key InstanceKey
+	if err := json.Unmarshal(respBody, &key); err != nil {
+		return nil, err
+	}
+	if key.InstanceID == "" {
+		key.InstanceID = instanceID
+	}
+	return &key, nil
+}
+
+// Connect returns a data Client for an instance, authenticated with the
+// instance's apiKey. Settings are taken from config when given (its BaseURL
+// and APIKey are replaced); otherwise the defaults of NewClient apply. Calling
+// Connect again with another instance ID is how an application switches
+// between environments.
+//
+// Example:
+//
+//	staging, err := cloud.Connect(stagingID, stagingKey)
+func (cc *CloudClient) Connect(instanceID, apiKey string, config ...ClientConfig) (*Client, error) {
+	instance, err := cc.GetInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if instance.URL == "" {
+		return nil, fmt.Errorf("instance %s has no URL yet (status: %s)", instanceID, instance.Status)
+	}
+
+	cfg := ClientConfig{ShouldRetry: true}
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	cfg.BaseURL = instance.URL
+	cfg.APIKey = apiKey
+	return NewClientWithConfig(cfg)
+}
@@ -0,0 +1,141 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func createTestCloudClient(t *testing.T, handlers map[string]http.HandlerFunc) (*CloudClient, func()) {
+	t.Helper()
+	server := createTestServer(t, handlers)
+	cloud, err := NewCloudClient(ClientConfig{BaseURL: server.URL, APIKey: "test-api-key", Format: JSON})
+	if err != nil {
+		server.Close()
+		t.Fatalf("NewCloudClient failed: %v", err)
+	}
+	return cloud, server.Close
+}
+
+func TestCloudInstanceLifecycle(t *testing.T) {
+	var created CreateInstanceRequest
+	deleted := ""
+	cloud, closeServer := createTestCloudClient(t, map[string]http.HandlerFunc{
+		"GET /api/instances": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"instances": []Instance{{ID: "i1", Name: "prod", Status: "running"}},
+			})
+		},
+		"POST /api/instances": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(Instance{ID: "i2", Name: created.Name, Status: "provisioning"})
+		},
+		"DELETE /api/instances/i2": func(w http.ResponseWriter, r *http.Request) {
+			deleted = "i2"
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	defer closeServer()
+
+	instances, err := cloud.ListInstances()
+	if err != nil {
+		t.Fatalf("ListInstances failed: %v", err)
+	}
+	if len(instances) != 1 || instances[0].ID != "i1" || instances[0].Status != "running" {
+		t.Errorf("unexpected instances: %+v", instances)
+	}
+
+	instance, err := cloud.CreateInstance(CreateInstanceRequest{Name: "staging", Region: "eu-west"})
+	if err != nil {
+		t.Fatalf("CreateInstance failed: %v", err)
+	}
+	if created.Name != "staging" || created.Region != "eu-west" || instance.ID != "i2" {
+		t.Errorf("unexpected create: request=%+v instance=%+v", created, instance)
+	}
+	if _, err := cloud.CreateInstance(CreateInstanceRequest{}); err == nil {
+		t.Error("expected error for missing instance name")
+	}
+
+	if err := cloud.DeleteInstance("i2"); err != nil {
+		t.Fatalf("DeleteInstance failed: %v", err)
+	}
+	if deleted != "i2" {
+		t.Error("DeleteInstance did not reach the server")
+	}
+}
+
+func TestCloudDatabasesAndKeys(t *testing.T) {
+	var dbName string
+	cloud, closeServer := createTestCloudClient(t, map[string]http.HandlerFunc{
+		"GET /api/instances/i1/databases": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"databases": []Database{{Name: "main"}}})
+		},
+		"POST /api/instances/i1/databases": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			dbName = body["name"]
+			_ = json.NewEncoder(w).Encode(Database{Name: dbName})
+		},
+		"DELETE /api/instances/i1/databases/analytics": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /api/instances/i1/keys/rotate": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"api_key": "new-key"})
+		},
+	})
+	defer closeServer()
+
+	dbs, err := cloud.ListDatabases("i1")
+	if err != nil || len(dbs) != 1 || dbs[0].Name != "main" {
+		t.Fatalf("ListDatabases = %+v, %v", dbs, err)
+	}
+	db, err := cloud.CreateDatabase("i1", "analytics")
+	if err != nil || db.Name != "analytics" || dbName != "analytics" {
+		t.Fatalf("CreateDatabase = %+v, %v", db, err)
+	}
+	if err := cloud.DeleteDatabase("i1", "analytics"); err != nil {
+		t.Fatalf("DeleteDatabase failed: %v", err)
+	}
+
+	key, err := cloud.RotateInstanceKey("i1")
+	if err != nil {
+		t.Fatalf("RotateInstanceKey failed: %v", err)
+	}
+	if key.APIKey != "new-key" || key.InstanceID != "i1" {
+		t.Errorf("unexpected key: %+v", key)
+	}
+}
+
+func TestCloudConnect(t *testing.T) {
+	data := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	})
+	defer data.Close()
+
+	cloud, closeServer := createTestCloudClient(t, map[string]http.HandlerFunc{
+		"GET /api/instances/i1": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(Instance{ID: "i1", Status: "running", URL: data.URL})
+		},
+		"GET /api/instances/i2": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(Instance{ID: "i2", Status: "provisioning"})
+		},
+	})
+	defer closeServer()
+
+	client, err := cloud.Connect("i1", "test-api-key", ClientConfig{Format: JSON})
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if client.baseURL != data.URL {
+		t.Errorf("baseURL = %q, want %q", client.baseURL, data.URL)
+	}
+	if err := client.Health(); err != nil {
+		t.Fatalf("Health on connected client failed: %v", err)
+	}
+
+	if _, err := cloud.Connect("i2", "test-api-key"); err == nil {
+		t.Error("expected error connecting to an instance without a URL")
+	}
+}
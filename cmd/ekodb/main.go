@@ -0,0 +1,64 @@
+// Command ekodb is a small administration tool built on the Go client.
+//
+// Usage:
+//
+//	ekodb schema graph [-format dot|mermaid] [-o file]
+//
+// The server and credentials are read from the EKODB_URL and EKODB_API_KEY
+// environment variables, or from the -url and -api-key flags.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ekoDB/ekodb-client-go"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "ekodb:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) < 2 || args[0] != "schema" || args[1] != "graph" {
+		return fmt.Errorf("usage: ekodb schema graph [-format dot|mermaid] [-o file]")
+	}
+
+	fs := flag.NewFlagSet("schema graph", flag.ContinueOnError)
+	baseURL := fs.String("url", os.Getenv("EKODB_URL"), "ekoDB server URL")
+	apiKey := fs.String("api-key", os.Getenv("EKODB_API_KEY"), "ekoDB API key")
+	format := fs.String("format", "mermaid", "output format: dot or mermaid")
+	output := fs.String("o", "", "write to file instead of stdout")
+	if err := fs.Parse(args[2:]); err != nil {
+		return err
+	}
+	if *baseURL == "" || *apiKey == "" {
+		return fmt.Errorf("set EKODB_URL and EKODB_API_KEY (or -url and -api-key)")
+	}
+
+	client, err := ekodb.NewClientWithConfig(ekodb.ClientConfig{
+		BaseURL:     *baseURL,
+		APIKey:      *apiKey,
+		ShouldRetry: true,
+		Format:      ekodb.JSON,
+	})
+	if err != nil {
+		return err
+	}
+
+	w := stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return client.ExportSchemaGraph(w, ekodb.SchemaGraphFormat(*format))
+}
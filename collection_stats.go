@@ -0,0 +1,57 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CollectionStats holds the analytics the server reports for a collection.
+// Fields the server leaves out are zero.
+type CollectionStats struct {
+	RecordCount      int64            `json:"record_count"`
+	StorageSizeBytes int64            `json:"storage_size_bytes"`
+	IndexSizes       map[string]int64 `json:"index_sizes,omitempty"` // Bytes per index, by index name
+	CacheHits        int64            `json:"cache_hits"`
+	CacheMisses      int64            `json:"cache_misses"`
+	CacheHitRate     float64          `json:"cache_hit_rate"` // 0 to 1
+}
+
+// IndexSizeBytes returns the combined size of the collection's indexes.
+func (s *CollectionStats) IndexSizeBytes() int64 {
+	var total int64
+	for _, n := range s.IndexSizes {
+		total += n
+	}
+	return total
+}
+
+// GetCollectionStats returns the analytics for collection, as returned in
+// CollectionMetadata.Analytics by GetCollection. When the server reports
+// cache hits and misses but no hit rate, the rate is derived from them.
+func (c *Client) GetCollectionStats(collection string, opts ...RequestOption) (*CollectionStats, error) {
+	c = c.withOptions(opts)
+	metadata, err := c.GetCollection(collection)
+	if err != nil {
+		return nil, err
+	}
+	return parseCollectionStats(metadata.Analytics)
+}
+
+// parseCollectionStats decodes the untyped analytics of CollectionMetadata.
+func parseCollectionStats(analytics interface{}) (*CollectionStats, error) {
+	stats := &CollectionStats{}
+	if analytics == nil {
+		return stats, nil
+	}
+	data, err := json.Marshal(analytics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode collection analytics: %w", err)
+	}
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, fmt.Errorf("failed to decode collection analytics: %w", err)
+	}
+	if lookups := stats.CacheHits + stats.CacheMisses; stats.CacheHitRate == 0 && lookups > 0 {
+		stats.CacheHitRate = float64(stats.CacheHits) / float64(lookups)
+	}
+	return stats, nil
+}
@@ -0,0 +1,50 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetCollectionStats(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/collections/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"collection": map[string]interface{}{"fields": map[string]interface{}{}},
+				"analytics": map[string]interface{}{
+					"record_count":       1200,
+					"storage_size_bytes": 4096,
+					"index_sizes":        map[string]interface{}{"email": 512, "name": 256},
+					"cache_hits":         75,
+					"cache_misses":       25,
+				},
+			})
+		},
+		"GET /api/collections/empty": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"collection": map[string]interface{}{"fields": map[string]interface{}{}},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	stats, err := client.GetCollectionStats("users")
+	if err != nil {
+		t.Fatalf("GetCollectionStats failed: %v", err)
+	}
+	if stats.RecordCount != 1200 || stats.StorageSizeBytes != 4096 || stats.IndexSizeBytes() != 768 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if stats.CacheHitRate != 0.75 {
+		t.Errorf("CacheHitRate = %v, want 0.75", stats.CacheHitRate)
+	}
+
+	stats, err = client.GetCollectionStats("empty")
+	if err != nil {
+		t.Fatalf("GetCollectionStats failed: %v", err)
+	}
+	if stats.RecordCount != 0 || stats.IndexSizes != nil {
+		t.Errorf("expected zero stats, got %+v", stats)
+	}
+}
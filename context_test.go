@@ -0,0 +1,128 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type ctxKey string
+
+func TestWithContextPropagatesContextHeaders(t *testing.T) {
+	var gotUser, gotTrace, gotLocale string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/find/users/u1": func(w http.ResponseWriter, r *http.Request) {
+			gotUser = r.Header.Get("X-User-ID")
+			gotTrace = r.Header.Get("X-Trace-ID")
+			gotLocale = r.Header.Get("Accept-Language")
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(Record{"id": "u1"})
+		},
+	})
+	defer server.Close()
+
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL: server.URL,
+		APIKey:  "test-api-key",
+		Format:  JSON,
+		ContextHeaders: map[string]interface{}{
+			"X-User-ID":       ctxKey("user"),
+			"X-Trace-ID":      ctxKey("trace"),
+			"Accept-Language": ctxKey("locale"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey("user"), "user-42")
+	ctx = context.WithValue(ctx, ctxKey("trace"), 12345)
+
+	if _, err := client.WithContext(ctx).FindByID("users", "u1"); err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if gotUser != "user-42" {
+		t.Errorf("X-User-ID = %q, want user-42", gotUser)
+	}
+	if gotTrace != "12345" {
+		t.Errorf("X-Trace-ID = %q, want 12345", gotTrace)
+	}
+	if gotLocale != "" {
+		t.Errorf("Accept-Language should be omitted when absent from context, got %q", gotLocale)
+	}
+
+	// The base client has no bound context, so no headers are sent.
+	if _, err := client.FindByID("users", "u1"); err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if gotUser != "" || gotTrace != "" {
+		t.Errorf("base client leaked context headers: user=%q trace=%q", gotUser, gotTrace)
+	}
+}
+
+func TestWithContextSharesAuthState(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	derived := client.WithContext(context.Background())
+	if derived == client {
+		t.Fatal("WithContext should return a new client")
+	}
+
+	// Clearing the token on the derived client clears it for the parent too.
+	derived.ClearTokenCache()
+	client.tokenMu.RLock()
+	token := client.token
+	client.tokenMu.RUnlock()
+	if token != "" {
+		t.Errorf("expected parent token cleared via derived client, got %q", token)
+	}
+
+	if _, err := derived.RefreshToken(); err != nil {
+		t.Fatalf("RefreshToken failed: %v", err)
+	}
+	if client.getToken() != "test-jwt-token" {
+		t.Errorf("expected parent to observe refreshed token, got %q", client.getToken())
+	}
+	if err := derived.Health(); err != nil {
+		t.Fatalf("Health via derived client failed: %v", err)
+	}
+}
+
+func TestWithContextCancellationAbortsRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		},
+	})
+	defer server.Close()
+	defer close(release)
+
+	client := createTestClient(t, server)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.WithContext(ctx).Health()
+	if err == nil {
+		t.Fatal("expected error from cancelled context")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("request was not aborted promptly (took %v)", elapsed)
+	}
+}
@@ -2,6 +2,7 @@ package ekodb
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
 )
@@ -182,3 +183,97 @@ func TestPaginate_Page2(t *testing.T) {
 		t.Errorf("Expected 2 results, got %d", len(results))
 	}
 }
+
+func TestInsertUnique_Inserts(t *testing.T) {
+	inserted := false
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			filter, _ := body["filter"].(map[string]interface{})
+			content, _ := filter["content"].(map[string]interface{})
+			if content["field"] != "email" || content["value"] != "a@example.com" {
+				t.Errorf("unexpected uniqueness filter: %v", body["filter"])
+			}
+			_ = json.NewEncoder(w).Encode([]Record{})
+		},
+		"POST /api/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			inserted = true
+			_ = json.NewEncoder(w).Encode(Record{"id": "u1"})
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	result, err := client.InsertUnique("users", Record{"email": "a@example.com"}, "email")
+	if err != nil {
+		t.Fatalf("InsertUnique failed: %v", err)
+	}
+	if !inserted || result["id"] != "u1" {
+		t.Errorf("expected insert of u1, got inserted=%v result=%v", inserted, result)
+	}
+}
+
+func TestInsertUnique_AlreadyExists(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode([]Record{{"id": "existing"}})
+		},
+		"POST /api/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			t.Error("insert must not be attempted when the key exists")
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	_, err := client.InsertUnique("users", Record{"email": "a@example.com", "tenant": "t1"}, "email", "tenant")
+	var exists *AlreadyExistsError
+	if !errors.As(err, &exists) {
+		t.Fatalf("expected AlreadyExistsError, got %v", err)
+	}
+	if exists.ExistingID != "existing" {
+		t.Errorf("ExistingID = %q, want existing", exists.ExistingID)
+	}
+	if len(exists.KeyFields) != 2 {
+		t.Errorf("KeyFields = %v, want [email tenant]", exists.KeyFields)
+	}
+}
+
+func TestInsertUnique_ConflictFromUniqueIndex(t *testing.T) {
+	finds := 0
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			finds++
+			if finds == 1 {
+				_ = json.NewEncoder(w).Encode([]Record{})
+				return
+			}
+			_ = json.NewEncoder(w).Encode([]Record{{"id": "winner"}})
+		},
+		"POST /api/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("unique constraint violated"))
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	_, err := client.InsertUnique("users", Record{"email": "a@example.com"}, "email")
+	var exists *AlreadyExistsError
+	if !errors.As(err, &exists) {
+		t.Fatalf("expected AlreadyExistsError, got %v", err)
+	}
+	if exists.ExistingID != "winner" {
+		t.Errorf("ExistingID = %q, want winner", exists.ExistingID)
+	}
+}
+
+func TestInsertUnique_MissingKeyField(t *testing.T) {
+	client := &Client{}
+	if _, err := client.InsertUnique("users", Record{"name": "x"}, "email"); err == nil {
+		t.Fatal("expected error for a record missing its key field")
+	}
+	if _, err := client.InsertUnique("users", Record{"name": "x"}); err == nil {
+		t.Fatal("expected error when no key fields are given")
+	}
+}
@@ -0,0 +1,146 @@
+package ekodb
+
+import "fmt"
+
+// CopyOptions contains optional parameters for CopyCollection
+type CopyOptions struct {
+	// ChunkSize is the number of records read and inserted per request
+	// (default: 500).
+	ChunkSize int
+	// Filter copies only matching records; it takes the same forms as for
+	// Count. Nil copies everything.
+	Filter interface{}
+	// Transform rewrites each record before it is inserted. Returning a nil
+	// record skips it; returning an error stops the copy.
+	Transform func(Record) (Record, error)
+	// Progress is called after each chunk with the number of source records
+	// processed so far (copied or skipped by Transform) and the number
+	// matching, counted up front.
+	Progress ProgressFunc
+	// SkipSchema copies only data, into a destination that must already
+	// exist. By default the destination is created with the source's schema
+	// if it does not exist.
+	SkipSchema bool
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
+}
+
+// CopyCollection copies the records of src into dst and returns how many
+// were copied. Records keep their IDs. They are streamed a chunk at a time in
+// id order, so memory use does not grow with the collection:
+//
+//	n, err := client.CopyCollection("orders", "orders_staging", ekodb.CopyOptions{
+//	    Transform: func(r ekodb.Record) (ekodb.Record, error) {
+//	        delete(r, "card_number")
+//	        return r, nil
+//	    },
+//	    Progress: func(n int) { log.Printf("copied %d", n) },
+//	})
+//
+// The copy is not atomic: writes to src while it runs may or may not be
+// copied, and a failure leaves the chunks already copied in dst. The count
+// returned with an error is the number copied before it. Cancelling the
+// client's context (see WithContext) stops the copy after the chunk in
+// flight.
+func (c *Client) CopyCollection(src, dst string, opts ...CopyOptions) (int, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	var o CopyOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.ChunkSize <= 0 {
+		o.ChunkSize = 500
+	}
+	if src == dst {
+		return 0, fmt.Errorf("copy collection: source and destination are both %s", src)
+	}
+	if err := validateQueryArg(o.Filter); err != nil {
+		return 0, fmt.Errorf("copy collection %s: %w", src, err)
+	}
+
+	if !o.SkipSchema {
+		if err := c.copySchema(src, dst); err != nil {
+			return 0, err
+		}
+	}
+
+	total := -1
+	if o.Progress != nil {
+		if n, err := c.Count(src, o.Filter); err == nil {
+			total = n
+		} else {
+			c.logf("CopyCollection(%s): could not count records for progress: %v", src, err)
+		}
+	}
+
+	query := NewQueryBuilder().SortAscending("id").Build()
+	if filter := filterOf(o.Filter); filter != nil {
+		query["filter"] = filter
+	}
+	cur := c.FindCursor(src, query).PageSize(o.ChunkSize)
+	copied, read := 0, 0
+	chunk := make([]Record, 0, o.ChunkSize)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		if _, err := c.BatchInsert(dst, chunk); err != nil {
+			err = fmt.Errorf("copy into %s failed: %w", dst, err)
+			if o.Progress != nil {
+				o.Progress(read, total, err)
+			}
+			return err
+		}
+		copied += len(chunk)
+		chunk = chunk[:0]
+		if o.Progress != nil {
+			o.Progress(read, total, nil)
+		}
+		return nil
+	}
+
+	for cur.Next(c.context()) {
+		rec := cur.Record()
+		read++
+		if o.Transform != nil {
+			var err error
+			if rec, err = o.Transform(rec); err != nil {
+				return copied, fmt.Errorf("copy transform: %w", err)
+			}
+			if rec == nil {
+				continue
+			}
+		}
+		chunk = append(chunk, rec)
+		if len(chunk) == o.ChunkSize {
+			if err := flush(); err != nil {
+				return copied, err
+			}
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return copied, err
+	}
+	return copied, flush()
+}
+
+// copySchema creates dst with src's schema unless dst already exists.
+func (c *Client) copySchema(src, dst string) error {
+	exists, err := c.CollectionExists(dst)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	schema, err := c.GetSchema(src)
+	if err != nil {
+		return fmt.Errorf("read schema of %s: %w", src, err)
+	}
+	if err := c.CreateCollection(dst, *schema); err != nil {
+		return fmt.Errorf("create %s: %w", dst, err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCopyCollection(t *testing.T) {
+	var created bool
+	var inserted []map[string]interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/collections": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"collections": []string{"orders"}})
+		},
+		"GET /api/collections/orders": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"collection": map[string]interface{}{"fields": map[string]interface{}{"total": map[string]interface{}{"field_type": "Float"}}},
+			})
+		},
+		"POST /api/collections/orders_copy": func(w http.ResponseWriter, r *http.Request) {
+			created = true
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+		"POST /api/find/orders": func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Limit int `json:"limit"`
+				Skip  int `json:"skip"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			page := []map[string]interface{}{}
+			for i := body.Skip; i < 5 && i < body.Skip+body.Limit; i++ {
+				page = append(page, map[string]interface{}{"id": fmt.Sprintf("o%d", i), "total": float64(i), "secret": "x"})
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		},
+		"POST /api/functions": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok", "id": "fn_1"})
+		},
+		"POST /api/functions/*": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"records": []interface{}{map[string]interface{}{"count": 5}},
+				"stats":   map[string]interface{}{},
+			})
+		},
+		"DELETE /api/functions/*": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+		},
+		"POST /api/batch/insert/orders_copy": func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Inserts []struct {
+					Data map[string]interface{} `json:"data"`
+				} `json:"inserts"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			ids := []string{}
+			for _, ins := range body.Inserts {
+				inserted = append(inserted, ins.Data)
+				ids = append(ids, ins.Data["id"].(string))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": ids, "failed": []interface{}{}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	var progress []int
+	n, err := client.CopyCollection("orders", "orders_copy", CopyOptions{
+		ChunkSize: 2,
+		Transform: func(r Record) (Record, error) {
+			if r["id"] == "o3" {
+				return nil, nil
+			}
+			delete(r, "secret")
+			return r, nil
+		},
+		Progress: func(done, total int, err error) {
+			if total != 5 || err != nil {
+				t.Errorf("progress total %d, error %v", total, err)
+			}
+			progress = append(progress, done)
+		},
+	})
+	if err != nil {
+		t.Fatalf("CopyCollection failed: %v", err)
+	}
+	if !created {
+		t.Error("destination collection was not created")
+	}
+	if n != 4 || len(inserted) != 4 || inserted[3]["id"] != "o4" {
+		t.Errorf("copied %d: %v", n, inserted)
+	}
+	if _, ok := inserted[0]["secret"]; ok {
+		t.Error("transform was not applied")
+	}
+	if fmt.Sprint(progress) != "[2 5]" {
+		t.Errorf("progress = %v", progress)
+	}
+
+	if _, err := client.CopyCollection("orders", "orders"); err == nil {
+		t.Error("expected an error copying a collection onto itself")
+	}
+}
@@ -0,0 +1,64 @@
+package ekodb
+
+import "fmt"
+
+// Count returns the number of records in collection matching filter, counted
+// by the server so no records are transferred. filter may be nil (count
+// everything), a *QueryBuilder, whose filters are used, or a filter expression
+// map such as a QueryBuilder's Build()["filter"].
+//
+// The count runs as a temporary Query+Count function (see TempScriptLabel),
+// which is deleted afterwards; one left behind by a crash is removed by
+// CleanupOrphans.
+func (c *Client) Count(collection string, filter interface{}, opts ...RequestOption) (int, error) {
+	c = c.withOptions(opts)
+	if err := validateQueryArg(filter); err != nil {
+		return 0, err
+	}
+	result, err := c.runTempFunction("count "+collection,
+		[]FunctionStageConfig{sourceStage(collection, filter), StageCount("count")})
+	if err != nil {
+		return 0, err
+	}
+	if len(result.Records) == 0 {
+		return 0, nil
+	}
+	n, ok := GetIntValue(result.Records[0]["count"])
+	if !ok {
+		return 0, fmt.Errorf("unexpected count result: %v", result.Records[0])
+	}
+	return n, nil
+}
+
+// sourceStage returns the stage that loads the records of collection
+// matching filter, as taken by Count.
+func sourceStage(collection string, filter interface{}) FunctionStageConfig {
+	if filter = filterOf(filter); filter != nil {
+		return StageQuery(collection, filter, nil, nil, nil)
+	}
+	return StageFindAll(collection)
+}
+
+// runTempFunction saves functions as a temporary function (see
+// TempScriptLabel), calls it once, and deletes it.
+func (c *Client) runTempFunction(name string, functions []FunctionStageConfig) (*FunctionResult, error) {
+	label := TempScriptLabel()
+	id, err := c.SaveFunction(UserFunction{
+		Label:      label,
+		Name:       name,
+		Parameters: map[string]ParameterDefinition{},
+		Functions:  functions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary function %q: %w", name, err)
+	}
+	if id == "" {
+		id = label
+	}
+	defer func() {
+		if err := c.DeleteFunction(id); err != nil {
+			c.logf("Failed to delete temporary function %s: %v", label, err)
+		}
+	}()
+	return c.CallFunction(label, nil)
+}
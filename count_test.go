@@ -0,0 +1,59 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	var saved struct {
+		Label     string                   `json:"label"`
+		Functions []map[string]interface{} `json:"functions"`
+	}
+	var called, deleted string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/functions": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&saved)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok", "id": "fn_1"})
+		},
+		"POST /api/functions/*": func(w http.ResponseWriter, r *http.Request) {
+			called = strings.TrimPrefix(r.URL.Path, "/api/functions/")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"records": []interface{}{map[string]interface{}{"count": 42}},
+				"stats":   map[string]interface{}{},
+			})
+		},
+		"DELETE /api/functions/*": func(w http.ResponseWriter, r *http.Request) {
+			deleted = strings.TrimPrefix(r.URL.Path, "/api/functions/")
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	n, err := client.Count("users", NewQueryBuilder().Eq("status", "active"))
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if n != 42 {
+		t.Errorf("Count = %d, want 42", n)
+	}
+	if !strings.HasPrefix(saved.Label, TempScriptPrefix) || called != saved.Label || deleted != "fn_1" {
+		t.Errorf("label %q, called %q, deleted %q", saved.Label, called, deleted)
+	}
+	if len(saved.Functions) != 2 || saved.Functions[0]["type"] != "Query" || saved.Functions[1]["type"] != "Count" {
+		t.Errorf("unexpected pipeline %+v", saved.Functions)
+	}
+	if saved.Functions[0]["filter"] == nil {
+		t.Error("filter not sent")
+	}
+
+	if _, err := client.Count("users", nil); err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if saved.Functions[0]["type"] != "FindAll" {
+		t.Errorf("unfiltered count should use FindAll, got %v", saved.Functions[0]["type"])
+	}
+}
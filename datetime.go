@@ -0,0 +1,83 @@
+package ekodb
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// DateTimeOptions controls how DateTime fields are formatted by FieldDateTime
+// and parsed by GetDateTimeValue, Record.Time, and UnmarshalRecord. Set them
+// once at startup with SetDateTimeOptions.
+type DateTimeOptions struct {
+	// Layouts are extra time.Parse layouts tried, in order, after RFC 3339,
+	// e.g. time.DateTime or "2006-01-02". Layouts without a zone are read in
+	// Location.
+	Layouts []string
+
+	// Location is the zone for layouts that carry none. Nil means UTC.
+	Location *time.Location
+
+	// UTC converts formatted and parsed times to UTC, so values compare and
+	// sort the same whatever zone they were written in.
+	UTC bool
+
+	// PreserveSubseconds formats times with nanosecond precision
+	// (time.RFC3339Nano) instead of whole seconds.
+	PreserveSubseconds bool
+}
+
+var dateTimeOptions atomic.Pointer[DateTimeOptions]
+
+// SetDateTimeOptions replaces the package's DateTime options. The zero value
+// restores the defaults: RFC 3339 only, whole seconds, and the value's own
+// zone.
+func SetDateTimeOptions(opts DateTimeOptions) {
+	opts.Layouts = append([]string(nil), opts.Layouts...)
+	dateTimeOptions.Store(&opts)
+}
+
+func currentDateTimeOptions() DateTimeOptions {
+	if opts := dateTimeOptions.Load(); opts != nil {
+		return *opts
+	}
+	return DateTimeOptions{}
+}
+
+// FormatDateTime formats t the way FieldDateTime sends it.
+func FormatDateTime(t time.Time) string {
+	opts := currentDateTimeOptions()
+	if opts.UTC {
+		t = t.UTC()
+	}
+	if opts.PreserveSubseconds {
+		return t.Format(time.RFC3339Nano)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// ParseDateTime parses a DateTime string as RFC 3339 (with or without
+// fractional seconds) or any of the configured DateTimeOptions.Layouts.
+func ParseDateTime(s string) (time.Time, error) {
+	opts := currentDateTimeOptions()
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		loc := opts.Location
+		if loc == nil {
+			loc = time.UTC
+		}
+		for _, layout := range opts.Layouts {
+			if parsed, layoutErr := time.ParseInLocation(layout, s, loc); layoutErr == nil {
+				t, err = parsed, nil
+				break
+			}
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid DateTime %q: %w", s, err)
+	}
+	if opts.UTC {
+		t = t.UTC()
+	}
+	return t, nil
+}
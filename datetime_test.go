@@ -0,0 +1,62 @@
+package ekodb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateTimeDefaults(t *testing.T) {
+	paris := time.FixedZone("CEST", 2*60*60)
+	at := time.Date(2024, 5, 1, 14, 0, 0, 123456789, paris)
+
+	if got := FieldDateTime(at)["value"]; got != "2024-05-01T14:00:00+02:00" {
+		t.Errorf("FieldDateTime = %v", got)
+	}
+	parsed := GetDateTimeValue(FieldDateTimeString("2024-05-01T14:00:00.5+02:00"))
+	if parsed == nil || parsed.Nanosecond() != 500000000 {
+		t.Errorf("fractional RFC3339 = %v", parsed)
+	}
+	if GetDateTimeValue("2024-05-01 12:00:00") != nil {
+		t.Error("parsed a non-RFC3339 layout by default")
+	}
+}
+
+func TestDateTimeOptions(t *testing.T) {
+	t.Cleanup(func() { SetDateTimeOptions(DateTimeOptions{}) })
+	SetDateTimeOptions(DateTimeOptions{
+		Layouts:            []string{time.DateTime, time.DateOnly},
+		Location:           time.FixedZone("EST", -5*60*60),
+		UTC:                true,
+		PreserveSubseconds: true,
+	})
+
+	paris := time.FixedZone("CEST", 2*60*60)
+	at := time.Date(2024, 5, 1, 14, 0, 0, 123456789, paris)
+	if got := FieldDateTime(at)["value"]; got != "2024-05-01T12:00:00.123456789Z" {
+		t.Errorf("FieldDateTime = %v", got)
+	}
+
+	got, err := ParseDateTime("2024-05-01 07:00:00")
+	if err != nil || !got.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) || got.Location() != time.UTC {
+		t.Errorf("ParseDateTime(layout) = %v, %v", got, err)
+	}
+	if got := (Record{"day": "2024-05-01"}).Time("day"); got.IsZero() {
+		t.Error("Record.Time did not use the extra layouts")
+	}
+	if got := GetDateTimeValue(at); got == nil || got.Location() != time.UTC {
+		t.Errorf("GetDateTimeValue(time.Time) = %v", got)
+	}
+	if _, err := ParseDateTime("yesterday"); err == nil {
+		t.Error("ParseDateTime accepted garbage")
+	}
+
+	var dst struct {
+		At time.Time `ekodb:"at"`
+	}
+	if err := UnmarshalRecord(Record{"at": FieldDateTimeString("2024-05-01T14:00:00+02:00")}, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.At.Location() != time.UTC || dst.At.Hour() != 12 {
+		t.Errorf("UnmarshalRecord = %v", dst.At)
+	}
+}
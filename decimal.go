@@ -0,0 +1,320 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+var (
+	_ msgpack.CustomEncoder = Decimal{}
+	_ msgpack.CustomDecoder = (*Decimal)(nil)
+)
+
+// Decimal is an exact decimal number for ekoDB Decimal fields, such as money,
+// where GetDecimalValue's float64 would lose precision. It stores an
+// arbitrary-precision integer and a scale (the number of digits after the
+// decimal point), so "12.30" keeps its trailing zero and 0.1 + 0.2 is
+// exactly 0.3. The zero value is 0.
+//
+// Add, Sub, and Mul are exact; Quo and Round round half away from zero to a
+// chosen scale. Decimal encodes as a JSON or MessagePack string. Use
+// FieldDecimalOf to build a field from one, GetDecimal to read one, and a
+// Decimal struct field with MarshalRecord and UnmarshalRecord.
+type Decimal struct {
+	unscaled *big.Int // nil means zero
+	scale    int      // value = unscaled / 10^scale; never negative
+}
+
+// maxDecimalScale bounds the scale, and the power of ten applied for a
+// negative one, that ParseDecimal accepts, so input such as "1e999999999"
+// cannot make it allocate a huge integer.
+const maxDecimalScale = 10000
+
+// ParseDecimal parses a decimal string such as "-12.30", "42", or "1.5e3".
+// Values whose scale would pass 10000 digits either way, such as "1e20000"
+// or "1e-20000", are rejected.
+func ParseDecimal(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	exp := 0
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.Atoi(str[i+1:])
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		if e > maxDecimalScale || e < -maxDecimalScale {
+			return Decimal{}, fmt.Errorf("decimal %q: exponent out of range", s)
+		}
+		exp, str = e, str[:i]
+	}
+	neg := false
+	if str != "" && (str[0] == '-' || str[0] == '+') {
+		neg, str = str[0] == '-', str[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	unscaled, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		unscaled.Neg(unscaled)
+	}
+	scale := len(fracPart) - exp
+	if scale > maxDecimalScale || scale < -maxDecimalScale {
+		return Decimal{}, fmt.Errorf("decimal %q: exponent out of range", s)
+	}
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+// MustParseDecimal is ParseDecimal for constants; it panics on invalid input.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromInt returns n as a Decimal with scale 0.
+func DecimalFromInt(n int64) Decimal {
+	return Decimal{unscaled: big.NewInt(n)}
+}
+
+// DecimalFromFloat returns the shortest decimal that round-trips to f, so
+// 0.1 becomes exactly 0.1 rather than its binary approximation. NaN and
+// infinities are rejected.
+func DecimalFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("cannot represent %v as a decimal", f)
+	}
+	return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// rescale returns d's unscaled value at a larger scale.
+func (d Decimal) rescale(scale int) *big.Int {
+	if scale == d.scale {
+		return new(big.Int).Set(d.int())
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int { return d.scale }
+
+// Sign returns -1, 0, or +1 according to d's sign.
+func (d Decimal) Sign() int { return d.int().Sign() }
+
+// IsZero reports whether d is zero, at any scale.
+func (d Decimal) IsZero() bool { return d.Sign() == 0 }
+
+// Cmp compares d and o numerically, returning -1, 0, or +1; "1.50" and "1.5"
+// compare equal.
+func (d Decimal) Cmp(o Decimal) int {
+	scale := max(d.scale, o.scale)
+	return d.rescale(scale).Cmp(o.rescale(scale))
+}
+
+// Equal reports whether d and o are numerically equal.
+func (d Decimal) Equal(o Decimal) bool { return d.Cmp(o) == 0 }
+
+// Add returns d + o at the larger of the two scales.
+func (d Decimal) Add(o Decimal) Decimal {
+	scale := max(d.scale, o.scale)
+	return Decimal{unscaled: new(big.Int).Add(d.rescale(scale), o.rescale(scale)), scale: scale}
+}
+
+// Sub returns d - o at the larger of the two scales.
+func (d Decimal) Sub(o Decimal) Decimal {
+	scale := max(d.scale, o.scale)
+	return Decimal{unscaled: new(big.Int).Sub(d.rescale(scale), o.rescale(scale)), scale: scale}
+}
+
+// Mul returns d * o exactly; the result's scale is the sum of the scales.
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.int(), o.int()), scale: d.scale + o.scale}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	return Decimal{unscaled: new(big.Int).Abs(d.int()), scale: d.scale}
+}
+
+// Quo returns d / o rounded half away from zero to scale digits after the
+// decimal point. Dividing by zero is an error.
+func (d Decimal) Quo(o Decimal, scale int) (Decimal, error) {
+	if o.IsZero() {
+		return Decimal{}, fmt.Errorf("decimal division by zero")
+	}
+	if scale < 0 {
+		return Decimal{}, fmt.Errorf("negative decimal scale %d", scale)
+	}
+	// d/o at scale s = d.unscaled * 10^(s+o.scale) / (o.unscaled * 10^d.scale)
+	num := new(big.Int).Mul(d.int(), pow10(scale+o.scale))
+	den := new(big.Int).Mul(o.int(), pow10(d.scale))
+	return Decimal{unscaled: quoRoundHalfAway(num, den), scale: scale}, nil
+}
+
+// Round returns d rounded half away from zero to scale digits after the
+// decimal point, or padded with zeros if d has fewer.
+func (d Decimal) Round(scale int) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	if scale >= d.scale {
+		return Decimal{unscaled: d.rescale(scale), scale: scale}
+	}
+	return Decimal{unscaled: quoRoundHalfAway(d.int(), pow10(d.scale-scale)), scale: scale}
+}
+
+func quoRoundHalfAway(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	r.Abs(r).Lsh(r, 1)
+	if r.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign() == den.Sign() {
+			q.Add(q, big.NewInt(1))
+		} else {
+			q.Sub(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// String formats d in plain notation with exactly Scale digits after the
+// decimal point, e.g. "-12.30".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Rat returns d as an exact rational.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.int(), pow10(d.scale))
+}
+
+// BigFloat returns d as a big.Float of the given precision in bits (0 means
+// 128), for math the Decimal methods do not cover.
+func (d Decimal) BigFloat(prec uint) *big.Float {
+	if prec == 0 {
+		prec = 128
+	}
+	return new(big.Float).SetPrec(prec).SetRat(d.Rat())
+}
+
+// Float64 returns the float64 nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// MarshalJSON encodes d as a JSON string, the form ekoDB uses for Decimal
+// values.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts a JSON string or number.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	parsed, err := decimalFrom(v)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+func (d Decimal) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeString(d.String())
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder. Strings and numbers are
+// accepted.
+func (d *Decimal) DecodeMsgpack(dec *msgpack.Decoder) error {
+	v, err := dec.DecodeInterface()
+	if err != nil {
+		return err
+	}
+	parsed, err := decimalFrom(v)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// decimalFrom converts a decoded Decimal value (a string, json.Number, or
+// number) into a Decimal.
+func decimalFrom(src interface{}) (Decimal, error) {
+	switch v := src.(type) {
+	case Decimal:
+		return v, nil
+	case string:
+		return ParseDecimal(v)
+	case json.Number:
+		return ParseDecimal(v.String())
+	case float64:
+		return DecimalFromFloat(v)
+	case float32:
+		return ParseDecimal(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	}
+	if n, ok := codecInt(src); ok {
+		return DecimalFromInt(n), nil
+	}
+	return Decimal{}, fmt.Errorf("cannot decode %T into Decimal", src)
+}
+
+// FieldDecimalOf creates a Decimal field value from d.
+func FieldDecimalOf(d Decimal) map[string]interface{} {
+	return FieldDecimal(d.String())
+}
+
+// GetDecimal extracts an exact Decimal from an ekoDB Decimal field, or from a
+// plain string or number. Unlike GetDecimalValue it reports values that
+// cannot be parsed instead of returning 0.
+func GetDecimal(field interface{}) (Decimal, error) {
+	return decimalFrom(GetValue(field))
+}
@@ -0,0 +1,133 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"12.30", "12.30"},
+		{"-0.5", "-0.5"},
+		{"+7", "7"},
+		{".25", "0.25"},
+		{"1.5e3", "1500"},
+		{"125e-4", "0.0125"},
+	}
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if d.String() != tt.want {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", tt.in, d, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "abc", "1.2.3", "-", "1e", "1e999999999", "1e-10001", "1e99999999999999999999"} {
+		if _, err := ParseDecimal(bad); err == nil {
+			t.Errorf("ParseDecimal(%q) should fail", bad)
+		}
+	}
+	if (Decimal{}).String() != "0" {
+		t.Errorf("zero value = %s", Decimal{})
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := MustParseDecimal("0.1"), MustParseDecimal("0.2")
+	if sum := a.Add(b); !sum.Equal(MustParseDecimal("0.3")) || sum.String() != "0.3" {
+		t.Errorf("0.1 + 0.2 = %s", sum)
+	}
+	if diff := a.Sub(MustParseDecimal("1.25")); diff.String() != "-1.15" {
+		t.Errorf("0.1 - 1.25 = %s", diff)
+	}
+	if prod := MustParseDecimal("19.99").Mul(DecimalFromInt(3)); prod.String() != "59.97" {
+		t.Errorf("19.99 * 3 = %s", prod)
+	}
+	q, err := DecimalFromInt(10).Quo(DecimalFromInt(3), 2)
+	if err != nil || q.String() != "3.33" {
+		t.Errorf("10 / 3 = %s, %v", q, err)
+	}
+	q, _ = DecimalFromInt(-2).Quo(DecimalFromInt(3), 2)
+	if q.String() != "-0.67" {
+		t.Errorf("-2 / 3 = %s", q)
+	}
+	if _, err := a.Quo(Decimal{}, 2); err == nil {
+		t.Error("expected division by zero error")
+	}
+	if r := MustParseDecimal("2.345").Round(2); r.String() != "2.35" {
+		t.Errorf("Round(2.345, 2) = %s", r)
+	}
+	if r := MustParseDecimal("2.5").Round(3); r.String() != "2.500" {
+		t.Errorf("Round(2.5, 3) = %s", r)
+	}
+	if MustParseDecimal("1.50").Cmp(MustParseDecimal("1.5")) != 0 || a.Cmp(b) != -1 {
+		t.Error("Cmp mismatch")
+	}
+	if f := MustParseDecimal("99.99").Float64(); f != 99.99 {
+		t.Errorf("Float64 = %v", f)
+	}
+	if d, err := DecimalFromFloat(0.1); err != nil || d.String() != "0.1" {
+		t.Errorf("DecimalFromFloat(0.1) = %s, %v", d, err)
+	}
+}
+
+func TestDecimalEncoding(t *testing.T) {
+	d := MustParseDecimal("1234567890123456789.01")
+	b, err := json.Marshal(d)
+	if err != nil || string(b) != `"1234567890123456789.01"` {
+		t.Fatalf("MarshalJSON = %s, %v", b, err)
+	}
+	var back Decimal
+	if err := json.Unmarshal([]byte(`12.5`), &back); err != nil || back.String() != "12.5" {
+		t.Errorf("UnmarshalJSON(number) = %s, %v", back, err)
+	}
+	packed, err := msgpack.Marshal(d)
+	if err != nil {
+		t.Fatalf("msgpack.Marshal failed: %v", err)
+	}
+	if err := msgpack.Unmarshal(packed, &back); err != nil || !back.Equal(d) {
+		t.Errorf("msgpack round trip = %s, %v", back, err)
+	}
+
+	field := FieldDecimalOf(d)
+	if field["type"] != "Decimal" || field["value"] != d.String() {
+		t.Errorf("FieldDecimalOf = %v", field)
+	}
+	got, err := GetDecimal(field)
+	if err != nil || !got.Equal(d) {
+		t.Errorf("GetDecimal = %s, %v", got, err)
+	}
+	if _, err := GetDecimal(FieldDecimal("n/a")); err == nil {
+		t.Error("expected GetDecimal to reject an invalid decimal")
+	}
+}
+
+func TestStructCodecDecimal(t *testing.T) {
+	type invoice struct {
+		Total Decimal   `ekodb:"total"`
+		Lines []Decimal `ekodb:"lines"`
+	}
+	in := invoice{Total: MustParseDecimal("10.10"), Lines: []Decimal{MustParseDecimal("4.05"), MustParseDecimal("6.05")}}
+	rec, err := MarshalRecord(in)
+	if err != nil {
+		t.Fatalf("MarshalRecord failed: %v", err)
+	}
+	total, _ := rec["total"].(map[string]interface{})
+	if total["type"] != "Decimal" || total["value"] != "10.10" {
+		t.Errorf("total = %v", rec["total"])
+	}
+
+	var out invoice
+	if err := UnmarshalRecord(rec, &out); err != nil {
+		t.Fatalf("UnmarshalRecord failed: %v", err)
+	}
+	if out.Total.String() != "10.10" || len(out.Lines) != 2 || !out.Lines[0].Add(out.Lines[1]).Equal(out.Total) {
+		t.Errorf("round trip = %+v", out)
+	}
+}
@@ -0,0 +1,111 @@
+package ekodb
+
+import "fmt"
+
+// DeleteByFilterOptions contains optional parameters for BatchDeleteByFilter
+type DeleteByFilterOptions struct {
+	// DryRun only counts the matching records.
+	DryRun bool
+	// Confirm is called with the number of matching records before anything
+	// is deleted; returning false cancels the delete. Nil deletes without
+	// asking.
+	Confirm func(matched int) bool
+	// ChunkSize is the number of records deleted per request (default: 500).
+	ChunkSize int
+	// Permanent deletes the records outright instead of moving them to the
+	// trash
+	Permanent bool
+	// Progress is called after each chunk with the records deleted so far
+	// and the number matched.
+	Progress ProgressFunc
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
+}
+
+// DeleteByFilterResult reports what BatchDeleteByFilter matched and deleted.
+type DeleteByFilterResult struct {
+	Matched int
+	Deleted int
+}
+
+// BatchDeleteByFilter deletes the records of collection matching filter,
+// which takes the same forms as for Count. It first counts the matches, so a
+// DryRun or a Confirm callback can check the damage before anything is
+// deleted:
+//
+//	result, err := client.BatchDeleteByFilter("sessions",
+//	    ekodb.NewQueryBuilder().Lt("expires_at", cutoff),
+//	    ekodb.DeleteByFilterOptions{Confirm: func(n int) bool { return n < 10_000 }})
+//
+// Records are then deleted a chunk at a time, never more than were counted,
+// so records that start matching during the delete are left alone. A nil or
+// empty filter is refused; use TruncateCollection to delete everything. The
+// result returned with an error holds the records deleted before it.
+// Cancelling the client's context (see WithContext) stops the delete
+// between chunks.
+func (c *Client) BatchDeleteByFilter(collection string, filter interface{}, opts ...DeleteByFilterOptions) (*DeleteByFilterResult, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	var o DeleteByFilterOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.ChunkSize <= 0 {
+		o.ChunkSize = 500
+	}
+	if err := validateQueryArg(filter); err != nil {
+		return nil, fmt.Errorf("delete by filter %s: %w", collection, err)
+	}
+	expr := filterOf(filter)
+	if isNilValue(expr) {
+		return nil, fmt.Errorf("delete by filter %s: no filter; use TruncateCollection to delete every record", collection)
+	}
+
+	matched, err := c.Count(collection, expr)
+	if err != nil {
+		return nil, fmt.Errorf("delete by filter %s: count matches: %w", collection, err)
+	}
+	result := &DeleteByFilterResult{Matched: matched}
+	if o.DryRun || matched == 0 || (o.Confirm != nil && !o.Confirm(matched)) {
+		return result, nil
+	}
+
+	ctx := c.context()
+	for result.Deleted < matched {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		limit := min(o.ChunkSize, matched-result.Deleted)
+		query := map[string]interface{}{
+			"filter":        expr,
+			"limit":         limit,
+			"select_fields": []string{"id"},
+		}
+		records, err := c.Find(collection, query)
+		if err != nil {
+			return result, fmt.Errorf("delete by filter %s: %w", collection, err)
+		}
+		ids := make([]string, 0, len(records))
+		for _, rec := range records {
+			if id := c.ExtractRecordID(collection, rec); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			break
+		}
+		deleted, err := c.BatchDelete(collection, ids, BatchDeleteOptions{Permanent: o.Permanent})
+		result.Deleted += deleted
+		if o.Progress != nil {
+			o.Progress(result.Deleted, matched, err)
+		}
+		if err != nil {
+			return result, fmt.Errorf("delete by filter %s: %w", collection, err)
+		}
+		if deleted == 0 {
+			return result, fmt.Errorf("delete by filter %s: no records deleted from a chunk of %d", collection, len(ids))
+		}
+	}
+	return result, nil
+}
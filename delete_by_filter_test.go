@@ -0,0 +1,74 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestBatchDeleteByFilter(t *testing.T) {
+	remaining := []string{"a", "b", "c", "d", "e"}
+	var deleteCalls int
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/functions": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok", "id": "fn_1"})
+		},
+		"POST /api/functions/*": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"records": []interface{}{map[string]interface{}{"count": len(remaining)}},
+				"stats":   map[string]interface{}{},
+			})
+		},
+		"DELETE /api/functions/*": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+		},
+		"POST /api/find/sessions": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			limit := int(body["limit"].(float64))
+			var out []map[string]interface{}
+			for _, id := range remaining[:min(limit, len(remaining))] {
+				out = append(out, map[string]interface{}{"id": id})
+			}
+			_ = json.NewEncoder(w).Encode(out)
+		},
+		"DELETE /api/batch/delete/sessions": func(w http.ResponseWriter, r *http.Request) {
+			deleteCalls++
+			var body batchDeleteQuery
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			var ids []string
+			for _, d := range body.Deletes {
+				ids = append(ids, d.ID)
+			}
+			remaining = remaining[len(ids):]
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": ids, "failed": []interface{}{}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+	filter := NewQueryBuilder().Eq("expired", true)
+
+	result, err := client.BatchDeleteByFilter("sessions", filter, DeleteByFilterOptions{DryRun: true})
+	if err != nil || result.Matched != 5 || result.Deleted != 0 || deleteCalls != 0 {
+		t.Fatalf("dry run = %+v, %v (%d deletes)", result, err, deleteCalls)
+	}
+
+	result, err = client.BatchDeleteByFilter("sessions", filter, DeleteByFilterOptions{
+		Confirm: func(n int) bool { return n < 5 },
+	})
+	if err != nil || result.Deleted != 0 || deleteCalls != 0 {
+		t.Fatalf("declined delete = %+v, %v (%d deletes)", result, err, deleteCalls)
+	}
+
+	result, err = client.BatchDeleteByFilter("sessions", filter, DeleteByFilterOptions{ChunkSize: 2})
+	if err != nil {
+		t.Fatalf("BatchDeleteByFilter failed: %v", err)
+	}
+	if result.Matched != 5 || result.Deleted != 5 || deleteCalls != 3 {
+		t.Errorf("result %+v after %d deletes", result, deleteCalls)
+	}
+
+	if _, err := client.BatchDeleteByFilter("sessions", NewQueryBuilder()); err == nil {
+		t.Error("expected an error for an empty filter")
+	}
+}
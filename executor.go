@@ -0,0 +1,72 @@
+package ekodb
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultMaxConcurrency bounds fan-out helpers when ClientConfig.MaxConcurrency
+// is unset.
+const defaultMaxConcurrency = 4
+
+// concurrency returns the number of requests a fan-out helper may have in
+// flight.
+func (c *Client) concurrency() int {
+	if c.maxConcurrency > 0 {
+		return c.maxConcurrency
+	}
+	return defaultMaxConcurrency
+}
+
+// fanOut calls fn for every index in [0, n), with at most c.concurrency()
+// calls running at once. fn receives a context derived from the client's: it
+// is cancelled as soon as any call fails, and calls not yet started are then
+// skipped. fanOut returns the first error once the running calls have
+// finished, or the client's context error if it was cancelled.
+//
+// fn usually issues its request through c.WithContext(ctx) so that the
+// remaining in-flight requests are abandoned after a failure.
+func (c *Client) fanOut(n int, fn func(ctx context.Context, i int) error) error {
+	parent := c.context()
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, c.concurrency())
+
+dispatch:
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := fn(ctx, i); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return parent.Err()
+}
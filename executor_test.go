@@ -0,0 +1,99 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFanOutBoundsConcurrency(t *testing.T) {
+	client := &Client{maxConcurrency: 3}
+
+	var inFlight, peak, calls int32
+	err := client.fanOut(20, func(ctx context.Context, i int) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("fanOut failed: %v", err)
+	}
+	if calls != 20 {
+		t.Errorf("expected 20 calls, got %d", calls)
+	}
+	if peak > 3 {
+		t.Errorf("expected at most 3 calls in flight, saw %d", peak)
+	}
+}
+
+func TestFanOutStopsOnFirstError(t *testing.T) {
+	client := &Client{maxConcurrency: 1}
+	boom := errors.New("boom")
+
+	var calls int32
+	err := client.fanOut(10, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 2 {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("expected boom, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected calls after the failure to be skipped, got %d calls", calls)
+	}
+}
+
+func TestFanOutHonorsClientContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := (&Client{}).WithContext(ctx)
+
+	var calls int32
+	err := client.fanOut(5, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no calls on a cancelled context, got %d", calls)
+	}
+}
+
+func TestSearchCollections(t *testing.T) {
+	searchHandler := func(total int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": []interface{}{}, "total": total})
+		}
+	}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/search/articles": searchHandler(3),
+		"POST /api/search/comments": searchHandler(7),
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	results, err := client.SearchCollections([]string{"articles", "comments"}, SearchQuery{Query: "go"})
+	if err != nil {
+		t.Fatalf("SearchCollections failed: %v", err)
+	}
+	if len(results) != 2 || results["articles"].Total != 3 || results["comments"].Total != 7 {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
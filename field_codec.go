@@ -0,0 +1,95 @@
+package ekodb
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// fieldCodec converts values of a registered Go type; see RegisterFieldCodec.
+type fieldCodec struct {
+	encode func(reflect.Value) (interface{}, error)
+	decode func(interface{}) (reflect.Value, error)
+}
+
+// fieldCodecs holds the registered codecs.
+var fieldCodecs sync.Map // reflect.Type -> fieldCodec
+
+// RegisterFieldCodec teaches MarshalRecord, UnmarshalRecord, and
+// DecodeRecord how to convert a Go type of your own, such as uuid.UUID, a
+// third-party decimal, or an enum, so struct fields of that type need no
+// manual FieldXxx calls. encode returns the stored value, usually a wrapped
+// field from a FieldXxx builder; decode receives the stored value with any
+// {"type", "value"} wrapper already removed. A registered codec takes
+// precedence over the field's tag type option, and applies inside slices,
+// maps, and nested structs too. Registering a type again replaces its codec.
+// Register codecs during initialization, before records are converted:
+//
+//	ekodb.RegisterFieldCodec(
+//	    func(id uuid.UUID) (interface{}, error) { return ekodb.FieldUUID(id.String()), nil },
+//	    func(v interface{}) (uuid.UUID, error) {
+//	        s, _ := v.(string)
+//	        return uuid.Parse(s)
+//	    },
+//	)
+func RegisterFieldCodec[T any](encode func(T) (interface{}, error), decode func(interface{}) (T, error)) {
+	typ := reflect.TypeFor[T]()
+	fieldCodecs.Store(typ, fieldCodec{
+		encode: func(rv reflect.Value) (interface{}, error) {
+			return encode(rv.Interface().(T))
+		},
+		decode: func(v interface{}) (reflect.Value, error) {
+			out, err := decode(v)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(&out).Elem(), nil
+		},
+	})
+}
+
+// UnregisterFieldCodec removes the codec registered for T, if any.
+func UnregisterFieldCodec[T any]() {
+	fieldCodecs.Delete(reflect.TypeFor[T]())
+}
+
+// lookupFieldCodec returns the codec registered for t.
+func lookupFieldCodec(t reflect.Type) (fieldCodec, bool) {
+	codec, ok := fieldCodecs.Load(t)
+	if !ok {
+		return fieldCodec{}, false
+	}
+	return codec.(fieldCodec), true
+}
+
+// hasFieldCodec reports whether t, or a type it points to, has a codec.
+func hasFieldCodec(t reflect.Type) bool {
+	for {
+		if _, ok := lookupFieldCodec(t); ok {
+			return true
+		}
+		if t.Kind() != reflect.Pointer {
+			return false
+		}
+		t = t.Elem()
+	}
+}
+
+// encodeWithCodec runs codec's encoder, attributing errors to the type.
+func encodeWithCodec(codec fieldCodec, rv reflect.Value) (interface{}, error) {
+	v, err := codec.encode(rv)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s: %w", rv.Type(), err)
+	}
+	return v, nil
+}
+
+// decodeWithCodec runs codec's decoder into dst.
+func decodeWithCodec(codec fieldCodec, dst reflect.Value, src interface{}) error {
+	v, err := codec.decode(src)
+	if err != nil {
+		return fmt.Errorf("decoding %s: %w", dst.Type(), err)
+	}
+	dst.Set(v)
+	return nil
+}
@@ -0,0 +1,98 @@
+package ekodb
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type codecStatus int
+
+const (
+	statusDraft codecStatus = iota
+	statusPublished
+)
+
+var codecStatusNames = []string{"draft", "published"}
+
+// codecID stands in for a third-party UUID type.
+type codecID [2]uint32
+
+func registerTestCodecs(t *testing.T) {
+	RegisterFieldCodec(
+		func(s codecStatus) (interface{}, error) {
+			if int(s) >= len(codecStatusNames) {
+				return nil, fmt.Errorf("unknown status %d", s)
+			}
+			return FieldString(codecStatusNames[s]), nil
+		},
+		func(v interface{}) (codecStatus, error) {
+			for i, name := range codecStatusNames {
+				if v == name {
+					return codecStatus(i), nil
+				}
+			}
+			return 0, fmt.Errorf("unknown status %v", v)
+		},
+	)
+	RegisterFieldCodec(
+		func(id codecID) (interface{}, error) {
+			return FieldUUID(fmt.Sprintf("%08x-%08x", id[0], id[1])), nil
+		},
+		func(v interface{}) (codecID, error) {
+			var id codecID
+			s, _ := v.(string)
+			_, err := fmt.Sscanf(strings.ReplaceAll(s, "-", " "), "%08x %08x", &id[0], &id[1])
+			return id, err
+		},
+	)
+	t.Cleanup(func() {
+		UnregisterFieldCodec[codecStatus]()
+		UnregisterFieldCodec[codecID]()
+	})
+}
+
+func TestRegisterFieldCodec(t *testing.T) {
+	registerTestCodecs(t)
+
+	type post struct {
+		ID      codecID       `ekodb:"id"`
+		Status  codecStatus   `ekodb:"status,integer"` // The codec wins over the tag
+		History []codecStatus `ekodb:"history"`
+		Parent  *codecID      `ekodb:"parent"`
+	}
+	in := post{ID: codecID{1, 0xbeef}, Status: statusPublished, History: []codecStatus{statusDraft, statusPublished}}
+	rec, err := MarshalRecord(in)
+	if err != nil {
+		t.Fatalf("MarshalRecord failed: %v", err)
+	}
+	id, _ := rec["id"].(map[string]interface{})
+	if id["type"] != "UUID" || id["value"] != "00000001-0000beef" {
+		t.Errorf("id = %v", rec["id"])
+	}
+	status, _ := rec["status"].(map[string]interface{})
+	if status["type"] != "String" || status["value"] != "published" {
+		t.Errorf("status = %v", rec["status"])
+	}
+	if history, ok := rec["history"].([]interface{}); !ok || len(history) != 2 {
+		t.Errorf("history = %v", rec["history"])
+	}
+	if rec["parent"] != nil {
+		t.Errorf("parent = %v", rec["parent"])
+	}
+
+	var out post
+	if err := UnmarshalRecord(rec, &out); err != nil {
+		t.Fatalf("UnmarshalRecord failed: %v", err)
+	}
+	if out.ID != in.ID || out.Status != in.Status || len(out.History) != 2 || out.History[1] != statusPublished {
+		t.Errorf("round trip = %+v", out)
+	}
+
+	if _, err := MarshalRecord(post{Status: 7}); err == nil || !strings.Contains(err.Error(), "unknown status 7") {
+		t.Errorf("expected an encode error, got %v", err)
+	}
+	if err := UnmarshalRecord(Record{"status": "archived"}, &out); err == nil {
+		t.Error("expected a decode error")
+	}
+}
@@ -0,0 +1,105 @@
+package ekodb
+
+import (
+	"context"
+	"fmt"
+)
+
+// findByIDsChunk caps the IDs in one FindByIDs request.
+const findByIDsChunk = 1000
+
+// FindByIDs fetches the records with the given IDs using In filters, one
+// request per 1000 IDs instead of one per record. It returns the records
+// keyed by ID and, in input order, the IDs that were not found. Duplicate
+// IDs are fetched once.
+//
+// Example:
+//
+//	found, missing, err := client.FindByIDs("users", ids)
+//	for _, id := range missing {
+//	    log.Printf("user %s not found", id)
+//	}
+func (c *Client) FindByIDs(collection string, ids []string, opts ...FindByIDOptions) (map[string]Record, []string, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	unique := make([]interface{}, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	var findOpts FindOptions
+	if len(opts) > 0 {
+		findOpts = FindOptions{
+			SelectFields:  opts[0].SelectFields,
+			ExcludeFields: opts[0].ExcludeFields,
+			BypassRipple:  opts[0].BypassRipple,
+			TransactionId: opts[0].TransactionId,
+		}
+	}
+
+	found := make(map[string]Record, len(unique))
+	for start := 0; start < len(unique); start += findByIDsChunk {
+		chunk := unique[start:min(start+findByIDsChunk, len(unique))]
+		query := NewQueryBuilder().In("id", chunk).Limit(len(chunk)).Build()
+		records, err := c.Find(collection, query, findOpts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("find %d records by id: %w", len(chunk), err)
+		}
+		for _, rec := range records {
+			if id := c.ExtractRecordID(collection, rec); id != "" {
+				found[id] = rec
+			}
+		}
+	}
+
+	var missing []string
+	for _, id := range unique {
+		if _, ok := found[id.(string)]; !ok {
+			missing = append(missing, id.(string))
+		}
+	}
+	return found, missing, nil
+}
+
+// GetResult is the outcome of one ID in GetMany.
+type GetResult struct {
+	ID     string
+	Record Record // nil if Err is set
+	// Err is the FindByID error for this ID, an *HTTPError with IsNotFound
+	// for a missing record.
+	Err error
+}
+
+// GetMany fetches ids with concurrent FindByID calls, at most parallelism
+// at a time (the client's MaxConcurrency if parallelism <= 0), and returns
+// one result per ID in input order. Unlike FindByIDs, a failed lookup does
+// not stop the others, which suits warming a cache from a list of IDs. The
+// error is only set if the client's context ends first.
+func (c *Client) GetMany(collection string, ids []string, parallelism int, opts ...FindByIDOptions) ([]GetResult, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+		// c carries the request options now; don't apply them again per call.
+		o := opts[0]
+		o.RequestOptions = nil
+		opts = []FindByIDOptions{o}
+	}
+	runner := c
+	if parallelism > 0 {
+		runner = c.With(WithMaxConcurrency(parallelism))
+	}
+	results := make([]GetResult, len(ids))
+	err := runner.fanOut(len(ids), func(ctx context.Context, i int) error {
+		rec, err := c.WithContext(ctx).FindByID(collection, ids[i], opts...)
+		results[i] = GetResult{ID: ids[i], Record: rec, Err: err}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
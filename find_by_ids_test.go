@@ -0,0 +1,102 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindByIDs(t *testing.T) {
+	var requests int
+	var sentIDs []interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			var body struct {
+				Filter struct {
+					Content struct {
+						Field    string        `json:"field"`
+						Operator string        `json:"operator"`
+						Value    []interface{} `json:"value"`
+					} `json:"content"`
+				} `json:"filter"`
+				SelectFields []string `json:"select_fields"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.Filter.Content.Field != "id" || body.Filter.Content.Operator != "In" {
+				t.Errorf("unexpected filter %+v", body.Filter)
+			}
+			if !reflect.DeepEqual(body.SelectFields, []string{"name"}) {
+				t.Errorf("select_fields = %v", body.SelectFields)
+			}
+			sentIDs = body.Filter.Content.Value
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": "u1", "name": "Alice"},
+				{"id": "u3", "name": "Carol"},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	found, missing, err := client.FindByIDs("users", []string{"u1", "u2", "u3", "u1", "u4"},
+		FindByIDOptions{SelectFields: []string{"name"}})
+	if err != nil {
+		t.Fatalf("FindByIDs failed: %v", err)
+	}
+	if requests != 1 || len(sentIDs) != 4 {
+		t.Errorf("%d requests for ids %v", requests, sentIDs)
+	}
+	if len(found) != 2 || found["u3"]["name"] != "Carol" {
+		t.Errorf("found = %v", found)
+	}
+	if !reflect.DeepEqual(missing, []string{"u2", "u4"}) {
+		t.Errorf("missing = %v", missing)
+	}
+
+	found, missing, err = client.FindByIDs("users", nil)
+	if err != nil || len(found) != 0 || missing != nil || requests != 1 {
+		t.Errorf("empty FindByIDs made a request or failed: %v %v %v", found, missing, err)
+	}
+}
+
+func TestGetMany(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/find/users/*": func(w http.ResponseWriter, r *http.Request) {
+			id := strings.TrimPrefix(r.URL.Path, "/api/find/users/")
+			if id == "missing" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":"not found"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": id})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	ids := []string{"u3", "missing", "u1", "u2", "u1"}
+	results, err := client.GetMany("users", ids, 2)
+	if err != nil {
+		t.Fatalf("GetMany failed: %v", err)
+	}
+	if len(results) != len(ids) {
+		t.Fatalf("got %d results, want %d", len(results), len(ids))
+	}
+	for i, r := range results {
+		if r.ID != ids[i] {
+			t.Errorf("result %d has ID %q, want %q", i, r.ID, ids[i])
+		}
+		if ids[i] == "missing" {
+			var httpErr *HTTPError
+			if !errors.As(r.Err, &httpErr) || !httpErr.IsNotFound() {
+				t.Errorf("expected a not-found error, got %v", r.Err)
+			}
+		} else if r.Err != nil || r.Record["id"] != ids[i] {
+			t.Errorf("result %d = %+v", i, r)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package ekodb
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+)
+
+// defaultCursorPageSize is how many records a Cursor fetches per request.
+const defaultCursorPageSize = 500
+
+// Cursor iterates over the results of a find query a page at a time, so
+// arbitrarily large result sets can be processed in constant memory:
+//
+//	cur := client.FindCursor("events", ekodb.NewQueryBuilder().SortAscending("id").Build())
+//	for cur.Next(ctx) {
+//	    process(cur.Record())
+//	}
+//	if err := cur.Err(); err != nil {
+//	    ...
+//	}
+//
+// Pages are fetched with limit/skip, so the query should sort on a unique
+// field (such as id) for records to be visited exactly once while the
+// collection changes. The query's own limit and skip, if any, bound the
+// iteration. A Cursor is not safe for concurrent use.
+type Cursor struct {
+	client     *Client
+	collection string
+	query      interface{}
+	opts       FindOptions
+	pageSize   int
+
+	skip      int
+	remaining int // Records left under the query's limit; -1 for no limit
+	page      []Record
+	pos       int
+	record    Record
+	exhausted bool
+	err       error
+}
+
+// FindCursor returns a Cursor over the records matching query, which takes
+// the same forms as for Find. No request is made until the first Next.
+func (c *Client) FindCursor(collection string, query interface{}, opts ...FindOptions) *Cursor {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	cursor := &Cursor{
+		client:     c,
+		collection: collection,
+		pageSize:   defaultCursorPageSize,
+		remaining:  -1,
+	}
+	if len(opts) > 0 {
+		cursor.opts = opts[0]
+	}
+
+	body, err := c.queryToBodyMap("/api/find/"+url.PathEscape(collection), query)
+	if err != nil {
+		cursor.err = fmt.Errorf("invalid find query: %w", err)
+		return cursor
+	}
+	if limit, ok := cursorInt(body["limit"], cursor.opts.Limit); ok {
+		cursor.remaining = limit
+	}
+	if skip, ok := cursorInt(body["skip"], cursor.opts.Skip); ok {
+		cursor.skip = skip
+	}
+	cursor.query = body
+	return cursor
+}
+
+// cursorInt returns an explicit FindOptions value, or else the query's.
+func cursorInt(fromQuery interface{}, fromOpts *int) (int, bool) {
+	if fromOpts != nil {
+		return *fromOpts, true
+	}
+	if fromQuery == nil {
+		return 0, false
+	}
+	n, ok := codecInt(fromQuery)
+	return int(n), ok
+}
+
+// PageSize sets how many records each request fetches (default 500). Call it
+// before the first Next.
+func (cur *Cursor) PageSize(n int) *Cursor {
+	if n > 0 {
+		cur.pageSize = n
+	}
+	return cur
+}
+
+// Next advances to the next record, fetching the next page when the current
+// one is used up. It returns false when the results are exhausted, ctx is
+// done, or a request fails; check Err to tell these apart.
+func (cur *Cursor) Next(ctx context.Context) bool {
+	if cur.err != nil {
+		return false
+	}
+	if cur.pos >= len(cur.page) {
+		if cur.exhausted {
+			cur.record = nil
+			return false
+		}
+		if err := cur.fetch(ctx); err != nil {
+			cur.err = err
+			cur.record = nil
+			return false
+		}
+		if len(cur.page) == 0 {
+			cur.record = nil
+			return false
+		}
+	}
+	cur.record = cur.page[cur.pos]
+	cur.pos++
+	return true
+}
+
+// fetch loads the next page.
+func (cur *Cursor) fetch(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	n := cur.pageSize
+	if cur.remaining >= 0 && cur.remaining < n {
+		n = cur.remaining
+	}
+	if n == 0 {
+		cur.page, cur.pos, cur.exhausted = nil, 0, true
+		return nil
+	}
+
+	opts := cur.opts
+	skip := cur.skip
+	opts.Limit, opts.Skip = &n, &skip
+	page, err := cur.client.WithContext(ctx).Find(cur.collection, cur.query, opts)
+	if err != nil {
+		return err
+	}
+	cur.page, cur.pos = page, 0
+	cur.skip += len(page)
+	if cur.remaining >= 0 {
+		cur.remaining -= len(page)
+	}
+	if len(page) < n {
+		cur.exhausted = true
+	}
+	return nil
+}
+
+// Record returns the record Next advanced to.
+func (cur *Cursor) Record() Record {
+	return cur.record
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (cur *Cursor) Err() error {
+	return cur.err
+}
+
+// FindIter returns an iterator over the records matching query, fetched
+// batchSize at a time (the Cursor default if batchSize is not positive), so
+// exports and ETL jobs run in flat memory:
+//
+//	for rec, err := range client.FindIter(ctx, "events", query, 1000) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    write(rec)
+//	}
+//
+// A failed request or a done ctx ends the iteration with one final non-nil
+// error. Paging follows the same rules as FindCursor, so the query should
+// sort on a unique field.
+func (c *Client) FindIter(ctx context.Context, collection string, query interface{}, batchSize int, opts ...FindOptions) iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		cur := c.FindCursor(collection, query, opts...).PageSize(batchSize)
+		for cur.Next(ctx) {
+			if !yield(cur.Record(), nil) {
+				return
+			}
+		}
+		if err := cur.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFindCursor(t *testing.T) {
+	requests := 0
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/events": func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			var body struct {
+				Limit  int                    `json:"limit"`
+				Skip   int                    `json:"skip"`
+				Filter map[string]interface{} `json:"filter"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.Filter == nil {
+				t.Error("filter was dropped")
+			}
+			page := []Record{}
+			for i := body.Skip; i < 7 && i < body.Skip+body.Limit; i++ {
+				page = append(page, Record{"id": float64(i)})
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+	ctx := context.Background()
+	query := NewQueryBuilder().Eq("kind", "click").SortAscending("id")
+
+	var ids []int
+	cur := client.FindCursor("events", query.Build()).PageSize(3)
+	for cur.Next(ctx) {
+		n, _ := GetIntValue(cur.Record()["id"])
+		ids = append(ids, n)
+	}
+	if err := cur.Err(); err != nil {
+		t.Fatalf("cursor failed: %v", err)
+	}
+	if len(ids) != 7 || ids[0] != 0 || ids[6] != 6 || requests != 3 {
+		t.Errorf("ids = %v after %d requests", ids, requests)
+	}
+
+	// The query's own skip and limit bound the iteration.
+	requests, ids = 0, nil
+	cur = client.FindCursor("events", query.Skip(1).Limit(5).Build()).PageSize(3)
+	for cur.Next(ctx) {
+		n, _ := GetIntValue(cur.Record()["id"])
+		ids = append(ids, n)
+	}
+	if len(ids) != 5 || ids[0] != 1 || ids[4] != 5 || requests != 2 {
+		t.Errorf("bounded ids = %v after %d requests", ids, requests)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	cur = client.FindCursor("events", query.Build())
+	if cur.Next(canceled) || !errors.Is(cur.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", cur.Err())
+	}
+}
+
+func TestFindIter(t *testing.T) {
+	requests := 0
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/events": func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			var body struct {
+				Limit int `json:"limit"`
+				Skip  int `json:"skip"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.Skip >= 4 {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte("boom"))
+				return
+			}
+			page := []Record{}
+			for i := body.Skip; i < body.Skip+body.Limit; i++ {
+				page = append(page, Record{"id": float64(i)})
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		},
+	})
+	defer server.Close()
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL: server.URL, APIKey: "test-api-key", Timeout: 5 * time.Second, Format: JSON,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := NewQueryBuilder().SortAscending("id").Build()
+
+	// Stopping early makes no further requests.
+	seen := 0
+	for rec, err := range client.FindIter(context.Background(), "events", query, 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen++; rec["id"] == float64(2) {
+			break
+		}
+	}
+	if seen != 3 || requests != 2 {
+		t.Errorf("saw %d records in %d requests", seen, requests)
+	}
+
+	// A failed page ends the iteration with the error.
+	var ids []interface{}
+	var last error
+	for rec, err := range client.FindIter(context.Background(), "events", query, 2) {
+		if err != nil {
+			last = err
+			continue
+		}
+		ids = append(ids, rec["id"])
+	}
+	var httpErr *HTTPError
+	if len(ids) != 4 || !errors.As(last, &httpErr) {
+		t.Errorf("ids = %v, err = %v", ids, last)
+	}
+}
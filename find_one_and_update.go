@@ -0,0 +1,117 @@
+package ekodb
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ReturnDocument selects which version of the record FindOneAndUpdate
+// returns.
+type ReturnDocument int
+
+const (
+	// ReturnBefore returns the record as it was before the update
+	ReturnBefore ReturnDocument = iota
+	// ReturnAfter returns the record as the server stored it after the update
+	ReturnAfter
+)
+
+// findOneAndUpdateAttempts bounds how often FindOneAndUpdate retries after a
+// commit conflict.
+const findOneAndUpdateAttempts = 3
+
+// FindOneAndUpdate atomically finds the first record matching filter and
+// applies update to it, returning the record before or after the update. It
+// returns nil, nil if nothing matches.
+//
+// filter may be a filter expression or a *QueryBuilder, whose sort is used to
+// pick the record, which makes this suitable for claiming jobs from a queue:
+//
+//	job, err := client.FindOneAndUpdate("jobs",
+//	    ekodb.NewQueryBuilder().Eq("state", "pending").SortAscending("created_at"),
+//	    ekodb.NewUpdateBuilder().Set("state", "running").Inc("attempts", 1),
+//	    ekodb.ReturnAfter)
+//
+// The read and the write run in a SERIALIZABLE transaction, so two callers
+// never both claim the same record; the loser's commit conflicts and it
+// retries against the next match. Update actions (Inc, Push, ...) are
+// evaluated against the record read in the transaction.
+func (c *Client) FindOneAndUpdate(collection string, filter interface{}, update *UpdateBuilder, ret ReturnDocument, opts ...RequestOption) (Record, error) {
+	c = c.withOptions(opts)
+	if update == nil || len(update.fields)+len(update.actions) == 0 {
+		return nil, fmt.Errorf("find one and update %s: empty update", collection)
+	}
+	var query map[string]interface{}
+	if qb, ok := filter.(*QueryBuilder); ok {
+		built, err := qb.BuildValidated()
+		if err != nil {
+			return nil, fmt.Errorf("find one and update %s: %w", collection, err)
+		}
+		query = built
+	} else {
+		query = map[string]interface{}{}
+		if f := filterOf(filter); f != nil {
+			query["filter"] = f
+		}
+	}
+	query["limit"] = 1
+
+	var err error
+	for attempt := 0; attempt < findOneAndUpdateAttempts; attempt++ {
+		var rec Record
+		rec, err = c.findOneAndUpdateOnce(collection, query, update, ret)
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) || !httpErr.IsConflict() {
+			return rec, err
+		}
+	}
+	return nil, err
+}
+
+func (c *Client) findOneAndUpdateOnce(collection string, query map[string]interface{}, update *UpdateBuilder, ret ReturnDocument) (Record, error) {
+	txID, err := c.BeginTransaction("SERIALIZABLE")
+	if err != nil {
+		return nil, err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = c.RollbackTransaction(txID)
+		}
+	}()
+
+	found, err := c.Find(collection, query, FindOptions{TransactionId: &txID})
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	before := found[0]
+	id := c.ExtractRecordID(collection, before)
+	if id == "" {
+		return nil, fmt.Errorf("find one and update %s: matched record has no id", collection)
+	}
+
+	changes, err := update.apply(before)
+	if err != nil {
+		return nil, fmt.Errorf("find one and update %s/%s: %w", collection, id, err)
+	}
+	// Update returns the stored record, finished like any other read, so
+	// server-computed fields and type wrapping match what Find returns.
+	after, err := c.Update(collection, id, changes, UpdateOptions{TransactionId: &txID})
+	if err != nil {
+		return nil, err
+	}
+	if ret == ReturnAfter && len(after) == 0 {
+		if after, err = c.FindByID(collection, id, FindByIDOptions{TransactionId: &txID}); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.CommitTransaction(txID); err != nil {
+		return nil, err
+	}
+	committed = true
+
+	if ret == ReturnBefore {
+		return before, nil
+	}
+	return after, nil
+}
@@ -0,0 +1,100 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFindOneAndUpdate(t *testing.T) {
+	commits, rollbacks := 0, 0
+	var findBody map[string]interface{}
+	var updateBody Record
+	var updateTx string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/transactions": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"transaction_id": "tx1"})
+		},
+		"POST /api/transactions/*": func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/rollback") {
+				rollbacks++
+				return
+			}
+			commits++
+			if commits == 1 {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte("transaction conflict"))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "committed"})
+		},
+		"POST /api/find/jobs": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&findBody)
+			_ = json.NewEncoder(w).Encode([]Record{{
+				"id":       "j1",
+				"state":    "pending",
+				"attempts": FieldInteger(2),
+				"log":      []interface{}{"queued"},
+			}})
+		},
+		"PUT /api/update/jobs/j1": func(w http.ResponseWriter, r *http.Request) {
+			updateTx = r.URL.Query().Get("transaction_id")
+			_ = json.NewDecoder(r.Body).Decode(&updateBody)
+			stored := Record{"id": "j1", "updated_at": "2026-10-18T00:00:00Z"}
+			for k, v := range updateBody {
+				stored[k] = v
+			}
+			_ = json.NewEncoder(w).Encode(stored)
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	job, err := client.FindOneAndUpdate("jobs",
+		NewQueryBuilder().Eq("state", "pending").SortAscending("created_at"),
+		NewUpdateBuilder().Set("state", "running").Inc("attempts", 1).Push("log", "claimed"),
+		ReturnAfter)
+	if err != nil {
+		t.Fatalf("FindOneAndUpdate failed: %v", err)
+	}
+	if commits != 2 || rollbacks != 1 {
+		t.Errorf("commits = %d, rollbacks = %d; want a retry after the conflict", commits, rollbacks)
+	}
+	if findBody["limit"] != float64(1) || findBody["sort"] == nil {
+		t.Errorf("find body = %v", findBody)
+	}
+	if updateTx != "tx1" {
+		t.Errorf("update not sent in the transaction: %q", updateTx)
+	}
+	if updateBody["state"] != "running" || updateBody.Int("attempts") != 3 || len(updateBody.Array("log")) != 2 {
+		t.Errorf("update body = %v", updateBody)
+	}
+	if job.String("state") != "running" || job.Int("attempts") != 3 || job["updated_at"] == nil {
+		t.Errorf("returned job = %v", job)
+	}
+
+	before, err := client.FindOneAndUpdate("jobs", nil, NewUpdateBuilder().Set("state", "running"), ReturnBefore)
+	if err != nil || before.String("state") != "pending" {
+		t.Errorf("ReturnBefore = %v, %v", before, err)
+	}
+}
+
+func TestUpdateBuilderApply(t *testing.T) {
+	rec := Record{"n": 2.5, "tags": []interface{}{"a", "b", "a"}}
+	changes, err := NewUpdateBuilder().
+		Mul("n", 2).
+		Dec("missing", 1).
+		Pull("tags", "a").
+		Push("tags", "c").
+		apply(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes["n"] != 5.0 || changes["missing"] != int64(-1) || len(changes["tags"].([]interface{})) != 2 {
+		t.Errorf("changes = %v", changes)
+	}
+	if _, err := NewUpdateBuilder().Inc("tags", 1).apply(rec); err == nil {
+		t.Error("expected an error incrementing an array")
+	}
+}
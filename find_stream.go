@@ -0,0 +1,240 @@
+package ekodb
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Streaming variants of Find and Search for large result sets. Find reads the
+// whole response into memory and then decodes it, so a multi-hundred-MB
+// result briefly needs twice that; these decode straight from the connection
+// through pooled read buffers instead.
+
+// streamReaderPool holds the read buffers used to decode response bodies.
+var streamReaderPool = sync.Pool{
+	New: func() interface{} { return bufio.NewReaderSize(nil, 32*1024) },
+}
+
+func getStreamReader(r io.Reader) *bufio.Reader {
+	br := streamReaderPool.Get().(*bufio.Reader)
+	br.Reset(r)
+	return br
+}
+
+func putStreamReader(br *bufio.Reader) {
+	br.Reset(nil)
+	streamReaderPool.Put(br)
+}
+
+// FindInto runs a Find and decodes the results directly into dest, a pointer
+// to a slice such as *[]User or *[]Record, without buffering the response.
+// Struct fields are matched by their msgpack tag, falling back to the json
+// tag, so a struct tagged for encoding/json works in both formats.
+//
+// Example:
+//
+//	var users []User
+//	err := client.FindInto("users", query, &users)
+func (c *Client) FindInto(collection string, query interface{}, dest interface{}, opts ...FindOptions) error {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	path, body, err := c.findRequest(collection, query, opts)
+	if err != nil {
+		return err
+	}
+	return c.makeStreamingRequest("POST", path, body, func(r io.Reader) error {
+		br := getStreamReader(r)
+		defer putStreamReader(br)
+
+		if shouldUseJSON(path) || c.format == JSON {
+			return c.jsonDecoder(br).Decode(dest)
+		}
+		dec := msgpack.GetDecoder()
+		defer msgpack.PutDecoder(dec)
+		dec.Reset(br)
+		dec.SetCustomStructTag("json")
+		return dec.Decode(dest)
+	})
+}
+
+// FindEach runs a Find and calls fn for each result as it is decoded from the
+// response, so memory use stays flat regardless of result size. The same
+// Record map is reused for every call: copy any values (or the record) that
+// must outlive fn. Returning an error from fn stops the iteration and is
+// returned by FindEach.
+//
+// Example:
+//
+//	err := client.FindEach("events", query, func(rec Record) error {
+//	    total += GetFloatValue(rec["amount"])
+//	    return nil
+//	})
+func (c *Client) FindEach(collection string, query interface{}, fn func(Record) error, opts ...FindOptions) error {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	path, body, err := c.findRequest(collection, query, opts)
+	if err != nil {
+		return err
+	}
+	fn = c.finishingRecordFunc(fn)
+	return c.makeStreamingRequest("POST", path, body, func(r io.Reader) error {
+		br := getStreamReader(r)
+		defer putStreamReader(br)
+
+		if shouldUseJSON(path) || c.format == JSON {
+			return eachJSONRecord(c.jsonDecoder(br), fn)
+		}
+		return eachMsgpackRecord(br, fn)
+	})
+}
+
+func eachJSONRecord(dec *json.Decoder, fn func(Record) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected array of records, got %v", tok)
+	}
+	rec := make(Record)
+	for dec.More() {
+		clear(rec)
+		if err := dec.Decode(&rec); err != nil {
+			return err
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+func eachMsgpackRecord(r io.Reader, fn func(Record) error) error {
+	dec := msgpack.GetDecoder()
+	defer msgpack.PutDecoder(dec)
+	dec.Reset(r)
+
+	n, err := dec.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	rec := make(Record)
+	for i := 0; i < n; i++ {
+		clear(rec)
+		if err := decodeRecordInto(dec, rec); err != nil {
+			return err
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchEach runs a Search and calls fn for each result as it is decoded,
+// reusing one SearchResult (including its Record map) across calls — copy
+// anything that must outlive fn. The returned response carries Total,
+// TookMs, and SnapshotToken; its Results are left empty. Returning an error from fn stops the
+// iteration and is returned by SearchEach.
+func (c *Client) SearchEach(collection string, searchQuery SearchQuery, fn func(SearchResult) error, opts ...RequestOption) (*SearchResponse, error) {
+	c = c.withOptions(opts)
+	if err := c.checkSearchVectorDims(collection, searchQuery); err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("/api/search/%s", url.PathEscape(collection))
+
+	var response SearchResponse
+	err := c.makeStreamingRequest("POST", endpoint, searchQuery, func(r io.Reader) error {
+		br := getStreamReader(r)
+		defer putStreamReader(br)
+		return eachSearchResult(c.jsonDecoder(br), &response, c.finishingSearchFunc(fn))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// eachSearchResult walks a SearchResponse object, streaming its "results"
+// array to fn and decoding the remaining fields into response.
+func eachSearchResult(dec *json.Decoder, response *SearchResponse, fn func(SearchResult) error) error {
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected search response object, got %v", tok)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		switch key {
+		case "results":
+			if err := eachSearchResultItem(dec, fn); err != nil {
+				return err
+			}
+		case "total":
+			if err := dec.Decode(&response.Total); err != nil {
+				return err
+			}
+		case "took_ms":
+			if err := dec.Decode(&response.TookMs); err != nil {
+				return err
+			}
+		case "snapshot_token":
+			if err := dec.Decode(&response.SnapshotToken); err != nil {
+				return err
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+func eachSearchResultItem(dec *json.Decoder, fn func(SearchResult) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected array of search results, got %v", tok)
+	}
+	record := make(map[string]interface{})
+	var result SearchResult
+	for dec.More() {
+		clear(record)
+		result.Record = record
+		result.Score = 0
+		result.MatchedFields = result.MatchedFields[:0]
+		if err := dec.Decode(&result); err != nil {
+			return err
+		}
+		if err := fn(result); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
@@ -0,0 +1,198 @@
+package ekodb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type streamUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func streamTestRecords(n int) []Record {
+	records := make([]Record, n)
+	for i := range records {
+		records[i] = Record{"id": fmt.Sprintf("u%d", i), "name": fmt.Sprintf("user-%d", i), "age": 20 + i}
+	}
+	return records
+}
+
+func createMsgpackTestClient(t *testing.T, handlers map[string]http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+	server := createTestServer(t, handlers)
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL: server.URL,
+		APIKey:  "test-api-key",
+		Format:  MessagePack,
+	})
+	if err != nil {
+		server.Close()
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+	return client, server.Close
+}
+
+func TestFindIntoStructsJSON(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(streamTestRecords(3))
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	var users []streamUser
+	if err := client.FindInto("users", NewQueryBuilder().Build(), &users); err != nil {
+		t.Fatalf("FindInto failed: %v", err)
+	}
+	if len(users) != 3 || users[2].ID != "u2" || users[2].Name != "user-2" || users[2].Age != 22 {
+		t.Errorf("unexpected users: %+v", users)
+	}
+}
+
+func TestFindIntoStructsMsgpack(t *testing.T) {
+	client, closeServer := createMsgpackTestClient(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/msgpack")
+			b, _ := msgpack.Marshal(streamTestRecords(3))
+			_, _ = w.Write(b)
+		},
+	})
+	defer closeServer()
+
+	// json tags are honored as a fallback in MessagePack mode.
+	var users []streamUser
+	if err := client.FindInto("users", NewQueryBuilder().Build(), &users); err != nil {
+		t.Fatalf("FindInto failed: %v", err)
+	}
+	if len(users) != 3 || users[1].ID != "u1" || users[1].Age != 21 {
+		t.Errorf("unexpected users: %+v", users)
+	}
+}
+
+func TestFindEach(t *testing.T) {
+	for _, format := range []SerializationFormat{JSON, MessagePack} {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			if format == JSON {
+				_ = json.NewEncoder(w).Encode(streamTestRecords(5))
+				return
+			}
+			b, _ := msgpack.Marshal(streamTestRecords(5))
+			_, _ = w.Write(b)
+		}
+		server := createTestServer(t, map[string]http.HandlerFunc{"POST /api/find/users": handler})
+		client, err := NewClientWithConfig(ClientConfig{BaseURL: server.URL, APIKey: "test-api-key", Format: format})
+		if err != nil {
+			t.Fatalf("NewClientWithConfig failed: %v", err)
+		}
+
+		var ids []string
+		err = client.FindEach("users", NewQueryBuilder().Build(), func(rec Record) error {
+			if len(rec) != 3 {
+				t.Errorf("format %v: record has stale fields: %v", format, rec)
+			}
+			ids = append(ids, rec["id"].(string))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("format %v: FindEach failed: %v", format, err)
+		}
+		if len(ids) != 5 || ids[0] != "u0" || ids[4] != "u4" {
+			t.Errorf("format %v: ids = %v", format, ids)
+		}
+
+		// An error from fn stops the iteration.
+		stop := errors.New("stop")
+		seen := 0
+		err = client.FindEach("users", NewQueryBuilder().Build(), func(rec Record) error {
+			seen++
+			if seen == 2 {
+				return stop
+			}
+			return nil
+		})
+		if !errors.Is(err, stop) || seen != 2 {
+			t.Errorf("format %v: expected stop after 2 records, got err=%v seen=%d", format, err, seen)
+		}
+		server.Close()
+	}
+}
+
+func TestFindEachHTTPError(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("bad filter"))
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	err := client.FindEach("users", nil, func(Record) error { return nil })
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected HTTPError 400, got %v", err)
+	}
+}
+
+func TestSearchEach(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/search/docs": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"results":[` +
+				`{"record":{"id":"d1","title":"a","extra":1},"score":0.9,"matched_fields":["title"]},` +
+				`{"record":{"id":"d2"},"score":0.5,"matched_fields":[]}` +
+				`],"total":2,"took_ms":7,"snapshot_token":"snap-1","unknown":{"x":1}}`))
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	var ids []string
+	resp, err := client.SearchEach("docs", SearchQuery{Query: "a"}, func(res SearchResult) error {
+		ids = append(ids, res.Record["id"].(string))
+		if res.Record["id"] == "d2" && (len(res.Record) != 1 || len(res.MatchedFields) != 0) {
+			t.Errorf("result carries stale data: %+v", res)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchEach failed: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "d1" || ids[1] != "d2" {
+		t.Errorf("ids = %v", ids)
+	}
+	if resp.Total != 2 || resp.TookMs == nil || *resp.TookMs != 7 || resp.SnapshotToken != "snap-1" {
+		t.Errorf("unexpected response metadata: %+v", resp)
+	}
+}
+
+func BenchmarkFindBuffered(b *testing.B) {
+	data, _ := msgpack.Marshal(streamTestRecords(1000))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var records []Record
+		if err := msgpack.Unmarshal(data, &records); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFindEachStreaming(b *testing.B) {
+	data, _ := msgpack.Marshal(streamTestRecords(1000))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		br := getStreamReader(bytes.NewReader(data))
+		if err := eachMsgpackRecord(br, func(Record) error { return nil }); err != nil {
+			b.Fatal(err)
+		}
+		putStreamReader(br)
+	}
+}
@@ -1248,6 +1248,9 @@ const (
 type SortFieldConfig struct {
 	Field     string `json:"field"`
 	Ascending bool   `json:"ascending"`
+	// Nulls and CaseInsensitive are as in SortOptions
+	Nulls           NullsOrder `json:"nulls,omitempty"`
+	CaseInsensitive bool       `json:"case_insensitive,omitempty"`
 }
 
 // FunctionResult from execution
@@ -1276,7 +1279,8 @@ type StageStats struct {
 // Client methods for functions
 
 // SaveFunction creates a new function
-func (c *Client) SaveFunction(function UserFunction) (string, error) {
+func (c *Client) SaveFunction(function UserFunction, opts ...RequestOption) (string, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", "/api/functions", function)
 	if err != nil {
 		return "", err
@@ -1294,7 +1298,8 @@ func (c *Client) SaveFunction(function UserFunction) (string, error) {
 }
 
 // GetFunction retrieves a function by ID
-func (c *Client) GetFunction(id string) (*UserFunction, error) {
+func (c *Client) GetFunction(id string, opts ...RequestOption) (*UserFunction, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/functions/%s", url.PathEscape(id)), nil)
 	if err != nil {
 		return nil, err
@@ -1309,7 +1314,8 @@ func (c *Client) GetFunction(id string) (*UserFunction, error) {
 }
 
 // ListFunctions lists all functions, optionally filtered by tags
-func (c *Client) ListFunctions(tags []string) ([]UserFunction, error) {
+func (c *Client) ListFunctions(tags []string, opts ...RequestOption) ([]UserFunction, error) {
+	c = c.withOptions(opts)
 	reqURL := "/api/functions"
 	if len(tags) > 0 {
 		// QueryEscape encodes the value (`&`/`=`/`,`), so a tag containing
@@ -1331,19 +1337,22 @@ func (c *Client) ListFunctions(tags []string) ([]UserFunction, error) {
 }
 
 // UpdateFunction updates an existing function by ID
-func (c *Client) UpdateFunction(id string, function UserFunction) error {
+func (c *Client) UpdateFunction(id string, function UserFunction, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("PUT", fmt.Sprintf("/api/functions/%s", url.PathEscape(id)), function)
 	return err
 }
 
 // DeleteFunction deletes a function by ID
-func (c *Client) DeleteFunction(id string) error {
+func (c *Client) DeleteFunction(id string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", fmt.Sprintf("/api/functions/%s", url.PathEscape(id)), nil)
 	return err
 }
 
 // CallFunction executes a function by label or ID
-func (c *Client) CallFunction(labelOrID string, params map[string]interface{}) (*FunctionResult, error) {
+func (c *Client) CallFunction(labelOrID string, params map[string]interface{}, opts ...RequestOption) (*FunctionResult, error) {
+	c = c.withOptions(opts)
 	// Convert nil params to empty map to avoid sending JSON null
 	if params == nil {
 		params = make(map[string]interface{})
@@ -1358,6 +1367,11 @@ func (c *Client) CallFunction(labelOrID string, params map[string]interface{}) (
 	if err := json.Unmarshal(respBody, &result); err != nil {
 		return nil, err
 	}
+	if c.packVectors {
+		for _, rec := range result.Records {
+			unpackVectorsInPlace(rec)
+		}
+	}
 
 	return &result, nil
 }
@@ -1379,7 +1393,8 @@ func joinStrings(strs []string, sep string) string {
 // ============================================================================
 
 // SaveUserFunction creates a new reusable user function
-func (c *Client) SaveUserFunction(userFunction UserFunction) (string, error) {
+func (c *Client) SaveUserFunction(userFunction UserFunction, opts ...RequestOption) (string, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", "/api/functions", userFunction)
 	if err != nil {
 		return "", err
@@ -1397,7 +1412,8 @@ func (c *Client) SaveUserFunction(userFunction UserFunction) (string, error) {
 }
 
 // GetUserFunction retrieves a user function by label
-func (c *Client) GetUserFunction(label string) (*UserFunction, error) {
+func (c *Client) GetUserFunction(label string, opts ...RequestOption) (*UserFunction, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/functions/%s", url.PathEscape(label)), nil)
 	if err != nil {
 		return nil, err
@@ -1412,7 +1428,8 @@ func (c *Client) GetUserFunction(label string) (*UserFunction, error) {
 }
 
 // ListUserFunctions lists all user functions, optionally filtered by tags
-func (c *Client) ListUserFunctions(tags []string) ([]UserFunction, error) {
+func (c *Client) ListUserFunctions(tags []string, opts ...RequestOption) ([]UserFunction, error) {
+	c = c.withOptions(opts)
 	reqURL := "/api/functions"
 	if len(tags) > 0 {
 		// QueryEscape encodes the value (`&`/`=`/`,`), so a tag containing
@@ -1434,13 +1451,15 @@ func (c *Client) ListUserFunctions(tags []string) ([]UserFunction, error) {
 }
 
 // UpdateUserFunction updates an existing user function by label
-func (c *Client) UpdateUserFunction(label string, userFunction UserFunction) error {
+func (c *Client) UpdateUserFunction(label string, userFunction UserFunction, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("PUT", fmt.Sprintf("/api/functions/%s", url.PathEscape(label)), userFunction)
 	return err
 }
 
 // DeleteUserFunction deletes a user function by label
-func (c *Client) DeleteUserFunction(label string) error {
+func (c *Client) DeleteUserFunction(label string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", fmt.Sprintf("/api/functions/%s", url.PathEscape(label)), nil)
 	return err
 }
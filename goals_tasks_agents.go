@@ -10,7 +10,8 @@ import (
 // ── Goal CRUD ──────────────────────────────────────────────────────────────
 
 // GoalCreate creates a new goal.
-func (c *Client) GoalCreate(data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) GoalCreate(data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", "/api/chat/goals", data)
 	if err != nil {
 		return nil, err
@@ -23,7 +24,8 @@ func (c *Client) GoalCreate(data map[string]interface{}) (map[string]interface{}
 }
 
 // GoalList lists all goals.
-func (c *Client) GoalList() (map[string]interface{}, error) {
+func (c *Client) GoalList(opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/chat/goals", nil)
 	if err != nil {
 		return nil, err
@@ -36,7 +38,8 @@ func (c *Client) GoalList() (map[string]interface{}, error) {
 }
 
 // GoalGet retrieves a goal by ID.
-func (c *Client) GoalGet(id string) (map[string]interface{}, error) {
+func (c *Client) GoalGet(id string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/chat/goals/%s", url.PathEscape(id)), nil)
 	if err != nil {
 		return nil, err
@@ -49,7 +52,8 @@ func (c *Client) GoalGet(id string) (map[string]interface{}, error) {
 }
 
 // GoalUpdate updates a goal by ID.
-func (c *Client) GoalUpdate(id string, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) GoalUpdate(id string, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("PUT", fmt.Sprintf("/api/chat/goals/%s", url.PathEscape(id)), data)
 	if err != nil {
 		return nil, err
@@ -62,13 +66,15 @@ func (c *Client) GoalUpdate(id string, data map[string]interface{}) (map[string]
 }
 
 // GoalDelete deletes a goal by ID.
-func (c *Client) GoalDelete(id string) error {
+func (c *Client) GoalDelete(id string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", fmt.Sprintf("/api/chat/goals/%s", url.PathEscape(id)), nil)
 	return err
 }
 
 // GoalSearch searches goals by query string.
-func (c *Client) GoalSearch(query string) (map[string]interface{}, error) {
+func (c *Client) GoalSearch(query string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/chat/goals/search?q=%s", url.QueryEscape(query)), nil)
 	if err != nil {
 		return nil, err
@@ -83,7 +89,8 @@ func (c *Client) GoalSearch(query string) (map[string]interface{}, error) {
 // ── Goal Lifecycle ─────────────────────────────────────────────────────────
 
 // GoalComplete atomically marks a goal as complete (status → pending_review).
-func (c *Client) GoalComplete(id string, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) GoalComplete(id string, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/goals/%s/complete", url.PathEscape(id)), data)
 	if err != nil {
 		return nil, err
@@ -96,7 +103,8 @@ func (c *Client) GoalComplete(id string, data map[string]interface{}) (map[strin
 }
 
 // GoalApprove atomically approves a goal (status → in_progress).
-func (c *Client) GoalApprove(id string) (map[string]interface{}, error) {
+func (c *Client) GoalApprove(id string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/goals/%s/approve", url.PathEscape(id)), nil)
 	if err != nil {
 		return nil, err
@@ -109,7 +117,8 @@ func (c *Client) GoalApprove(id string) (map[string]interface{}, error) {
 }
 
 // GoalReject atomically rejects a goal (status → failed).
-func (c *Client) GoalReject(id string, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) GoalReject(id string, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/goals/%s/reject", url.PathEscape(id)), data)
 	if err != nil {
 		return nil, err
@@ -124,7 +133,8 @@ func (c *Client) GoalReject(id string, data map[string]interface{}) (map[string]
 // ── Goal Step Lifecycle ────────────────────────────────────────────────────
 
 // GoalStepStart atomically marks a goal step as in_progress.
-func (c *Client) GoalStepStart(id string, stepIndex int) (map[string]interface{}, error) {
+func (c *Client) GoalStepStart(id string, stepIndex int, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/goals/%s/steps/%s/start", url.PathEscape(id), url.PathEscape(strconv.Itoa(stepIndex))), nil)
 	if err != nil {
 		return nil, err
@@ -137,7 +147,8 @@ func (c *Client) GoalStepStart(id string, stepIndex int) (map[string]interface{}
 }
 
 // GoalStepComplete atomically marks a goal step as completed.
-func (c *Client) GoalStepComplete(id string, stepIndex int, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) GoalStepComplete(id string, stepIndex int, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/goals/%s/steps/%s/complete", url.PathEscape(id), url.PathEscape(strconv.Itoa(stepIndex))), data)
 	if err != nil {
 		return nil, err
@@ -150,7 +161,8 @@ func (c *Client) GoalStepComplete(id string, stepIndex int, data map[string]inte
 }
 
 // GoalStepFail atomically marks a goal step as failed.
-func (c *Client) GoalStepFail(id string, stepIndex int, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) GoalStepFail(id string, stepIndex int, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/goals/%s/steps/%s/fail", url.PathEscape(id), url.PathEscape(strconv.Itoa(stepIndex))), data)
 	if err != nil {
 		return nil, err
@@ -165,7 +177,8 @@ func (c *Client) GoalStepFail(id string, stepIndex int, data map[string]interfac
 // ── Goal Template CRUD ─────────────────────────────────────────────────────
 
 // GoalTemplateCreate creates a new goal template.
-func (c *Client) GoalTemplateCreate(data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) GoalTemplateCreate(data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", "/api/chat/goal-templates", data)
 	if err != nil {
 		return nil, err
@@ -178,7 +191,8 @@ func (c *Client) GoalTemplateCreate(data map[string]interface{}) (map[string]int
 }
 
 // GoalTemplateList lists all goal templates.
-func (c *Client) GoalTemplateList() (map[string]interface{}, error) {
+func (c *Client) GoalTemplateList(opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/chat/goal-templates", nil)
 	if err != nil {
 		return nil, err
@@ -191,7 +205,8 @@ func (c *Client) GoalTemplateList() (map[string]interface{}, error) {
 }
 
 // GoalTemplateGet retrieves a goal template by ID.
-func (c *Client) GoalTemplateGet(id string) (map[string]interface{}, error) {
+func (c *Client) GoalTemplateGet(id string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/chat/goal-templates/%s", url.PathEscape(id)), nil)
 	if err != nil {
 		return nil, err
@@ -204,7 +219,8 @@ func (c *Client) GoalTemplateGet(id string) (map[string]interface{}, error) {
 }
 
 // GoalTemplateUpdate updates a goal template by ID.
-func (c *Client) GoalTemplateUpdate(id string, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) GoalTemplateUpdate(id string, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("PUT", fmt.Sprintf("/api/chat/goal-templates/%s", url.PathEscape(id)), data)
 	if err != nil {
 		return nil, err
@@ -217,7 +233,8 @@ func (c *Client) GoalTemplateUpdate(id string, data map[string]interface{}) (map
 }
 
 // GoalTemplateDelete deletes a goal template by ID.
-func (c *Client) GoalTemplateDelete(id string) error {
+func (c *Client) GoalTemplateDelete(id string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", fmt.Sprintf("/api/chat/goal-templates/%s", url.PathEscape(id)), nil)
 	return err
 }
@@ -225,7 +242,8 @@ func (c *Client) GoalTemplateDelete(id string) error {
 // ── Task CRUD ──────────────────────────────────────────────────────────────
 
 // TaskCreate creates a new scheduled task.
-func (c *Client) TaskCreate(data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) TaskCreate(data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", "/api/chat/tasks", data)
 	if err != nil {
 		return nil, err
@@ -238,7 +256,8 @@ func (c *Client) TaskCreate(data map[string]interface{}) (map[string]interface{}
 }
 
 // TaskList lists all scheduled tasks.
-func (c *Client) TaskList() (map[string]interface{}, error) {
+func (c *Client) TaskList(opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/chat/tasks", nil)
 	if err != nil {
 		return nil, err
@@ -251,7 +270,8 @@ func (c *Client) TaskList() (map[string]interface{}, error) {
 }
 
 // TaskGet retrieves a task by ID.
-func (c *Client) TaskGet(id string) (map[string]interface{}, error) {
+func (c *Client) TaskGet(id string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/chat/tasks/%s", url.PathEscape(id)), nil)
 	if err != nil {
 		return nil, err
@@ -264,7 +284,8 @@ func (c *Client) TaskGet(id string) (map[string]interface{}, error) {
 }
 
 // TaskUpdate updates a task by ID.
-func (c *Client) TaskUpdate(id string, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) TaskUpdate(id string, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("PUT", fmt.Sprintf("/api/chat/tasks/%s", url.PathEscape(id)), data)
 	if err != nil {
 		return nil, err
@@ -277,13 +298,15 @@ func (c *Client) TaskUpdate(id string, data map[string]interface{}) (map[string]
 }
 
 // TaskDelete deletes a task by ID.
-func (c *Client) TaskDelete(id string) error {
+func (c *Client) TaskDelete(id string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", fmt.Sprintf("/api/chat/tasks/%s", url.PathEscape(id)), nil)
 	return err
 }
 
 // TaskDue retrieves tasks that are due at the given time.
-func (c *Client) TaskDue(now string) (map[string]interface{}, error) {
+func (c *Client) TaskDue(now string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/chat/tasks/due?now=%s", url.QueryEscape(now)), nil)
 	if err != nil {
 		return nil, err
@@ -298,7 +321,8 @@ func (c *Client) TaskDue(now string) (map[string]interface{}, error) {
 // ── Task Lifecycle ─────────────────────────────────────────────────────────
 
 // TaskStart atomically marks a task as running.
-func (c *Client) TaskStart(id string) (map[string]interface{}, error) {
+func (c *Client) TaskStart(id string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/tasks/%s/start", url.PathEscape(id)), nil)
 	if err != nil {
 		return nil, err
@@ -311,7 +335,8 @@ func (c *Client) TaskStart(id string) (map[string]interface{}, error) {
 }
 
 // TaskSucceed atomically marks a task as succeeded.
-func (c *Client) TaskSucceed(id string, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) TaskSucceed(id string, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/tasks/%s/succeed", url.PathEscape(id)), data)
 	if err != nil {
 		return nil, err
@@ -324,7 +349,8 @@ func (c *Client) TaskSucceed(id string, data map[string]interface{}) (map[string
 }
 
 // TaskFail atomically marks a task as failed.
-func (c *Client) TaskFail(id string, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) TaskFail(id string, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/tasks/%s/fail", url.PathEscape(id)), data)
 	if err != nil {
 		return nil, err
@@ -337,7 +363,8 @@ func (c *Client) TaskFail(id string, data map[string]interface{}) (map[string]in
 }
 
 // TaskPause atomically pauses a task.
-func (c *Client) TaskPause(id string) (map[string]interface{}, error) {
+func (c *Client) TaskPause(id string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/tasks/%s/pause", url.PathEscape(id)), nil)
 	if err != nil {
 		return nil, err
@@ -350,7 +377,8 @@ func (c *Client) TaskPause(id string) (map[string]interface{}, error) {
 }
 
 // TaskResume atomically resumes a paused task.
-func (c *Client) TaskResume(id string, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) TaskResume(id string, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/chat/tasks/%s/resume", url.PathEscape(id)), data)
 	if err != nil {
 		return nil, err
@@ -365,7 +393,8 @@ func (c *Client) TaskResume(id string, data map[string]interface{}) (map[string]
 // ── Agent CRUD ─────────────────────────────────────────────────────────────
 
 // AgentCreate creates a new agent.
-func (c *Client) AgentCreate(data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) AgentCreate(data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", "/api/chat/agents", data)
 	if err != nil {
 		return nil, err
@@ -378,7 +407,8 @@ func (c *Client) AgentCreate(data map[string]interface{}) (map[string]interface{
 }
 
 // AgentList lists all agents.
-func (c *Client) AgentList() (map[string]interface{}, error) {
+func (c *Client) AgentList(opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/chat/agents", nil)
 	if err != nil {
 		return nil, err
@@ -391,7 +421,8 @@ func (c *Client) AgentList() (map[string]interface{}, error) {
 }
 
 // AgentGet retrieves an agent by ID.
-func (c *Client) AgentGet(id string) (map[string]interface{}, error) {
+func (c *Client) AgentGet(id string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/chat/agents/%s", url.PathEscape(id)), nil)
 	if err != nil {
 		return nil, err
@@ -404,7 +435,8 @@ func (c *Client) AgentGet(id string) (map[string]interface{}, error) {
 }
 
 // AgentGetByName retrieves an agent by name.
-func (c *Client) AgentGetByName(name string) (map[string]interface{}, error) {
+func (c *Client) AgentGetByName(name string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/chat/agents/by-name/%s", url.PathEscape(name)), nil)
 	if err != nil {
 		return nil, err
@@ -417,7 +449,8 @@ func (c *Client) AgentGetByName(name string) (map[string]interface{}, error) {
 }
 
 // AgentUpdate updates an agent by ID.
-func (c *Client) AgentUpdate(id string, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) AgentUpdate(id string, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("PUT", fmt.Sprintf("/api/chat/agents/%s", url.PathEscape(id)), data)
 	if err != nil {
 		return nil, err
@@ -430,13 +463,15 @@ func (c *Client) AgentUpdate(id string, data map[string]interface{}) (map[string
 }
 
 // AgentDelete deletes an agent by ID.
-func (c *Client) AgentDelete(id string) error {
+func (c *Client) AgentDelete(id string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", fmt.Sprintf("/api/chat/agents/%s", url.PathEscape(id)), nil)
 	return err
 }
 
 // AgentsByDeployment retrieves agents associated with a deployment ID.
-func (c *Client) AgentsByDeployment(deploymentId string) (map[string]interface{}, error) {
+func (c *Client) AgentsByDeployment(deploymentId string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/chat/agents/by-deployment/%s", url.PathEscape(deploymentId)), nil)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,91 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// HookEvent is the collection write that triggers a hook.
+type HookEvent string
+
+const (
+	// HookOnInsert fires after a record is inserted
+	HookOnInsert HookEvent = "insert"
+	// HookOnUpdate fires after a record is updated
+	HookOnUpdate HookEvent = "update"
+	// HookOnDelete fires after a record is deleted
+	HookOnDelete HookEvent = "delete"
+)
+
+// Hook binds a saved Function to a collection event. When the event fires,
+// the server calls the function with the affected record's fields as
+// parameters (plus "collection", "event", and "record_id").
+type Hook struct {
+	ID            string    `json:"id,omitempty"`
+	Collection    string    `json:"collection"`
+	Event         HookEvent `json:"event"`
+	FunctionLabel string    `json:"function_label"`
+	Enabled       bool      `json:"enabled"`
+	CreatedAt     string    `json:"created_at,omitempty"`
+}
+
+// RegisterHook makes the saved Function functionLabel run whenever event
+// happens on collection, turning it into a trigger. It returns the new hook's
+// ID.
+//
+// Example:
+//
+//	id, err := client.RegisterHook("orders", HookOnInsert, "notify_fulfillment")
+func (c *Client) RegisterHook(collection string, event HookEvent, functionLabel string, opts ...RequestOption) (string, error) {
+	c = c.withOptions(opts)
+	switch event {
+	case HookOnInsert, HookOnUpdate, HookOnDelete:
+	default:
+		return "", fmt.Errorf("invalid hook event: %s (must be one of: insert, update, delete)", event)
+	}
+
+	hook := Hook{
+		Collection:    collection,
+		Event:         event,
+		FunctionLabel: functionLabel,
+		Enabled:       true,
+	}
+	respBody, err := c.makeRequest("POST", "/api/hooks/"+url.PathEscape(collection), hook)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", err
+	}
+	return result.ID, nil
+}
+
+// ListHooks lists the hooks registered on collection.
+func (c *Client) ListHooks(collection string, opts ...RequestOption) ([]Hook, error) {
+	c = c.withOptions(opts)
+	respBody, err := c.makeRequest("GET", "/api/hooks/"+url.PathEscape(collection), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Hooks []Hook `json:"hooks"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, err
+	}
+	return result.Hooks, nil
+}
+
+// RemoveHook unregisters a hook from collection. The Function itself is left
+// in place.
+func (c *Client) RemoveHook(collection, hookID string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
+	_, err := c.makeRequest("DELETE", fmt.Sprintf("/api/hooks/%s/%s", url.PathEscape(collection), url.PathEscape(hookID)), nil)
+	return err
+}
@@ -0,0 +1,84 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRegisterHook(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/hooks/orders": func(w http.ResponseWriter, r *http.Request) {
+			var hook Hook
+			if err := json.NewDecoder(r.Body).Decode(&hook); err != nil {
+				t.Fatalf("decode hook: %v", err)
+			}
+			if hook.Event != HookOnInsert || hook.FunctionLabel != "notify" || hook.Collection != "orders" || !hook.Enabled {
+				t.Errorf("unexpected hook body: %+v", hook)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "hook_1"})
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	id, err := client.RegisterHook("orders", HookOnInsert, "notify")
+	if err != nil {
+		t.Fatalf("RegisterHook failed: %v", err)
+	}
+	if id != "hook_1" {
+		t.Errorf("Expected hook_1, got %s", id)
+	}
+}
+
+func TestRegisterHookInvalidEvent(t *testing.T) {
+	client := &Client{}
+	if _, err := client.RegisterHook("orders", HookEvent("upsert"), "notify"); err == nil {
+		t.Fatal("expected error for invalid hook event")
+	}
+}
+
+func TestListHooks(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/hooks/orders": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"hooks": []map[string]interface{}{
+					{"id": "hook_1", "collection": "orders", "event": "insert", "function_label": "notify", "enabled": true},
+					{"id": "hook_2", "collection": "orders", "event": "delete", "function_label": "audit", "enabled": false},
+				},
+			})
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	hooks, err := client.ListHooks("orders")
+	if err != nil {
+		t.Fatalf("ListHooks failed: %v", err)
+	}
+	if len(hooks) != 2 {
+		t.Fatalf("Expected 2 hooks, got %d", len(hooks))
+	}
+	if hooks[1].Event != HookOnDelete || hooks[1].FunctionLabel != "audit" || hooks[1].Enabled {
+		t.Errorf("unexpected second hook: %+v", hooks[1])
+	}
+}
+
+func TestRemoveHook(t *testing.T) {
+	called := false
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"DELETE /api/hooks/orders/hook_1": func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	if err := client.RemoveHook("orders", "hook_1"); err != nil {
+		t.Fatalf("RemoveHook failed: %v", err)
+	}
+	if !called {
+		t.Error("expected DELETE /api/hooks/orders/hook_1")
+	}
+}
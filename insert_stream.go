@@ -0,0 +1,323 @@
+package ekodb
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// InsertStreamOptions contains optional parameters for InsertStream
+type InsertStreamOptions struct {
+	BypassRipple *bool
+	// OnProgress is called each time the server acknowledges progress, with
+	// running totals. It runs on the goroutine that called InsertStream.
+	OnProgress func(InsertStreamProgress)
+	// Progress is called after every ProgressEvery records written to the
+	// request (default 1000) with the number sent so far, and once more when
+	// the input ends. The total is -1, since a stream's length is unknown.
+	// It runs on the goroutine writing the request body.
+	Progress      ProgressFunc
+	ProgressEvery int
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
+}
+
+// InsertStreamProgress is a running acknowledgement from the bulk endpoint.
+type InsertStreamProgress struct {
+	Inserted int `json:"inserted"`
+	Failed   int `json:"failed"`
+}
+
+// InsertStreamResult summarizes a completed InsertStream.
+type InsertStreamResult struct {
+	Inserted int      `json:"inserted"`
+	Failed   int      `json:"failed"`
+	Errors   []string `json:"errors,omitempty"` // Messages for failed records, if the server reports them
+}
+
+// insertStreamAck is one line of the bulk endpoint's NDJSON response.
+type insertStreamAck struct {
+	InsertStreamResult
+	Done  bool   `json:"done"`
+	Error string `json:"error,omitempty"`
+}
+
+// InsertStream inserts every record received from records over a single
+// streaming request, returning once records is closed and the server has
+// acknowledged the last one. Records are sent as NDJSON, or as a stream of
+// concatenated MessagePack values when the client uses MessagePack; the
+// server replies with NDJSON progress lines ({"inserted": n, "failed": m})
+// and a final summary. This avoids a round trip per batch for firehose
+// ingestion.
+//
+// Each record goes through soft schema (see EnableSoftSchema) and
+// ClientConfig.ValidateRecords as it is sent; a record that fails validation
+// aborts the request with the *ValidationError, and records sent before it
+// may already be inserted.
+//
+// The request is never retried, since a partially consumed stream cannot be
+// replayed. If the server rejects the token, it is refreshed and the
+// *HTTPError returned, so a retry with the remaining records authenticates.
+// Cancel the client's context (see WithContext) to abort.
+//
+// Example:
+//
+//	records := make(chan Record)
+//	go func() {
+//	    defer close(records)
+//	    for event := range events {
+//	        records <- Record{"type": event.Type, "at": event.At}
+//	    }
+//	}()
+//	result, err := client.InsertStream("events", records, InsertStreamOptions{
+//	    OnProgress: func(p InsertStreamProgress) { log.Printf("%d inserted", p.Inserted) },
+//	})
+func (c *Client) InsertStream(collection string, records <-chan Record, opts ...InsertStreamOptions) (*InsertStreamResult, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	var opt InsertStreamOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	path := "/api/insert/stream/" + url.PathEscape(collection)
+	if opt.BypassRipple != nil {
+		path += fmt.Sprintf("?bypass_ripple=%t", *opt.BypassRipple)
+	}
+
+	contentType := "application/x-ndjson"
+	if c.format == MessagePack {
+		contentType = "application/x-msgpack-stream"
+	}
+
+	// rejected carries the record validation error that aborted the body, so
+	// it is returned instead of the transport's error for the broken request.
+	rejected := make(chan error, 1)
+	prepare := func(r Record) (Record, error) {
+		c.softSchemaObserve(collection, r)
+		if err := c.validateWrite(collection, false, r); err != nil {
+			rejected <- err
+			return nil, err
+		}
+		if c.packVectors {
+			r = packRequestVectors(r).(Record)
+		}
+		return r, nil
+	}
+	streamErr := func(err error) error {
+		select {
+		case verr := <-rejected:
+			return verr
+		default:
+			return err
+		}
+	}
+
+	// Wait out a rate limit before the writer starts taking records.
+	if err := c.admit(false); err != nil {
+		return nil, err
+	}
+
+	ctx := c.context()
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeInsertStream(pw, records, c.format, ctx.Done(), prepare, opt))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+path, pr)
+	if err != nil {
+		pr.CloseWithError(err)
+		return nil, err
+	}
+	c.applyHeaders(req)
+	usedToken := c.getToken()
+	req.Header.Set("Authorization", "Bearer "+usedToken)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/x-ndjson")
+
+	// streamClient has no overall timeout (unless set with WithTimeout), so a
+	// long-running ingest is not cut off mid-stream.
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		pr.CloseWithError(err)
+		return nil, streamErr(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		pr.CloseWithError(fmt.Errorf("insert stream rejected with status %d", resp.StatusCode))
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusTooManyRequests && c.rateLimitQueue != nil {
+			c.rateLimitQueue.holdUntil(time.Now().Add(time.Duration(retryAfterSecs(resp)) * time.Second))
+		}
+		if resp.StatusCode == http.StatusUnauthorized {
+			if err := c.refreshTokenIfStale(usedToken); err != nil {
+				c.logf("Token refresh after rejected insert stream failed: %v", err)
+			}
+		}
+		return nil, responseError(resp, body)
+	}
+	c.extractRateLimitInfo(resp)
+
+	// A json.Decoder rather than a bufio.Scanner, whose 64KB line limit a
+	// final acknowledgement listing many errors can exceed.
+	var result InsertStreamResult
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var ack insertStreamAck
+		if err := dec.Decode(&ack); err != nil {
+			if err == io.EOF {
+				break
+			}
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				return &result, fmt.Errorf("invalid insert stream acknowledgement: %w", err)
+			}
+			return &result, streamErr(err)
+		}
+		if ack.Error != "" {
+			return &result, fmt.Errorf("insert stream failed: %s", ack.Error)
+		}
+		result.Inserted, result.Failed = ack.Inserted, ack.Failed
+		result.Errors = append(result.Errors, ack.Errors...)
+		if ack.Done {
+			return &result, streamErr(nil)
+		}
+		if opt.OnProgress != nil {
+			opt.OnProgress(InsertStreamProgress{Inserted: ack.Inserted, Failed: ack.Failed})
+		}
+	}
+	return &result, streamErr(fmt.Errorf("insert stream ended without a final acknowledgement"))
+}
+
+// defaultProgressEvery is how often InsertStreamOptions.Progress is called
+// when ProgressEvery is unset.
+const defaultProgressEvery = 1000
+
+// writeInsertStream encodes records onto w until the channel is closed or
+// done fires, passing each through prepare first and reporting to
+// opt.Progress.
+func writeInsertStream(w io.Writer, records <-chan Record, format SerializationFormat, done <-chan struct{}, prepare func(Record) (Record, error), opt InsertStreamOptions) error {
+	bw := bufio.NewWriter(w)
+	var encode func(Record) error
+	if format == MessagePack {
+		enc := msgpack.NewEncoder(bw)
+		encode = func(r Record) error { return enc.Encode(r) }
+	} else {
+		enc := json.NewEncoder(bw)
+		encode = func(r Record) error { return enc.Encode(r) }
+	}
+
+	every := opt.ProgressEvery
+	if every <= 0 {
+		every = defaultProgressEvery
+	}
+	sent := 0
+	for {
+		select {
+		case <-done:
+			return fmt.Errorf("insert stream cancelled")
+		case rec, ok := <-records:
+			if !ok {
+				if opt.Progress != nil && sent%every != 0 {
+					opt.Progress(sent, -1, nil)
+				}
+				return bw.Flush()
+			}
+			rec, err := prepare(rec)
+			if err != nil {
+				if opt.Progress != nil {
+					opt.Progress(sent, -1, err)
+				}
+				return err
+			}
+			if err := encode(rec); err != nil {
+				if opt.Progress != nil {
+					opt.Progress(sent, -1, err)
+				}
+				return err
+			}
+			sent++
+			if opt.Progress != nil && sent%every == 0 {
+				opt.Progress(sent, -1, nil)
+			}
+			// Send what is buffered whenever the producer is momentarily idle,
+			// so slow streams are not held back by the write buffer.
+			if len(records) == 0 {
+				if err := bw.Flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// InsertStreamReader inserts the newline-delimited JSON records read from r
+// over a single streaming request, like InsertStream. Records are decoded
+// and sent one at a time, so the input is never held in memory; use
+// Progress to report progress every ProgressEvery records. Cancelling ctx
+// aborts the request.
+//
+// If r yields something other than a JSON object, the request is aborted
+// and the error returned; records sent before it may already be inserted.
+//
+//	f, _ := os.Open("events.ndjson")
+//	defer f.Close()
+//	result, err := client.InsertStreamReader(ctx, "events", f, InsertStreamOptions{
+//	    ProgressEvery: 10_000,
+//	    Progress:      func(n, _ int, _ error) { log.Printf("%d sent", n) },
+//	})
+func (c *Client) InsertStreamReader(ctx context.Context, collection string, r io.Reader, opts ...InsertStreamOptions) (*InsertStreamResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	records := make(chan Record)
+	var readErr error
+	go func() {
+		defer close(records)
+		dec := json.NewDecoder(r)
+		for n := 1; ; n++ {
+			var rec Record
+			if err := dec.Decode(&rec); err != nil {
+				if err != io.EOF {
+					readErr = fmt.Errorf("record %d: %w", n, err)
+					cancel()
+				}
+				return
+			}
+			if rec == nil {
+				readErr = fmt.Errorf("record %d: null is not a record", n)
+				cancel()
+				return
+			}
+			select {
+			case records <- rec:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	result, err := c.WithContext(ctx).InsertStream(collection, records, opts...)
+	// Wait for the reader to stop before reading readErr.
+	cancel()
+	for range records {
+	}
+	if readErr != nil {
+		return result, fmt.Errorf("insert stream: %w", readErr)
+	}
+	return result, err
+}
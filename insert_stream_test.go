@@ -0,0 +1,280 @@
+package ekodb
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// insertStreamHandler acknowledges every two records and sends a final
+// summary; records with "bad": true count as failures.
+func insertStreamHandler(t *testing.T, gotContentType *string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*gotContentType = r.Header.Get("Content-Type")
+		if err := http.NewResponseController(w).EnableFullDuplex(); err != nil {
+			t.Errorf("EnableFullDuplex: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		enc := json.NewEncoder(w)
+		inserted, failed := 0, 0
+		var errs []string
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var rec Record
+			if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+				t.Errorf("bad NDJSON line %q: %v", scanner.Text(), err)
+				continue
+			}
+			if rec["bad"] == true {
+				failed++
+				errs = append(errs, fmt.Sprintf("record %v rejected", rec["n"]))
+			} else {
+				inserted++
+			}
+			if (inserted+failed)%2 == 0 {
+				_ = enc.Encode(map[string]int{"inserted": inserted, "failed": failed})
+				w.(http.Flusher).Flush()
+			}
+		}
+		_ = enc.Encode(map[string]interface{}{"inserted": inserted, "failed": failed, "errors": errs, "done": true})
+	}
+}
+
+func TestInsertStream(t *testing.T) {
+	var contentType string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/insert/stream/events": insertStreamHandler(t, &contentType),
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	records := make(chan Record)
+	go func() {
+		defer close(records)
+		for i := 0; i < 5; i++ {
+			records <- Record{"n": i, "bad": i == 3}
+		}
+	}()
+
+	var progress []InsertStreamProgress
+	result, err := client.InsertStream("events", records, InsertStreamOptions{
+		OnProgress: func(p InsertStreamProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("InsertStream failed: %v", err)
+	}
+	if contentType != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q", contentType)
+	}
+	if result.Inserted != 4 || result.Failed != 1 || len(result.Errors) != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(progress) != 2 || progress[1].Inserted+progress[1].Failed != 4 {
+		t.Errorf("unexpected progress: %+v", progress)
+	}
+}
+
+func TestInsertStreamRejected(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/insert/stream/events": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("collection not found"))
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	records := make(chan Record, 1)
+	records <- Record{"n": 1}
+	close(records)
+
+	_, err := client.InsertStream("events", records)
+	httpErr, ok := err.(*HTTPError)
+	if !ok || !httpErr.IsNotFound() {
+		t.Errorf("expected HTTPError 404, got %v", err)
+	}
+}
+
+func TestInsertStreamReader(t *testing.T) {
+	var contentType string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/insert/stream/events": insertStreamHandler(t, &contentType),
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	input := "{\"n\": 0}\n{\"n\": 1}\n\n{\"n\": 2, \"bad\": true}\n{\"n\": 3}\n{\"n\": 4}\n"
+	var sent []int
+	result, err := client.InsertStreamReader(context.Background(), "events", strings.NewReader(input), InsertStreamOptions{
+		ProgressEvery: 2,
+		Progress:      func(n, total int, _ error) { sent = append(sent, n) },
+	})
+	if err != nil {
+		t.Fatalf("InsertStreamReader failed: %v", err)
+	}
+	if result.Inserted != 4 || result.Failed != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if fmt.Sprint(sent) != "[2 4 5]" {
+		t.Errorf("Progress calls = %v, want [2 4 5]", sent)
+	}
+
+	_, err = client.InsertStreamReader(context.Background(), "events", strings.NewReader("{\"n\": 0}\n[1, 2]\n"))
+	if err == nil || !strings.Contains(err.Error(), "record 2") {
+		t.Errorf("expected an error for record 2, got %v", err)
+	}
+
+	// Must not panic on a nil context.
+	result, err = client.InsertStreamReader(nil, "events", strings.NewReader("{\"n\": 0}\n"))
+	if err != nil || result.Inserted != 1 {
+		t.Errorf("InsertStreamReader with a nil ctx: %+v, %v", result, err)
+	}
+}
+
+func TestInsertStreamLargeFinalAck(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/insert/stream/events": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.Copy(io.Discard, r.Body)
+			errs := make([]string, 2000)
+			for i := range errs {
+				errs[i] = fmt.Sprintf("record %d rejected: %s", i, strings.Repeat("x", 50))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"inserted": 0, "failed": len(errs), "errors": errs, "done": true})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	records := make(chan Record, 1)
+	records <- Record{"n": 1}
+	close(records)
+
+	result, err := client.InsertStream("events", records)
+	if err != nil {
+		t.Fatalf("InsertStream failed: %v", err)
+	}
+	if result.Failed != 2000 || len(result.Errors) != 2000 {
+		t.Errorf("unexpected result: failed=%d, %d errors", result.Failed, len(result.Errors))
+	}
+}
+
+func TestInsertStreamRefreshesRejectedToken(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/insert/stream/events": insertStreamHandler(t, new(string)),
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.tokenMu.Lock()
+	client.token = "stale-token"
+	client.tokenMu.Unlock()
+
+	records := make(chan Record, 1)
+	records <- Record{"n": 1}
+	close(records)
+	_, err := client.InsertStream("events", records)
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected HTTPError 401, got %v", err)
+	}
+
+	records = make(chan Record, 1)
+	records <- Record{"n": 1}
+	close(records)
+	if _, err := client.InsertStream("events", records); err != nil {
+		t.Errorf("InsertStream after the refresh failed: %v", err)
+	}
+}
+
+func TestInsertStreamValidatesRecords(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/collections/events": func(w http.ResponseWriter, r *http.Request) {
+			schema := NewSchemaBuilder().
+				AddField("n", NewFieldTypeSchemaBuilder("Integer").Required().Build()).
+				Build()
+			_ = json.NewEncoder(w).Encode(CollectionMetadata{Collection: schema})
+		},
+		"POST /api/insert/stream/events": insertStreamHandler(t, new(string)),
+	})
+	defer server.Close()
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:         server.URL,
+		APIKey:          "test-api-key",
+		Timeout:         5 * time.Second,
+		Format:          JSON,
+		ValidateRecords: true,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+
+	records := make(chan Record, 3)
+	records <- Record{"n": 1}
+	records <- Record{"other": true}
+	records <- Record{"n": 3}
+	close(records)
+	_, err = client.InsertStream("events", records)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+}
+
+func TestInsertStreamPacksVectors(t *testing.T) {
+	var sent Record
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(ServerInfo{Version: "1.0", Features: []string{"packed_vectors"}})
+		},
+		"POST /api/insert/stream/docs": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"inserted": 1, "done": true})
+		},
+	})
+	defer server.Close()
+	client := newPackingClient(t, server)
+
+	records := make(chan Record, 1)
+	records <- Record{"embedding": FieldVector([]float64{0.5, 0.25})}
+	close(records)
+	if _, err := client.InsertStream("docs", records); err != nil {
+		t.Fatalf("InsertStream failed: %v", err)
+	}
+	vec, _ := sent["embedding"].(map[string]interface{})
+	if vec["encoding"] != PackedVectorEncoding || vec["value"] != PackVector([]float64{0.5, 0.25}) {
+		t.Errorf("vector not packed on the wire: %v", sent["embedding"])
+	}
+}
+
+func TestInsertStreamWaitsForRateLimit(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/insert/stream/events": func(w http.ResponseWriter, r *http.Request) {
+			t.Error("stream sent while the rate limit was held")
+		},
+	})
+	defer server.Close()
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:             server.URL,
+		APIKey:              "test-api-key",
+		Format:              JSON,
+		RateLimitQueueDepth: 2,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+	client.rateLimitQueue.holdUntil(time.Now().Add(time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	records := make(chan Record)
+	close(records)
+	if _, err := client.WithContext(ctx).InsertStream("events", records); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
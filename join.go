@@ -1,12 +1,25 @@
 // Package ekodb provides a Go client for ekoDB
 package ekodb
 
-// JoinConfig represents configuration for joining collections
+import (
+	"fmt"
+	"strings"
+)
+
+// JoinConfig represents configuration for joining collections.
+//
+// AsField may be a dot path such as "customer.profile", which nests the joined
+// records under an existing or new object. Filter, SelectFields, and
+// ExcludeFields apply to the joined records only.
 type JoinConfig struct {
 	Collections  []string `json:"collections"`
 	LocalField   string   `json:"local_field"`
 	ForeignField string   `json:"foreign_field"`
 	AsField      string   `json:"as_field"`
+
+	Filter        interface{} `json:"filter,omitempty"`
+	SelectFields  []string    `json:"select_fields,omitempty"`
+	ExcludeFields []string    `json:"exclude_fields,omitempty"`
 }
 
 // NewJoinConfig creates a new join configuration
@@ -29,12 +42,73 @@ func NewSingleJoin(collection, localField, foreignField, asField string) JoinCon
 	}
 }
 
+// Where returns a copy of the join that only joins records matching filter,
+// a *QueryBuilder (whose filters are used) or a filter expression
+func (j JoinConfig) Where(filter interface{}) JoinConfig {
+	j.Filter = filterOf(filter)
+	return j
+}
+
+// Select returns a copy of the join that keeps only these fields of the
+// joined records
+func (j JoinConfig) Select(fields ...string) JoinConfig {
+	j.SelectFields = fields
+	return j
+}
+
+// Exclude returns a copy of the join that drops these fields from the joined
+// records
+func (j JoinConfig) Exclude(fields ...string) JoinConfig {
+	j.ExcludeFields = fields
+	return j
+}
+
 // ToMap converts JoinConfig to a map for use in queries
 func (j JoinConfig) ToMap() map[string]interface{} {
-	return map[string]interface{}{
+	m := map[string]interface{}{
 		"collections":   j.Collections,
 		"local_field":   j.LocalField,
 		"foreign_field": j.ForeignField,
 		"as_field":      j.AsField,
 	}
+	if filter := filterOf(j.Filter); filter != nil {
+		m["filter"] = filter
+	}
+	if len(j.SelectFields) > 0 {
+		m["select_fields"] = j.SelectFields
+	}
+	if len(j.ExcludeFields) > 0 {
+		m["exclude_fields"] = j.ExcludeFields
+	}
+	return m
+}
+
+// validate reports problems with the join's fields.
+func (j JoinConfig) validate() []string {
+	var problems []string
+	if len(j.Collections) == 0 {
+		problems = append(problems, "no collections")
+	}
+	for _, c := range j.Collections {
+		if c == "" {
+			problems = append(problems, "empty collection name")
+		}
+	}
+	if j.LocalField == "" {
+		problems = append(problems, "empty local_field")
+	}
+	if j.ForeignField == "" {
+		problems = append(problems, "empty foreign_field")
+	}
+	if j.AsField == "" {
+		problems = append(problems, "empty as_field")
+	} else {
+		for _, seg := range strings.Split(j.AsField, ".") {
+			if seg == "" {
+				problems = append(problems, fmt.Sprintf("as_field %q has an empty path segment", j.AsField))
+				break
+			}
+		}
+	}
+	return append(problems, validateProjection(j.SelectFields, j.ExcludeFields)...)
 }
@@ -0,0 +1,162 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// kvBatchFeature is the ServerInfo feature flag for the mixed KV batch
+// endpoint.
+const kvBatchFeature = "kv_batch"
+
+// KVBatch queues KV sets and deletes to send together with Execute:
+//
+//	results, err := client.KVBatch().
+//	    Set("config:workers", 8).
+//	    Set("session:abc", session, ekodb.KVSetOptions{TTL: time.Hour}).
+//	    Delete("session:old").
+//	    Execute(ctx)
+//
+// On servers that advertise the "kv_batch" feature the operations go in one
+// request and are applied atomically: if any fails, none are applied and its
+// result says why. Elsewhere Execute sends them one at a time, in order, and
+// the operations that succeeded stay applied.
+type KVBatch struct {
+	client *Client
+	ops    []kvBatchOp
+	err    error
+}
+
+// ErrKVBatchRolledBack is the error of the operations in an atomic KV batch
+// that were not applied because another operation failed.
+var ErrKVBatchRolledBack = errors.New("ekodb: kv batch rolled back")
+
+// kvBatchOp is one operation in the KV batch envelope.
+type kvBatchOp struct {
+	Op    string      `json:"op"`
+	Key   string      `json:"key"`
+	Value interface{} `json:"value,omitempty"`
+	TTL   int64       `json:"ttl,omitempty"`
+}
+
+// KVBatchResult is the outcome of one KV batch operation.
+type KVBatchResult struct {
+	Op  string // "set" or "delete"
+	Key string
+	// Err is the operation's error. When an atomic batch fails, the other
+	// operations' errors wrap ErrKVBatchRolledBack.
+	Err error
+}
+
+// KVBatch starts an empty KV batch.
+func (c *Client) KVBatch(opts ...RequestOption) *KVBatch {
+	c = c.withOptions(opts)
+	return &KVBatch{client: c}
+}
+
+// Set queues a set of key, with the same options as KVSet
+func (b *KVBatch) Set(key string, value interface{}, opts ...KVSetOptions) *KVBatch {
+	ttl, err := kvSetTTL("KVBatch set", key, opts)
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	op := kvBatchOp{Op: "set", Key: key, Value: value}
+	if ttl > 0 {
+		op.TTL = kvTTLSeconds(ttl)
+	}
+	b.ops = append(b.ops, op)
+	return b
+}
+
+// Delete queues a delete of key
+func (b *KVBatch) Delete(key string) *KVBatch {
+	b.ops = append(b.ops, kvBatchOp{Op: "delete", Key: key})
+	return b
+}
+
+// Len returns the number of queued operations.
+func (b *KVBatch) Len() int {
+	return len(b.ops)
+}
+
+// Execute sends the queued operations and returns one result per operation,
+// in order. The error is non-nil only if the batch could not be sent at all,
+// or an operation had invalid options (in which case nothing is sent);
+// failures of single operations are in their results.
+func (b *KVBatch) Execute(ctx context.Context) ([]KVBatchResult, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.ops) == 0 {
+		return nil, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c := b.client.WithContext(ctx)
+	if c.serverFeature(kvBatchFeature) {
+		return c.executeKVBatch(b.ops)
+	}
+
+	results := make([]KVBatchResult, len(b.ops))
+	for i, op := range b.ops {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		r := KVBatchResult{Op: op.Op, Key: op.Key}
+		switch op.Op {
+		case "set":
+			var opts []KVSetOptions
+			if op.TTL > 0 {
+				opts = append(opts, KVSetOptions{TTL: time.Duration(op.TTL) * time.Second})
+			}
+			r.Err = c.KVSet(op.Key, op.Value, opts...)
+		case "delete":
+			r.Err = c.KVDelete(op.Key)
+		}
+		results[i] = r
+	}
+	return results, nil
+}
+
+// executeKVBatch sends ops in one atomic request to the KV batch endpoint.
+func (c *Client) executeKVBatch(ops []kvBatchOp) ([]KVBatchResult, error) {
+	path := "/api/kv/batch"
+	respBody, err := c.makeRequest("POST", path, map[string]interface{}{"operations": ops, "atomic": true})
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Results []struct {
+			Error string `json:"error"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Results) != len(ops) {
+		return nil, fmt.Errorf("kv batch: %d results for %d operations", len(response.Results), len(ops))
+	}
+	failed := false
+	for _, res := range response.Results {
+		failed = failed || res.Error != ""
+	}
+	results := make([]KVBatchResult, len(ops))
+	for i, op := range ops {
+		r := KVBatchResult{Op: op.Op, Key: op.Key}
+		switch msg := response.Results[i].Error; {
+		case msg != "":
+			r.Err = fmt.Errorf("kv %s %s: %s", op.Op, op.Key, msg)
+		case failed:
+			r.Err = fmt.Errorf("kv %s %s: %w", op.Op, op.Key, ErrKVBatchRolledBack)
+		}
+		results[i] = r
+	}
+	return results, nil
+}
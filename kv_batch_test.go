@@ -0,0 +1,97 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestKVBatchExecute(t *testing.T) {
+	var body struct {
+		Operations []map[string]interface{} `json:"operations"`
+		Atomic     bool                     `json:"atomic"`
+	}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"kv_batch"}})
+		},
+		"POST /api/kv/batch": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			results := []map[string]interface{}{}
+			for _, op := range body.Operations {
+				if op["key"] == "bad" {
+					results = append(results, map[string]interface{}{"error": "key is read-only"})
+				} else {
+					results = append(results, map[string]interface{}{})
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	results, err := client.KVBatch().
+		Set("a", 1, KVSetOptions{TTL: time.Minute}).
+		Delete("b").
+		Execute(t.Context())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !body.Atomic || len(body.Operations) != 2 || body.Operations[0]["ttl"] != float64(60) || body.Operations[1]["op"] != "delete" {
+		t.Errorf("sent %+v", body)
+	}
+	if len(results) != 2 || results[0].Err != nil || results[1].Err != nil || results[1].Key != "b" {
+		t.Errorf("results %+v", results)
+	}
+
+	results, err = client.KVBatch().Set("a", 2).Set("bad", 3).Execute(t.Context())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !errors.Is(results[0].Err, ErrKVBatchRolledBack) || results[1].Err == nil || errors.Is(results[1].Err, ErrKVBatchRolledBack) {
+		t.Errorf("rolled back results %+v", results)
+	}
+
+	if _, err := client.KVBatch().Set("a", 1, KVSetOptions{TTL: -time.Second}).Execute(t.Context()); err == nil {
+		t.Error("expected an error for a negative TTL")
+	}
+}
+
+func TestKVBatchExecuteFallback(t *testing.T) {
+	var calls []string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0"})
+		},
+		"POST /api/kv/set/*": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			calls = append(calls, r.URL.Path)
+			if body["ttl"] != float64(30) {
+				t.Errorf("ttl = %v", body["ttl"])
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+		"DELETE /api/kv/delete/*": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	results, err := client.KVBatch().Set("a", 1, KVSetOptions{TTL: 30 * time.Second}).Delete("b").Execute(t.Context())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "/api/kv/set/a" || calls[1] != "/api/kv/delete/b" {
+		t.Errorf("calls %v", calls)
+	}
+	if results[0].Err != nil || results[1].Err == nil {
+		t.Errorf("results %+v", results)
+	}
+}
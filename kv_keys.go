@@ -0,0 +1,81 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// kvListKeysFeature is the ServerInfo feature flag for the key listing
+// endpoint.
+const kvListKeysFeature = "kv_list_keys"
+
+// KVListKeys lists up to limit keys starting with prefix, in key order,
+// without their values. Pass the returned cursor to get the next page; it is
+// "" after the last page:
+//
+//	cursor := ""
+//	for {
+//	    keys, next, err := client.KVListKeys("session:", 1000, cursor)
+//	    ...
+//	    if next == "" {
+//	        break
+//	    }
+//	    cursor = next
+//	}
+//
+// On servers that do not advertise "kv_list_keys", the page is cut from a
+// KVFind on the prefix, which fetches the matching values every call.
+func (c *Client) KVListKeys(prefix string, limit int, cursor string, opts ...RequestOption) ([]string, string, error) {
+	c = c.withOptions(opts)
+	if limit <= 0 {
+		limit = 1000
+	}
+	if !c.serverFeature(kvListKeysFeature) {
+		return c.kvListKeysByFind(prefix, limit, cursor)
+	}
+
+	params := url.Values{}
+	if prefix != "" {
+		params.Set("prefix", prefix)
+	}
+	params.Set("limit", strconv.Itoa(limit))
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+	respBody, err := c.makeRequest("GET", "/api/kv/keys?"+params.Encode(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	var result struct {
+		Keys       []string `json:"keys"`
+		NextCursor string   `json:"next_cursor"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, "", err
+	}
+	return result.Keys, result.NextCursor, nil
+}
+
+// kvListKeysByFind pages through the keys KVFind returns for prefix. The
+// cursor is the last key of the previous page.
+func (c *Client) kvListKeysByFind(prefix string, limit int, cursor string) ([]string, string, error) {
+	entries, err := c.KVFind(prefix+"*", false)
+	if err != nil {
+		return nil, "", err
+	}
+	keys := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if key, ok := e["key"].(string); ok && strings.HasPrefix(key, prefix) && key > cursor {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) <= limit {
+		return keys, "", nil
+	}
+	keys = keys[:limit]
+	return keys, keys[limit-1], nil
+}
@@ -0,0 +1,67 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestKVListKeys(t *testing.T) {
+	var query string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"kv_list_keys"}})
+		},
+		"GET /api/kv/keys": func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []string{"user:3", "user:4"}, "next_cursor": "c2"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	keys, next, err := client.KVListKeys("user:", 2, "c1")
+	if err != nil {
+		t.Fatalf("KVListKeys failed: %v", err)
+	}
+	if query != "cursor=c1&limit=2&prefix=user%3A" {
+		t.Errorf("query = %q", query)
+	}
+	if fmt.Sprint(keys) != "[user:3 user:4]" || next != "c2" {
+		t.Errorf("keys %v, next %q", keys, next)
+	}
+}
+
+func TestKVListKeysFallback(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0"})
+		},
+		"POST /api/kv/find": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"key": "user:3", "value": 3}, {"key": "user:1", "value": 1},
+				{"key": "user:2", "value": 2}, {"key": "users", "value": 0},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	var pages []string
+	cursor := ""
+	for {
+		keys, next, err := client.KVListKeys("user:", 2, cursor)
+		if err != nil {
+			t.Fatalf("KVListKeys failed: %v", err)
+		}
+		pages = append(pages, fmt.Sprint(keys))
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if fmt.Sprint(pages) != "[[user:1 user:2] [user:3]]" {
+		t.Errorf("pages = %v", pages)
+	}
+}
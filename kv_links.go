@@ -7,7 +7,8 @@ import (
 )
 
 // KVGetLinks retrieves documents linked to a KV key.
-func (c *Client) KVGetLinks(key string) (map[string]interface{}, error) {
+func (c *Client) KVGetLinks(key string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/kv/links/%s", url.PathEscape(key)), nil)
 	if err != nil {
 		return nil, err
@@ -20,7 +21,8 @@ func (c *Client) KVGetLinks(key string) (map[string]interface{}, error) {
 }
 
 // KVLink creates a link between a KV key and a document.
-func (c *Client) KVLink(key, collection, documentId string) (map[string]interface{}, error) {
+func (c *Client) KVLink(key, collection, documentId string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	body := map[string]interface{}{
 		"key":         key,
 		"collection":  collection,
@@ -38,7 +40,8 @@ func (c *Client) KVLink(key, collection, documentId string) (map[string]interfac
 }
 
 // KVUnlink removes a link between a KV key and a document.
-func (c *Client) KVUnlink(key, collection, documentId string) (map[string]interface{}, error) {
+func (c *Client) KVUnlink(key, collection, documentId string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	body := map[string]interface{}{
 		"key":         key,
 		"collection":  collection,
@@ -0,0 +1,147 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// kvListFeature is the ServerInfo feature flag for the atomic KV list
+// push and pop endpoints.
+const kvListFeature = "kv_list"
+
+// KVAppend adds item to the end of the list stored at key, creating the list
+// if the key does not exist, and returns the new length. With KVPopLeft it
+// makes a FIFO work queue; with KVPop, a stack.
+//
+// On servers that advertise "kv_list" the push is atomic. Elsewhere the list
+// is read and written back whole, so concurrent writers can lose items.
+func (c *Client) KVAppend(key string, item interface{}, opts ...RequestOption) (int, error) {
+	c = c.withOptions(opts)
+	return c.kvListPush(key, item, "right")
+}
+
+// KVPrepend adds item to the start of the list stored at key, as KVAppend
+// does to the end
+func (c *Client) KVPrepend(key string, item interface{}, opts ...RequestOption) (int, error) {
+	c = c.withOptions(opts)
+	return c.kvListPush(key, item, "left")
+}
+
+// KVPop removes and returns the last item of the list stored at key. It
+// returns false if the list is empty or the key does not exist.
+//
+// On servers that advertise "kv_list" the pop is atomic. Elsewhere the list
+// is read and written back whole, so concurrent consumers can receive the
+// same item.
+func (c *Client) KVPop(key string, opts ...RequestOption) (interface{}, bool, error) {
+	c = c.withOptions(opts)
+	return c.kvListPop(key, "right")
+}
+
+// KVPopLeft removes and returns the first item of the list stored at key, as
+// KVPop does the last
+func (c *Client) KVPopLeft(key string, opts ...RequestOption) (interface{}, bool, error) {
+	c = c.withOptions(opts)
+	return c.kvListPop(key, "left")
+}
+
+func (c *Client) kvListPush(key string, item interface{}, side string) (int, error) {
+	if !c.serverFeature(kvListFeature) {
+		return c.kvListRewrite(key, func(items []interface{}) []interface{} {
+			if side == "left" {
+				return append([]interface{}{item}, items...)
+			}
+			return append(items, item)
+		})
+	}
+	path := fmt.Sprintf("/api/kv/list/%s/push", url.PathEscape(key))
+	respBody, err := c.makeRequest("POST", path, map[string]interface{}{"items": []interface{}{item}, "side": side})
+	if err != nil {
+		return 0, err
+	}
+	var result struct {
+		Length int `json:"length"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return 0, err
+	}
+	return result.Length, nil
+}
+
+func (c *Client) kvListPop(key, side string) (interface{}, bool, error) {
+	if !c.serverFeature(kvListFeature) {
+		var popped interface{}
+		var found bool
+		_, err := c.kvListRewrite(key, func(items []interface{}) []interface{} {
+			if len(items) == 0 {
+				return nil
+			}
+			found = true
+			if side == "left" {
+				popped = items[0]
+				return items[1:]
+			}
+			popped = items[len(items)-1]
+			return items[:len(items)-1]
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		return popped, found, nil
+	}
+	path := fmt.Sprintf("/api/kv/list/%s/pop", url.PathEscape(key))
+	respBody, err := c.makeRequest("POST", path, map[string]interface{}{"side": side})
+	if err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.IsNotFound() {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	var result struct {
+		Value interface{} `json:"value"`
+		Found bool        `json:"found"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, false, err
+	}
+	return result.Value, result.Found, nil
+}
+
+// kvListRewrite applies edit to the list stored at key and writes the result
+// back, keeping the key's expiry, and returns the new length. A missing key
+// is an empty list; if edit returns nil for an empty list nothing is written.
+func (c *Client) kvListRewrite(key string, edit func([]interface{}) []interface{}) (int, error) {
+	var items []interface{}
+	var opts []KVSetOptions
+	entry, err := c.KVGetWithMetadata(key)
+	switch {
+	case err == nil:
+		if entry.Value != nil {
+			list, ok := entry.Value.([]interface{})
+			if !ok {
+				return 0, fmt.Errorf("kv %s holds %T, not a list", key, entry.Value)
+			}
+			items = list
+		}
+		if entry.TTL > 0 {
+			opts = append(opts, KVSetOptions{TTL: entry.TTL})
+		}
+	default:
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) || !httpErr.IsNotFound() {
+			return 0, err
+		}
+	}
+
+	items = edit(items)
+	if items == nil {
+		return 0, nil
+	}
+	if err := c.KVSet(key, items, opts...); err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
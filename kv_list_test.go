@@ -0,0 +1,125 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestKVListOperations(t *testing.T) {
+	var list []interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"kv_list"}})
+		},
+		"POST /api/kv/list/jobs/push": func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Items []interface{} `json:"items"`
+				Side  string        `json:"side"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.Side == "left" {
+				list = append(body.Items, list...)
+			} else {
+				list = append(list, body.Items...)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"length": len(list)})
+		},
+		"POST /api/kv/list/jobs/pop": func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Side string `json:"side"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if len(list) == 0 {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"found": false})
+				return
+			}
+			var v interface{}
+			if body.Side == "left" {
+				v, list = list[0], list[1:]
+			} else {
+				v, list = list[len(list)-1], list[:len(list)-1]
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"value": v, "found": true})
+		},
+		"POST /api/kv/list/missing/pop": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	for _, job := range []string{"b", "c"} {
+		if _, err := client.KVAppend("jobs", job); err != nil {
+			t.Fatalf("KVAppend failed: %v", err)
+		}
+	}
+	if n, err := client.KVPrepend("jobs", "a"); err != nil || n != 3 {
+		t.Fatalf("KVPrepend = %d, %v", n, err)
+	}
+	if v, ok, err := client.KVPopLeft("jobs"); err != nil || !ok || v != "a" {
+		t.Errorf("KVPopLeft = %v, %v, %v", v, ok, err)
+	}
+	if v, ok, err := client.KVPop("jobs"); err != nil || !ok || v != "c" {
+		t.Errorf("KVPop = %v, %v, %v", v, ok, err)
+	}
+	if _, _, err := client.KVPop("jobs"); err != nil {
+		t.Fatalf("KVPop failed: %v", err)
+	}
+	if v, ok, err := client.KVPop("jobs"); err != nil || ok || v != nil {
+		t.Errorf("KVPop on an empty list = %v, %v, %v", v, ok, err)
+	}
+	if _, ok, err := client.KVPopLeft("missing"); err != nil || ok {
+		t.Errorf("KVPopLeft on a missing key = %v, %v", ok, err)
+	}
+}
+
+func TestKVListFallback(t *testing.T) {
+	stored := map[string]interface{}{"recent": []interface{}{"x"}}
+	var ttls []interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0"})
+		},
+		"GET /api/kv/get/*": func(w http.ResponseWriter, r *http.Request) {
+			key := r.URL.Path[len("/api/kv/get/"):]
+			v, ok := stored[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"value": v, "ttl_remaining_secs": 120})
+		},
+		"POST /api/kv/set/*": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			stored[r.URL.Path[len("/api/kv/set/"):]] = body["value"]
+			ttls = append(ttls, body["ttl"])
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	if n, err := client.KVAppend("recent", "y"); err != nil || n != 2 {
+		t.Fatalf("KVAppend = %d, %v", n, err)
+	}
+	if v, ok, err := client.KVPopLeft("recent"); err != nil || !ok || v != "x" {
+		t.Errorf("KVPopLeft = %v, %v, %v", v, ok, err)
+	}
+	if ttls[0] != float64(120) {
+		t.Errorf("expiry not kept: %v", ttls)
+	}
+	if n, err := client.KVPrepend("fresh", 1); err != nil || n != 1 {
+		t.Errorf("KVPrepend on a missing key = %d, %v", n, err)
+	}
+	if _, ok, err := client.KVPop("missing"); err != nil || ok {
+		t.Errorf("KVPop on a missing key = %v, %v", ok, err)
+	}
+	stored["scalar"] = "s"
+	if _, err := client.KVAppend("scalar", 1); err == nil {
+		t.Error("expected an error appending to a non-list")
+	}
+}
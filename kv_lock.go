@@ -0,0 +1,211 @@
+package ekodb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// kvConditionalFeature is the ServerInfo feature flag for conditional KV
+// writes (if_not_exists and if_version).
+const kvConditionalFeature = "kv_conditional"
+
+// kvLockPrefix is prepended to lock names to form their KV keys.
+const kvLockPrefix = "lock:"
+
+var (
+	// ErrLockHeld is returned by TryLock when another holder has the lock.
+	ErrLockHeld = errors.New("ekodb: lock is held")
+	// ErrLockLost is returned by Lease.Renew and Lease.Unlock when the lease
+	// expired and the lock was released or taken by another holder.
+	ErrLockLost = errors.New("ekodb: lock lost")
+)
+
+// Lease is a held lock, returned by Lock and TryLock. It lapses after its TTL
+// unless renewed; a holder doing long work should Renew well within the TTL
+// and stop work when Renew returns ErrLockLost. A Lease is safe for
+// concurrent use.
+type Lease struct {
+	client *Client
+	name   string
+	token  string
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	version   int64
+	expiresAt time.Time
+}
+
+// Lock acquires the named lock, waiting while another holder has it, and
+// returns a Lease that expires after ttl unless renewed. It gives up with
+// ctx's error when ctx is done. Locks are stored under the KV key
+// "lock:<name>", so they coordinate every client of the same database, e.g.
+// for leader election or cron dedup:
+//
+//	lease, err := client.Lock(ctx, "nightly-report", time.Minute)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lease.Unlock()
+//
+// Locking needs a server that advertises the "kv_conditional" feature, since
+// a plain KV write cannot be made atomic.
+func (c *Client) Lock(ctx context.Context, name string, ttl time.Duration, opts ...RequestOption) (*Lease, error) {
+	c = c.withOptions(opts)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for attempt := 0; ; attempt++ {
+		lease, err := c.tryLock(c.WithContext(ctx), name, ttl)
+		if !errors.Is(err, ErrLockHeld) {
+			return lease, err
+		}
+		wait := min(retryBackoff(attempt), ttl)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("lock %s: %w", name, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// TryLock acquires the named lock if it is free, and otherwise returns
+// ErrLockHeld without waiting.
+func (c *Client) TryLock(name string, ttl time.Duration, opts ...RequestOption) (*Lease, error) {
+	c = c.withOptions(opts)
+	return c.tryLock(c, name, ttl)
+}
+
+// tryLock acquires the lock through acquire, which may carry a context that
+// ends with the acquisition, and returns a Lease that renews and unlocks
+// through c.
+func (c *Client) tryLock(acquire *Client, name string, ttl time.Duration) (*Lease, error) {
+	if name == "" {
+		return nil, fmt.Errorf("lock name is required")
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("lock %s: TTL must be positive, got %v", name, ttl)
+	}
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	version, err := acquire.kvSetConditional(kvLockPrefix+name, token, ttl, map[string]interface{}{"if_not_exists": true})
+	if err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.IsConflict() {
+			return nil, fmt.Errorf("lock %s: %w", name, ErrLockHeld)
+		}
+		return nil, fmt.Errorf("lock %s: %w", name, err)
+	}
+	return &Lease{
+		client:    c,
+		name:      name,
+		token:     token,
+		ttl:       ttl,
+		version:   version,
+		expiresAt: start.Add(ttl),
+	}, nil
+}
+
+// Name returns the lock's name
+func (l *Lease) Name() string {
+	return l.name
+}
+
+// Token returns the random value identifying this holder, stored as the
+// lock key's value
+func (l *Lease) Token() string {
+	return l.token
+}
+
+// ExpiresAt returns when the lease lapses unless renewed
+func (l *Lease) ExpiresAt() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.expiresAt
+}
+
+// Renew extends the lease by its original TTL. It returns ErrLockLost if the
+// lease already expired and the lock was released or taken by another holder.
+func (l *Lease) Renew() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	start := time.Now()
+	version, err := l.client.kvSetConditional(kvLockPrefix+l.name, l.token, l.ttl, map[string]interface{}{"if_version": l.version})
+	if err != nil {
+		return l.lockError(err)
+	}
+	l.version = version
+	l.expiresAt = start.Add(l.ttl)
+	return nil
+}
+
+// Unlock releases the lock. It returns ErrLockLost if the lease had already
+// expired, in which case the lock may now belong to another holder and is
+// left alone.
+func (l *Lease) Unlock() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	path := fmt.Sprintf("/api/kv/delete/%s?if_version=%d", url.PathEscape(kvLockPrefix+l.name), l.version)
+	if _, err := l.client.makeRequest("DELETE", path, nil); err != nil {
+		return l.lockError(err)
+	}
+	l.expiresAt = time.Time{}
+	return nil
+}
+
+// lockError maps a failed conditional write on the lock key to ErrLockLost.
+func (l *Lease) lockError(err error) error {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && (httpErr.IsConflict() || httpErr.IsNotFound()) {
+		return fmt.Errorf("lock %s: %w", l.name, ErrLockLost)
+	}
+	return fmt.Errorf("lock %s: %w", l.name, err)
+}
+
+// kvSetConditional sets key to value with the given TTL (none if zero) if
+// the condition in cond holds, returning the key's new version. A failed
+// condition is reported by the server as an *HTTPError with IsConflict.
+func (c *Client) kvSetConditional(key string, value interface{}, ttl time.Duration, cond map[string]interface{}) (int64, error) {
+	if !c.serverFeature(kvConditionalFeature) {
+		return 0, fmt.Errorf("server does not support conditional KV writes (feature %q)", kvConditionalFeature)
+	}
+	data := map[string]interface{}{"value": value}
+	if ttl > 0 {
+		data["ttl"] = kvTTLSeconds(ttl)
+	}
+	for k, v := range cond {
+		data[k] = v
+	}
+	respBody, err := c.makeRequest("POST", "/api/kv/set/"+url.PathEscape(key), data)
+	if err != nil {
+		return 0, err
+	}
+	var result struct {
+		Version int64 `json:"version"`
+	}
+	if len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return 0, err
+		}
+	}
+	return result.Version, nil
+}
+
+// newLockToken returns a random token identifying a lock holder.
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating lock token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,109 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLockServer serves conditional KV writes for a single lock key.
+type fakeLockServer struct {
+	mu      sync.Mutex
+	held    bool
+	version int64
+	ttls    []interface{}
+}
+
+func (f *fakeLockServer) handlers() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"kv_conditional"}})
+		},
+		"POST /api/kv/set/lock:cron": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.ttls = append(f.ttls, body["ttl"])
+			switch {
+			case body["if_not_exists"] == true && f.held,
+				body["if_version"] != nil && (!f.held || body["if_version"] != float64(f.version)):
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "condition failed"})
+				return
+			}
+			f.held = true
+			f.version++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": f.version})
+		},
+		"DELETE /api/kv/delete/lock:cron": func(w http.ResponseWriter, r *http.Request) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if !f.held || r.URL.Query().Get("if_version") != "2" {
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "condition failed"})
+				return
+			}
+			f.held = false
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+		},
+	}
+}
+
+func TestLock(t *testing.T) {
+	fake := &fakeLockServer{}
+	server := createTestServer(t, fake.handlers())
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	acquireCtx, cancelAcquire := context.WithCancel(context.Background())
+	lease, err := client.Lock(acquireCtx, "cron", 1500*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+	// The lease outlives the context it was acquired with.
+	cancelAcquire()
+	if lease.Name() != "cron" || len(lease.Token()) != 32 || time.Until(lease.ExpiresAt()) <= 0 {
+		t.Errorf("lease = %s %q %v", lease.Name(), lease.Token(), lease.ExpiresAt())
+	}
+
+	if _, err := client.TryLock("cron", time.Second); !errors.Is(err, ErrLockHeld) {
+		t.Errorf("TryLock on a held lock = %v, want ErrLockHeld", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Lock(ctx, "cron", time.Second); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Lock on a held lock = %v, want the ctx error", err)
+	}
+
+	if err := lease.Renew(); err != nil {
+		t.Fatalf("Renew failed: %v", err)
+	}
+	if err := lease.Unlock(); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	if err := lease.Renew(); !errors.Is(err, ErrLockLost) {
+		t.Errorf("Renew after Unlock = %v, want ErrLockLost", err)
+	}
+	if fake.ttls[0] != float64(2) {
+		t.Errorf("ttl sent = %v, want 2", fake.ttls[0])
+	}
+}
+
+func TestLockRequiresConditionalWrites(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	if _, err := client.TryLock("cron", time.Second); err == nil || errors.Is(err, ErrLockHeld) {
+		t.Errorf("TryLock without kv_conditional = %v, want an unsupported error", err)
+	}
+}
@@ -0,0 +1,79 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// KVEntry is a KV value with its metadata.
+type KVEntry struct {
+	Key   string
+	Value interface{}
+	// Version increases with every write to the key; use it to detect
+	// concurrent changes.
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// ExpiresAt is when the key expires, and TTL the time remaining until
+	// then; both are zero for a key without expiry.
+	ExpiresAt time.Time
+	TTL       time.Duration
+}
+
+// kvEntryWire is the server's form of a KVEntry.
+type kvEntryWire struct {
+	Key           string      `json:"key"`
+	Value         interface{} `json:"value"`
+	Version       int64       `json:"version"`
+	CreatedAt     *time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time  `json:"updated_at"`
+	ExpiresAt     *time.Time  `json:"expires_at"`
+	TTLRemainingS *float64    `json:"ttl_remaining_secs"`
+}
+
+// entry converts w, taking the remaining TTL from the server when given and
+// otherwise from ExpiresAt.
+func (w kvEntryWire) entry() KVEntry {
+	e := KVEntry{Key: w.Key, Value: w.Value, Version: w.Version}
+	if w.CreatedAt != nil {
+		e.CreatedAt = *w.CreatedAt
+	}
+	if w.UpdatedAt != nil {
+		e.UpdatedAt = *w.UpdatedAt
+	}
+	if w.ExpiresAt != nil {
+		e.ExpiresAt = *w.ExpiresAt
+	}
+	switch {
+	case w.TTLRemainingS != nil:
+		e.TTL = time.Duration(*w.TTLRemainingS * float64(time.Second))
+		if e.ExpiresAt.IsZero() {
+			e.ExpiresAt = time.Now().Add(e.TTL)
+		}
+	case !e.ExpiresAt.IsZero():
+		e.TTL = max(time.Until(e.ExpiresAt), 0)
+	}
+	return e
+}
+
+// KVGetWithMetadata gets a value by key along with its version, timestamps,
+// and remaining TTL. A missing key returns an *HTTPError with IsNotFound.
+func (c *Client) KVGetWithMetadata(key string, opts ...RequestOption) (*KVEntry, error) {
+	c = c.withOptions(opts)
+	path := fmt.Sprintf("/api/kv/get/%s?metadata=true", url.PathEscape(key))
+	respBody, err := c.makeRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var wire kvEntryWire
+	if err := json.Unmarshal(respBody, &wire); err != nil {
+		return nil, err
+	}
+	if wire.Key == "" {
+		wire.Key = key
+	}
+	entry := wire.entry()
+	return &entry, nil
+}
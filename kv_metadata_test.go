@@ -0,0 +1,54 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestKVGetWithMetadata(t *testing.T) {
+	var query string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/kv/get/session": func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"value":              map[string]interface{}{"user": "u1"},
+				"version":            7,
+				"created_at":         "2026-10-01T10:00:00Z",
+				"updated_at":         "2026-10-02T11:30:00Z",
+				"ttl_remaining_secs": 90.5,
+			})
+		},
+		"GET /api/kv/get/config": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"key": "config", "value": "x", "version": 1})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	entry, err := client.KVGetWithMetadata("session")
+	if err != nil {
+		t.Fatalf("KVGetWithMetadata failed: %v", err)
+	}
+	if query != "metadata=true" {
+		t.Errorf("query = %q", query)
+	}
+	if entry.Key != "session" || entry.Version != 7 || entry.Value.(map[string]interface{})["user"] != "u1" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if entry.UpdatedAt.Day() != 2 || entry.CreatedAt.Hour() != 10 {
+		t.Errorf("timestamps %v, %v", entry.CreatedAt, entry.UpdatedAt)
+	}
+	if entry.TTL != 90500*time.Millisecond || time.Until(entry.ExpiresAt) > 91*time.Second {
+		t.Errorf("TTL %v, expires %v", entry.TTL, entry.ExpiresAt)
+	}
+
+	entry, err = client.KVGetWithMetadata("config")
+	if err != nil {
+		t.Fatalf("KVGetWithMetadata failed: %v", err)
+	}
+	if entry.TTL != 0 || !entry.ExpiresAt.IsZero() {
+		t.Errorf("key without expiry has TTL %v, expires %v", entry.TTL, entry.ExpiresAt)
+	}
+}
@@ -0,0 +1,124 @@
+package ekodb
+
+import (
+	"context"
+	"iter"
+	"strings"
+)
+
+// KVNamespace is a view of the KV store under a key prefix. Keys passed to
+// its methods are prefixed and keys it returns are unprefixed, so code
+// written for one tenant cannot touch another's keys:
+//
+//	kv := client.KVNamespace("tenant:42:")
+//	err := kv.Set("settings", settings)         // sets "tenant:42:settings"
+//	keys, _, err := kv.ListKeys("", 100, "")    // keys without "tenant:42:"
+type KVNamespace struct {
+	client *Client
+	prefix string
+}
+
+// KVNamespace returns a handle on the keys starting with prefix.
+func (c *Client) KVNamespace(prefix string, opts ...RequestOption) *KVNamespace {
+	c = c.withOptions(opts)
+	return &KVNamespace{client: c, prefix: prefix}
+}
+
+// Namespace returns a handle on a nested namespace
+func (ns *KVNamespace) Namespace(prefix string) *KVNamespace {
+	return &KVNamespace{client: ns.client, prefix: ns.prefix + prefix}
+}
+
+// Prefix returns the namespace's key prefix
+func (ns *KVNamespace) Prefix() string {
+	return ns.prefix
+}
+
+// Key returns the full key of key in the namespace
+func (ns *KVNamespace) Key(key string) string {
+	return ns.prefix + key
+}
+
+// Set sets a key-value pair, like KVSet
+func (ns *KVNamespace) Set(key string, value interface{}, opts ...KVSetOptions) error {
+	return ns.client.KVSet(ns.Key(key), value, opts...)
+}
+
+// Get gets a value by key, like KVGet
+func (ns *KVNamespace) Get(key string) (interface{}, error) {
+	return ns.client.KVGet(ns.Key(key))
+}
+
+// GetWithMetadata gets a value and its metadata, like KVGetWithMetadata
+func (ns *KVNamespace) GetWithMetadata(key string) (*KVEntry, error) {
+	entry, err := ns.client.KVGetWithMetadata(ns.Key(key))
+	if err != nil {
+		return nil, err
+	}
+	entry.Key = strings.TrimPrefix(entry.Key, ns.prefix)
+	return entry, nil
+}
+
+// Delete deletes a key, like KVDelete
+func (ns *KVNamespace) Delete(key string) error {
+	return ns.client.KVDelete(ns.Key(key))
+}
+
+// Exists checks if a key exists, like KVExists
+func (ns *KVNamespace) Exists(key string) (bool, error) {
+	return ns.client.KVExists(ns.Key(key))
+}
+
+// Find finds entries whose unprefixed keys match pattern, like KVFind. The
+// returned entries are copies with unprefixed keys.
+func (ns *KVNamespace) Find(pattern string, includeExpired bool) ([]map[string]interface{}, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+	entries, err := ns.client.KVFind(ns.Key(pattern), includeExpired)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]map[string]interface{}, 0, len(entries))
+	for _, e := range entries {
+		key, _ := e["key"].(string)
+		if !strings.HasPrefix(key, ns.prefix) {
+			continue
+		}
+		entry := make(map[string]interface{}, len(e))
+		for k, v := range e {
+			entry[k] = v
+		}
+		entry["key"] = strings.TrimPrefix(key, ns.prefix)
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+// ListKeys lists unprefixed keys starting with prefix, like KVListKeys
+func (ns *KVNamespace) ListKeys(prefix string, limit int, cursor string) ([]string, string, error) {
+	keys, next, err := ns.client.KVListKeys(ns.Key(prefix), limit, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	for i, k := range keys {
+		keys[i] = strings.TrimPrefix(k, ns.prefix)
+	}
+	return keys, next, nil
+}
+
+// Scan iterates over the entries whose unprefixed keys match pattern, like
+// KVScan
+func (ns *KVNamespace) Scan(ctx context.Context, pattern string) iter.Seq2[KVEntry, error] {
+	if pattern == "" {
+		pattern = "*"
+	}
+	return func(yield func(KVEntry, error) bool) {
+		for entry, err := range ns.client.KVScan(ctx, ns.Key(pattern)) {
+			entry.Key = strings.TrimPrefix(entry.Key, ns.prefix)
+			if !yield(entry, err) {
+				return
+			}
+		}
+	}
+}
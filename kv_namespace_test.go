@@ -0,0 +1,57 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestKVNamespace(t *testing.T) {
+	var setPath, findPattern string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/kv/set/*": func(w http.ResponseWriter, r *http.Request) {
+			setPath = r.URL.Path
+			_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+		},
+		"GET /api/kv/get/tenant:42:settings": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"value": "dark"})
+		},
+		"POST /api/kv/find": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			findPattern, _ = body["pattern"].(string)
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"key": "tenant:42:user:1", "value": 1},
+				{"key": "tenant:420:user:1", "value": 2},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+	kv := client.KVNamespace("tenant:42:")
+
+	if err := kv.Set("settings", "dark"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if setPath != "/api/kv/set/tenant:42:settings" {
+		t.Errorf("set path = %q", setPath)
+	}
+	if v, err := kv.Get("settings"); err != nil || v != "dark" {
+		t.Errorf("Get = %v, %v", v, err)
+	}
+
+	entries, err := kv.Find("user:*", false)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if findPattern != "tenant:42:user:*" {
+		t.Errorf("pattern = %q", findPattern)
+	}
+	if len(entries) != 1 || entries[0]["key"] != "user:1" {
+		t.Errorf("entries = %v", entries)
+	}
+
+	if got := kv.Namespace("cache:").Key("x"); got != "tenant:42:cache:x" {
+		t.Errorf("nested key = %q", got)
+	}
+}
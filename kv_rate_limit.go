@@ -0,0 +1,120 @@
+package ekodb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// KVRateLimiter is a fixed-window counter kept in the KV store, for
+// throttling shared by every client of the database:
+//
+//	limiter := client.KVRateLimiter("api:user:"+userID, 100, time.Minute)
+//	result, err := limiter.Allow(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	if !result.Allowed {
+//	    return errTooManyRequests
+//	}
+//
+// Each Allow calls a saved function running the server's RateLimit stage,
+// which increments the window's counter atomically and expires it with the
+// window. The function's label is derived from the key, limit, and window
+// (see FunctionLabel), so every limiter with the same settings shares it; it
+// is saved by the first Allow that finds it missing. A KVRateLimiter holds no
+// state of its own, so one can be made per request.
+type KVRateLimiter struct {
+	client *Client
+	key    string
+	limit  int
+	window time.Duration
+}
+
+// RateLimitResult is the outcome of KVRateLimiter.Allow.
+type RateLimitResult struct {
+	Allowed bool
+	// Count is the number of hits in the current window, including this one.
+	Count int
+	Limit int
+}
+
+// Remaining returns how many more hits the current window allows
+func (r RateLimitResult) Remaining() int {
+	return max(r.Limit-r.Count, 0)
+}
+
+// KVRateLimiter returns a limiter allowing limit hits on key per window. The
+// window is counted in whole seconds, rounded up.
+func (c *Client) KVRateLimiter(key string, limit int, window time.Duration, opts ...RequestOption) *KVRateLimiter {
+	c = c.withOptions(opts)
+	return &KVRateLimiter{client: c, key: key, limit: limit, window: window}
+}
+
+// FunctionLabel returns the label of the saved function Allow calls. Delete
+// it with DeleteFunction once the limiter is retired.
+func (l *KVRateLimiter) FunctionLabel() string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s\x00%d\x00%d", l.key, l.limit, kvTTLSeconds(l.window)))
+	return "rate_limit_" + hex.EncodeToString(sum[:8])
+}
+
+// Allow records a hit and reports whether it is within the limit. Hits over
+// the limit still count toward the window.
+func (l *KVRateLimiter) Allow(ctx context.Context) (RateLimitResult, error) {
+	if l.key == "" || l.limit <= 0 || l.window <= 0 {
+		return RateLimitResult{}, fmt.Errorf("rate limiter needs a key, a positive limit, and a positive window")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	client := l.client.WithContext(ctx)
+	label := l.FunctionLabel()
+	result, err := client.CallFunction(label, nil)
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.IsNotFound() {
+		if err := l.saveFunction(client, label); err != nil {
+			return RateLimitResult{}, fmt.Errorf("rate limit %s: %w", l.key, err)
+		}
+		result, err = client.CallFunction(label, nil)
+	}
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("rate limit %s: %w", l.key, err)
+	}
+	if len(result.Records) == 0 {
+		return RateLimitResult{}, fmt.Errorf("rate limit %s: no result", l.key)
+	}
+	out, ok := GetValue(result.Records[0]["rate_limit"]).(map[string]interface{})
+	if !ok {
+		return RateLimitResult{}, fmt.Errorf("rate limit %s: unexpected result: %v", l.key, result.Records[0])
+	}
+	allowed, _ := GetValue(out["allowed"]).(bool)
+	count, _ := GetIntValue(out["count"])
+	limit, ok := GetIntValue(out["limit"])
+	if !ok {
+		limit = l.limit
+	}
+	return RateLimitResult{Allowed: allowed, Count: count, Limit: limit}, nil
+}
+
+// saveFunction saves the function Allow calls under label. A conflict means
+// another limiter with the same settings saved it first.
+func (l *KVRateLimiter) saveFunction(client *Client, label string) error {
+	stage := StageRateLimit(l.key, uint64(l.limit), uint64(kvTTLSeconds(l.window)), "rate_limit", "skip")
+	_, err := client.SaveFunction(UserFunction{
+		Label:      label,
+		Name:       "rate limit " + l.key,
+		Parameters: map[string]ParameterDefinition{},
+		Functions:  []FunctionStageConfig{stage},
+	})
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.IsConflict() {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save rate limit function %q: %w", label, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKVRateLimiter(t *testing.T) {
+	var saved, savedLabel string
+	saves, hits := 0, 0
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/functions": func(w http.ResponseWriter, r *http.Request) {
+			saves++
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			raw, _ := json.Marshal(body["functions"])
+			saved = string(raw)
+			savedLabel, _ = body["label"].(string)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok", "id": "fn_1"})
+		},
+		"POST /api/functions/*": func(w http.ResponseWriter, r *http.Request) {
+			if savedLabel == "" || r.URL.Path != "/api/functions/"+savedLabel {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("function not found"))
+				return
+			}
+			hits++
+			out := map[string]interface{}{"allowed": hits <= 2, "count": hits, "limit": 2}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"records": []interface{}{map[string]interface{}{"rate_limit": out}},
+				"stats":   map[string]interface{}{},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	limiter := client.KVRateLimiter("api:user:7", 2, 1500*time.Millisecond)
+	for i := 1; i <= 3; i++ {
+		result, err := limiter.Allow(t.Context())
+		if err != nil {
+			t.Fatalf("Allow failed: %v", err)
+		}
+		if result.Allowed != (i <= 2) || result.Count != i || result.Limit != 2 {
+			t.Errorf("hit %d: %+v", i, result)
+		}
+		if i == 3 && result.Remaining() != 0 {
+			t.Errorf("Remaining = %d", result.Remaining())
+		}
+	}
+	if saves != 1 {
+		t.Errorf("expected the function to be saved once, got %d saves", saves)
+	}
+	if _, err := client.KVRateLimiter("api:user:7", 2, 1500*time.Millisecond).Allow(t.Context()); err != nil || saves != 1 {
+		t.Errorf("a limiter with the same settings must reuse the function: err=%v, saves=%d", err, saves)
+	}
+	if label := limiter.FunctionLabel(); label != savedLabel || client.KVRateLimiter("api:user:8", 2, time.Second).FunctionLabel() == label {
+		t.Errorf("unexpected function label %q", label)
+	}
+	for _, want := range []string{`"RateLimit"`, `"key":"api:user:7"`, `"window_secs":2`, `"on_exceed":"skip"`} {
+		if !strings.Contains(saved, want) {
+			t.Errorf("saved function %s is missing %s", saved, want)
+		}
+	}
+
+	if _, err := client.KVRateLimiter("k", 0, time.Second).Allow(t.Context()); err == nil {
+		t.Error("expected an error for a zero limit")
+	}
+}
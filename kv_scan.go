@@ -0,0 +1,101 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+)
+
+// kvScanFeature is the ServerInfo feature flag for the paged scan endpoint.
+const kvScanFeature = "kv_scan"
+
+// kvScanPageSize is the number of entries KVScan fetches per request.
+const kvScanPageSize = 500
+
+// KVScan returns an iterator over the entries whose keys match pattern
+// (with '*' wildcards, as for KVFind), fetched a page at a time so scripts
+// can walk millions of keys in flat memory:
+//
+//	for entry, err := range client.KVScan(ctx, "session:*") {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    if entry.TTL == 0 {
+//	        stale = append(stale, entry.Key)
+//	    }
+//	}
+//
+// A failed request or a done ctx ends the iteration with one final non-nil
+// error. On servers that do not advertise "kv_scan", the entries come from a
+// single KVFind, so memory use grows with the result.
+func (c *Client) KVScan(ctx context.Context, pattern string, opts ...RequestOption) iter.Seq2[KVEntry, error] {
+	c = c.withOptions(opts)
+	return func(yield func(KVEntry, error) bool) {
+		c := c.WithContext(ctx)
+		if !c.serverFeature(kvScanFeature) {
+			c.kvScanByFind(pattern, yield)
+			return
+		}
+
+		cursor := ""
+		for {
+			body := map[string]interface{}{"limit": kvScanPageSize}
+			if pattern != "" {
+				body["pattern"] = pattern
+			}
+			if cursor != "" {
+				body["cursor"] = cursor
+			}
+			respBody, err := c.makeRequest("POST", "/api/kv/scan", body)
+			if err != nil {
+				yield(KVEntry{}, err)
+				return
+			}
+			var page struct {
+				Entries    []kvEntryWire `json:"entries"`
+				NextCursor string        `json:"next_cursor"`
+			}
+			if err := json.Unmarshal(respBody, &page); err != nil {
+				yield(KVEntry{}, err)
+				return
+			}
+			for _, w := range page.Entries {
+				if !yield(w.entry(), nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			if err := ctx.Err(); err != nil {
+				yield(KVEntry{}, err)
+				return
+			}
+			cursor = page.NextCursor
+		}
+	}
+}
+
+// kvScanByFind yields the entries of a single KVFind.
+func (c *Client) kvScanByFind(pattern string, yield func(KVEntry, error) bool) {
+	entries, err := c.KVFind(pattern, false)
+	if err != nil {
+		yield(KVEntry{}, err)
+		return
+	}
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			yield(KVEntry{}, err)
+			return
+		}
+		var w kvEntryWire
+		if err := json.Unmarshal(data, &w); err != nil {
+			yield(KVEntry{}, err)
+			return
+		}
+		if !yield(w.entry(), nil) {
+			return
+		}
+	}
+}
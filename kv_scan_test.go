@@ -0,0 +1,79 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestKVScan(t *testing.T) {
+	var cursors []interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"kv_scan"}})
+		},
+		"POST /api/kv/scan": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			cursors = append(cursors, body["cursor"])
+			if body["cursor"] == nil {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"entries":     []map[string]interface{}{{"key": "s:1", "value": 1}, {"key": "s:2", "value": 2}},
+					"next_cursor": "p2",
+				})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"entries": []map[string]interface{}{{"key": "s:3", "value": 3, "ttl_remaining_secs": 10}},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	var keys []string
+	for entry, err := range client.KVScan(context.Background(), "s:*") {
+		if err != nil {
+			t.Fatalf("KVScan failed: %v", err)
+		}
+		keys = append(keys, entry.Key)
+	}
+	if fmt.Sprint(keys) != "[s:1 s:2 s:3]" || fmt.Sprint(cursors) != "[<nil> p2]" {
+		t.Errorf("keys %v, cursors %v", keys, cursors)
+	}
+
+	// Stopping early makes no further requests.
+	cursors = nil
+	for range client.KVScan(context.Background(), "s:*") {
+		break
+	}
+	if len(cursors) != 1 {
+		t.Errorf("requests after break: %v", cursors)
+	}
+}
+
+func TestKVScanFallback(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0"})
+		},
+		"POST /api/kv/find": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"key": "s:1", "value": "a"}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	var entries []KVEntry
+	for entry, err := range client.KVScan(context.Background(), "s:*") {
+		if err != nil {
+			t.Fatalf("KVScan failed: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 1 || entries[0].Key != "s:1" || entries[0].Value != "a" {
+		t.Errorf("entries = %+v", entries)
+	}
+}
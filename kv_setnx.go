@@ -0,0 +1,35 @@
+package ekodb
+
+import (
+	"errors"
+)
+
+// KVSetNX sets key to value only if the key does not exist (or has expired),
+// and reports whether the write happened. It suits idempotency tokens and
+// once-only initialization:
+//
+//	ok, err := client.KVSetNX("idem:"+requestID, "pending", ekodb.KVSetOptions{TTL: time.Hour})
+//	if err == nil && !ok {
+//	    return errDuplicateRequest
+//	}
+//
+// The check and the write are one atomic server operation, which needs a
+// server that advertises the "kv_conditional" feature.
+func (c *Client) KVSetNX(key string, value interface{}, opts ...KVSetOptions) (bool, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	ttl, err := kvSetTTL("KVSetNX", key, opts)
+	if err != nil {
+		return false, err
+	}
+	_, err = c.kvSetConditional(key, value, ttl, map[string]interface{}{"if_not_exists": true})
+	if err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.IsConflict() {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,55 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestKVSetNX(t *testing.T) {
+	stored := map[string]interface{}{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"kv_conditional"}})
+		},
+		"POST /api/kv/set/idem:1": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["if_not_exists"] != true {
+				t.Errorf("if_not_exists not sent: %v", body)
+			}
+			if _, exists := stored["idem:1"]; exists {
+				w.WriteHeader(http.StatusConflict)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "key exists"})
+				return
+			}
+			stored["idem:1"] = body
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": 1})
+		},
+		"POST /api/kv/set/broken": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "boom"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	ok, err := client.KVSetNX("idem:1", "pending", KVSetOptions{TTL: time.Hour})
+	if err != nil || !ok {
+		t.Fatalf("first KVSetNX = %v, %v", ok, err)
+	}
+	if body := stored["idem:1"].(map[string]interface{}); body["value"] != "pending" || body["ttl"] != float64(3600) {
+		t.Errorf("stored %v", body)
+	}
+	ok, err = client.KVSetNX("idem:1", "again")
+	if err != nil || ok {
+		t.Errorf("second KVSetNX = %v, %v", ok, err)
+	}
+	if _, err := client.KVSetNX("broken", 1); err == nil {
+		t.Error("expected a server error to be returned")
+	}
+	if _, err := client.KVSetNX("idem:2", 1, KVSetOptions{TTL: -time.Second}); err == nil {
+		t.Error("expected an error for a negative TTL")
+	}
+}
@@ -0,0 +1,74 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// kvExpireFeature is the ServerInfo feature flag for the expire, persist, and
+// ttl endpoints.
+const kvExpireFeature = "kv_expire"
+
+// KVExpire sets a new TTL on an existing key without changing its value. On
+// servers that do not advertise "kv_expire" the value is read and written
+// back with the new TTL, which can lose a concurrent write to the key.
+func (c *Client) KVExpire(key string, ttl time.Duration, opts ...RequestOption) error {
+	c = c.withOptions(opts)
+	if ttl <= 0 {
+		return fmt.Errorf("KVExpire %s: TTL must be positive, got %v", key, ttl)
+	}
+	if !c.serverFeature(kvExpireFeature) {
+		value, err := c.KVGet(key)
+		if err != nil {
+			return err
+		}
+		return c.KVSet(key, value, KVSetOptions{TTL: ttl})
+	}
+	data := map[string]interface{}{"ttl": kvTTLSeconds(ttl)}
+	_, err := c.makeRequest("POST", "/api/kv/expire/"+url.PathEscape(key), data)
+	return err
+}
+
+// KVPersist removes a key's expiry, keeping its value. On servers that do not
+// advertise "kv_expire" the value is read and written back without a TTL.
+func (c *Client) KVPersist(key string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
+	if !c.serverFeature(kvExpireFeature) {
+		value, err := c.KVGet(key)
+		if err != nil {
+			return err
+		}
+		return c.KVSet(key, value)
+	}
+	_, err := c.makeRequest("POST", "/api/kv/persist/"+url.PathEscape(key), nil)
+	return err
+}
+
+// KVTTL returns the time left before key expires, and false if the key has no
+// expiry. A missing key returns an *HTTPError with IsNotFound.
+func (c *Client) KVTTL(key string, opts ...RequestOption) (time.Duration, bool, error) {
+	c = c.withOptions(opts)
+	if !c.serverFeature(kvExpireFeature) {
+		entry, err := c.KVGetWithMetadata(key)
+		if err != nil {
+			return 0, false, err
+		}
+		return entry.TTL, !entry.ExpiresAt.IsZero(), nil
+	}
+	respBody, err := c.makeRequest("GET", "/api/kv/ttl/"+url.PathEscape(key), nil)
+	if err != nil {
+		return 0, false, err
+	}
+	var result struct {
+		TTLRemainingS *float64 `json:"ttl_remaining_secs"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return 0, false, err
+	}
+	if result.TTLRemainingS == nil {
+		return 0, false, nil
+	}
+	return time.Duration(*result.TTLRemainingS * float64(time.Second)), true, nil
+}
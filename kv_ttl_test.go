@@ -0,0 +1,90 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestKVTTLManagement(t *testing.T) {
+	var expireTTL interface{}
+	var persisted bool
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"kv_expire"}})
+		},
+		"POST /api/kv/expire/session": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			expireTTL = body["ttl"]
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+		"POST /api/kv/persist/session": func(w http.ResponseWriter, r *http.Request) {
+			persisted = true
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+		"GET /api/kv/ttl/session": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ttl_remaining_secs": 42.5})
+		},
+		"GET /api/kv/ttl/config": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ttl_remaining_secs": nil})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	if err := client.KVExpire("session", 90*time.Second+time.Millisecond); err != nil {
+		t.Fatalf("KVExpire failed: %v", err)
+	}
+	if expireTTL != float64(91) {
+		t.Errorf("ttl sent = %v, want 91", expireTTL)
+	}
+	if err := client.KVExpire("session", 0); err == nil {
+		t.Error("expected an error for a zero TTL")
+	}
+	if err := client.KVPersist("session"); err != nil || !persisted {
+		t.Fatalf("KVPersist failed: %v", err)
+	}
+
+	ttl, ok, err := client.KVTTL("session")
+	if err != nil || !ok || ttl != 42500*time.Millisecond {
+		t.Errorf("KVTTL(session) = %v, %v, %v", ttl, ok, err)
+	}
+	ttl, ok, err = client.KVTTL("config")
+	if err != nil || ok || ttl != 0 {
+		t.Errorf("KVTTL(config) = %v, %v, %v", ttl, ok, err)
+	}
+}
+
+func TestKVExpireFallback(t *testing.T) {
+	var setBody map[string]interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0"})
+		},
+		"GET /api/kv/get/session": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"value": "abc"})
+		},
+		"POST /api/kv/set/session": func(w http.ResponseWriter, r *http.Request) {
+			setBody = nil
+			_ = json.NewDecoder(r.Body).Decode(&setBody)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	if err := client.KVExpire("session", time.Minute); err != nil {
+		t.Fatalf("KVExpire failed: %v", err)
+	}
+	if setBody["value"] != "abc" || setBody["ttl"] != float64(60) {
+		t.Errorf("set body = %v", setBody)
+	}
+	if err := client.KVPersist("session"); err != nil {
+		t.Fatalf("KVPersist failed: %v", err)
+	}
+	if _, ok := setBody["ttl"]; ok || setBody["value"] != "abc" {
+		t.Errorf("persist set body = %v", setBody)
+	}
+}
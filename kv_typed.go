@@ -0,0 +1,66 @@
+package ekodb
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// KVSetTyped stores v under key, converting structs (and slices and maps of
+// them) through the struct codec as MarshalRecord does, so the value can be
+// read back with KVGetInto:
+//
+//	err := client.KVSetTyped("config:app", AppConfig{Workers: 8})
+func (c *Client) KVSetTyped(key string, v interface{}, opts ...KVSetOptions) error {
+	value, err := marshalValue(reflect.ValueOf(v))
+	if err != nil {
+		return fmt.Errorf("KVSetTyped %s: %w", key, err)
+	}
+	return c.KVSet(key, value, opts...)
+}
+
+// KVGetInto reads key into dst, a non-nil pointer, converting the value as
+// UnmarshalRecord does for a field of dst's type:
+//
+//	var cfg AppConfig
+//	err := client.KVGetInto("config:app", &cfg)
+//
+// A missing key returns an *HTTPError with IsNotFound.
+func (c *Client) KVGetInto(key string, dst interface{}, opts ...RequestOption) error {
+	c = c.withOptions(opts)
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("KVGetInto: expected a non-nil pointer, got %T", dst)
+	}
+	value, err := c.KVGet(key)
+	if err != nil {
+		return err
+	}
+	if err := unmarshalValue(rv.Elem(), value); err != nil {
+		return fmt.Errorf("KVGetInto %s: %w", key, err)
+	}
+	return nil
+}
+
+// KVGetString reads key as a string
+func (c *Client) KVGetString(key string, opts ...RequestOption) (string, error) {
+	c = c.withOptions(opts)
+	var s string
+	err := c.KVGetInto(key, &s)
+	return s, err
+}
+
+// KVGetInt reads key as an integer; fractional numbers are an error
+func (c *Client) KVGetInt(key string, opts ...RequestOption) (int64, error) {
+	c = c.withOptions(opts)
+	var n int64
+	err := c.KVGetInto(key, &n)
+	return n, err
+}
+
+// KVGetBool reads key as a boolean
+func (c *Client) KVGetBool(key string, opts ...RequestOption) (bool, error) {
+	c = c.withOptions(opts)
+	var b bool
+	err := c.KVGetInto(key, &b)
+	return b, err
+}
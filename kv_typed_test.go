@@ -0,0 +1,77 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKVTypedAccessors(t *testing.T) {
+	type appConfig struct {
+		Workers int           `json:"workers"`
+		Timeout time.Duration `ekodb:"timeout,duration"`
+		Tags    []string      `json:"tags"`
+	}
+
+	stored := map[string]interface{}{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/kv/set/*": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			stored[strings.TrimPrefix(r.URL.Path, "/api/kv/set/")] = body["value"]
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+		"GET /api/kv/get/*": func(w http.ResponseWriter, r *http.Request) {
+			value, ok := stored[strings.TrimPrefix(r.URL.Path, "/api/kv/get/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"value": value})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	want := appConfig{Workers: 8, Timeout: 1500 * time.Millisecond, Tags: []string{"a", "b"}}
+	if err := client.KVSetTyped("config", &want); err != nil {
+		t.Fatalf("KVSetTyped failed: %v", err)
+	}
+	var got appConfig
+	if err := client.KVGetInto("config", &got); err != nil {
+		t.Fatalf("KVGetInto failed: %v", err)
+	}
+	if got.Workers != want.Workers || got.Timeout != want.Timeout || strings.Join(got.Tags, ",") != "a,b" {
+		t.Errorf("round trip = %+v", got)
+	}
+
+	stored["name"], stored["count"], stored["on"], stored["frac"] = "ekodb", 42.0, true, 1.5
+	if s, err := client.KVGetString("name"); err != nil || s != "ekodb" {
+		t.Errorf("KVGetString = %q, %v", s, err)
+	}
+	if n, err := client.KVGetInt("count"); err != nil || n != 42 {
+		t.Errorf("KVGetInt = %d, %v", n, err)
+	}
+	if b, err := client.KVGetBool("on"); err != nil || !b {
+		t.Errorf("KVGetBool = %v, %v", b, err)
+	}
+	if _, err := client.KVGetInt("frac"); err == nil {
+		t.Error("expected an error reading a fractional number as an int")
+	}
+	if _, err := client.KVGetBool("name"); err == nil {
+		t.Error("expected an error reading a string as a bool")
+	}
+	if err := client.KVGetInto("config", got); err == nil {
+		t.Error("expected an error for a non-pointer destination")
+	}
+
+	_, err := client.KVGetString("missing")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || !httpErr.IsNotFound() {
+		t.Errorf("missing key error = %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// KVChangeEvent describes a change to a watched KV key.
+type KVChangeEvent struct {
+	Key       string      `json:"key"`
+	Op        string      `json:"op"` // "set" or "delete"
+	Value     interface{} `json:"value,omitempty"`
+	Version   int64       `json:"version,omitempty"`
+	Timestamp string      `json:"timestamp,omitempty"`
+}
+
+// IsDelete reports whether the event is a deletion (including expiry).
+func (e KVChangeEvent) IsDelete() bool {
+	return e.Op == "delete"
+}
+
+// KVWatch streams changes to a KV key, or to every key under a prefix when
+// keyOrPrefix ends in "*", so services can hot-reload configuration kept in
+// ekoDB KV:
+//
+//	events, err := ws.KVWatch(ctx, "config:*")
+//	...
+//	for ev := range events {
+//	    if ev.IsDelete() {
+//	        cfg.Remove(ev.Key)
+//	    } else {
+//	        cfg.Apply(ev.Key, ev.Value)
+//	    }
+//	}
+//
+// The channel is closed when ctx is done, the watch is removed with
+// KVUnwatch, or the client is closed. Like Subscribe, the watch is replayed
+// after an automatic reconnect, and events are dropped if the consumer falls
+// more than 64 behind. Only one watch per keyOrPrefix may be active at a time.
+func (ws *WebSocketClient) KVWatch(ctx context.Context, keyOrPrefix string) (<-chan KVChangeEvent, error) {
+	if keyOrPrefix == "" {
+		return nil, fmt.Errorf("kv watch requires a key or prefix")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ch := make(chan KVChangeEvent, 64)
+	ws.mu.Lock()
+	if _, exists := ws.kvWatches[keyOrPrefix]; exists {
+		ws.mu.Unlock()
+		return nil, fmt.Errorf("already watching kv key %q", keyOrPrefix)
+	}
+	if ws.kvWatches == nil {
+		ws.kvWatches = make(map[string]chan KVChangeEvent)
+	}
+	ws.kvWatches[keyOrPrefix] = ch
+	ws.mu.Unlock()
+
+	messageID := ws.genMessageID()
+	request := map[string]interface{}{
+		"type":      "KVSubscribe",
+		"messageId": messageID,
+		"payload":   map[string]interface{}{"key": keyOrPrefix},
+	}
+	if _, err := ws.sendRequest(request, messageID); err != nil {
+		ws.mu.Lock()
+		if ws.kvWatches[keyOrPrefix] == ch {
+			delete(ws.kvWatches, keyOrPrefix)
+		}
+		ws.mu.Unlock()
+		return nil, err
+	}
+
+	if ctx.Done() != nil {
+		go func() {
+			<-ctx.Done()
+			ws.unwatch(keyOrPrefix, ch)
+		}()
+	}
+	return ch, nil
+}
+
+// KVUnwatch stops a watch started by KVWatch and closes its channel. Like
+// Unsubscribe, it sends a best-effort frame telling the server to stop, and is
+// a no-op if keyOrPrefix is not being watched.
+func (ws *WebSocketClient) KVUnwatch(keyOrPrefix string) {
+	ws.unwatch(keyOrPrefix, nil)
+}
+
+// unwatch removes the watch for pattern, provided it is still ch (any channel
+// when ch is nil), so a ctx firing after a re-watch can't remove the new one.
+func (ws *WebSocketClient) unwatch(pattern string, ch chan KVChangeEvent) {
+	ws.mu.Lock()
+	current, ok := ws.kvWatches[pattern]
+	if !ok || (ch != nil && current != ch) {
+		ws.mu.Unlock()
+		return
+	}
+	delete(ws.kvWatches, pattern)
+	ws.mu.Unlock()
+	close(current)
+
+	_ = ws.writeJSON(map[string]interface{}{
+		"type":      "KVUnsubscribe",
+		"messageId": ws.genMessageID(),
+		"payload":   map[string]interface{}{"key": pattern},
+	})
+}
+
+// kvWatchMatches reports whether key falls under a KVWatch pattern.
+func kvWatchMatches(pattern, key string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(key, prefix)
+	}
+	return key == pattern
+}
+
+func (ws *WebSocketClient) routeKVChange(msg map[string]json.RawMessage) {
+	payloadRaw, ok := msg["payload"]
+	if !ok {
+		return
+	}
+
+	var event KVChangeEvent
+	if err := json.Unmarshal(payloadRaw, &event); err != nil {
+		return
+	}
+
+	// As in routeMutationNotification, the lock is held across the
+	// non-blocking sends so a delivery never races a close.
+	ws.mu.Lock()
+	for pattern, ch := range ws.kvWatches {
+		if !kvWatchMatches(pattern, event.Key) {
+			continue
+		}
+		select {
+		case ch <- event:
+		default:
+			// Drop if the consumer is not keeping up.
+		}
+	}
+	ws.mu.Unlock()
+}
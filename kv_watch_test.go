@@ -0,0 +1,114 @@
+package ekodb
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestKVWatch(t *testing.T) {
+	wsURL, connCh, server := setupTestWSServer(t)
+	defer server.Close()
+
+	client := &Client{token: "test-token"}
+	ws, err := client.WebSocket(wsURL)
+	if err != nil {
+		t.Fatalf("failed to create WebSocket client: %v", err)
+	}
+	defer ws.Close()
+
+	serverConn := <-connCh
+	defer serverConn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchCh := make(chan (<-chan KVChangeEvent), 1)
+	watchErr := make(chan error, 1)
+	go func() {
+		ch, err := ws.KVWatch(ctx, "config:*")
+		if err != nil {
+			watchErr <- err
+			return
+		}
+		watchCh <- ch
+	}()
+
+	msg := readMessage(t, serverConn)
+	if msg["type"] != "KVSubscribe" {
+		t.Fatalf("expected KVSubscribe, got %v", msg["type"])
+	}
+	if key := msg["payload"].(map[string]interface{})["key"]; key != "config:*" {
+		t.Fatalf("expected key config:*, got %v", key)
+	}
+	mustWriteJSON(t, serverConn, map[string]interface{}{
+		"type":    "Success",
+		"payload": map[string]interface{}{"message_id": msg["messageId"]},
+	})
+
+	var events <-chan KVChangeEvent
+	select {
+	case events = <-watchCh:
+	case err := <-watchErr:
+		t.Fatalf("KVWatch failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for KVWatch")
+	}
+
+	for _, payload := range []map[string]interface{}{
+		{"key": "other", "op": "set", "value": 1, "version": 1},
+		{"key": "config:flags", "op": "set", "value": map[string]interface{}{"beta": true}, "version": 3},
+		{"key": "config:flags", "op": "delete", "version": 4},
+	} {
+		mustWriteJSON(t, serverConn, map[string]interface{}{"type": "KVChange", "payload": payload})
+	}
+
+	var got []KVChangeEvent
+	for len(got) < 2 {
+		select {
+		case ev := <-events:
+			got = append(got, ev)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for events, got %v", got)
+		}
+	}
+	if got[0].Key != "config:flags" || got[0].Op != "set" || got[0].Version != 3 {
+		t.Errorf("first event = %+v", got[0])
+	}
+	if v, ok := got[0].Value.(map[string]interface{}); !ok || v["beta"] != true {
+		t.Errorf("first event value = %v", got[0].Value)
+	}
+	if !got[1].IsDelete() || got[1].Version != 4 {
+		t.Errorf("second event = %+v", got[1])
+	}
+
+	cancel()
+	if msg := readMessage(t, serverConn); msg["type"] != "KVUnsubscribe" {
+		t.Fatalf("expected KVUnsubscribe, got %v", msg["type"])
+	}
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("expected the channel to be closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel not closed after ctx was cancelled")
+	}
+}
+
+func TestKVWatchMatches(t *testing.T) {
+	cases := []struct {
+		pattern, key string
+		want         bool
+	}{
+		{"config:db", "config:db", true},
+		{"config:db", "config:dbx", false},
+		{"config:*", "config:db", true},
+		{"config:*", "cache:db", false},
+		{"*", "anything", true},
+	}
+	for _, c := range cases {
+		if got := kvWatchMatches(c.pattern, c.key); got != c.want {
+			t.Errorf("kvWatchMatches(%q, %q) = %v", c.pattern, c.key, got)
+		}
+	}
+}
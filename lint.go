@@ -0,0 +1,275 @@
+package ekodb
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// LintWarning describes a likely mistake in a query found by LintQuery.
+type LintWarning struct {
+	Code    string // Stable identifier, e.g. "missing_limit"
+	Field   string // Field the warning concerns ("" for query-wide warnings)
+	Message string // Human-readable explanation with a suggested fix
+}
+
+func (w LintWarning) String() string {
+	if w.Field != "" {
+		return fmt.Sprintf("%s (%s): %s", w.Code, w.Field, w.Message)
+	}
+	return fmt.Sprintf("%s: %s", w.Code, w.Message)
+}
+
+// Lint warning codes returned by LintQuery.
+const (
+	LintMissingLimit        = "missing_limit"
+	LintUnindexedPattern    = "unindexed_pattern"
+	LintContradictoryFilter = "contradictory_filter"
+	LintEmptyIn             = "empty_in"
+	LintUnknownField        = "unknown_field"
+)
+
+// LintQuery inspects a find query (as produced by QueryBuilder.Build()) for
+// common mistakes before it is sent, returning actionable warnings. It never
+// modifies the query, and an empty result means nothing was flagged.
+//
+// Checks that need field metadata — pattern matches on unindexed fields and
+// filters or sorts on fields the collection does not define — run only when
+// schema is non-nil:
+//
+//	schema, _ := client.GetSchema("users")
+//	for _, w := range LintQuery(query, schema) {
+//	    log.Println(w)
+//	}
+func LintQuery(query map[string]interface{}, schema *Schema) []LintWarning {
+	var warnings []LintWarning
+
+	if _, ok := query["limit"]; !ok {
+		warnings = append(warnings, LintWarning{
+			Code:    LintMissingLimit,
+			Message: "query has no limit and will return every matching record; add Limit(n) or paginate",
+		})
+	}
+
+	if filter, ok := query["filter"]; ok {
+		warnings = append(warnings, lintExpression(filter, schema)...)
+	}
+
+	for _, field := range lintSortFields(query["sort"]) {
+		if schema != nil && !lintSchemaHasField(schema, field) {
+			warnings = append(warnings, LintWarning{
+				Code:    LintUnknownField,
+				Field:   field,
+				Message: "sort field is not defined in the collection schema; results will not be ordered by it",
+			})
+		}
+	}
+
+	return warnings
+}
+
+// lintExpression walks a filter expression. Conditions that are ANDed together
+// at the same level are also checked against each other for contradictions.
+func lintExpression(expr interface{}, schema *Schema) []LintWarning {
+	node, ok := lintMap(expr)
+	if !ok {
+		return nil
+	}
+	content, _ := lintMap(node["content"])
+
+	switch node["type"] {
+	case "Condition":
+		return lintCondition(content, schema)
+	case "Logical":
+		var warnings []LintWarning
+		children := lintSlice(content["expressions"])
+		var siblings []map[string]interface{}
+		for _, child := range children {
+			warnings = append(warnings, lintExpression(child, schema)...)
+			if m, ok := lintMap(child); ok && m["type"] == "Condition" {
+				if cond, ok := lintMap(m["content"]); ok {
+					siblings = append(siblings, cond)
+				}
+			}
+		}
+		if content["operator"] == "And" {
+			warnings = append(warnings, lintContradictions(siblings)...)
+		}
+		return warnings
+	}
+	return nil
+}
+
+func lintCondition(cond map[string]interface{}, schema *Schema) []LintWarning {
+	field, _ := cond["field"].(string)
+	op, _ := cond["operator"].(string)
+	var warnings []LintWarning
+
+	if (op == "In" || op == "NotIn" || op == "ContainsAny" || op == "ContainsAll") && len(lintSlice(cond["value"])) == 0 {
+		msg := op + " with an empty list never matches; skip the query instead"
+		if op == "NotIn" || op == "ContainsAll" {
+			msg = op + " with an empty list matches everything; drop the condition"
+		}
+		warnings = append(warnings, LintWarning{Code: LintEmptyIn, Field: field, Message: msg})
+	}
+
+	if schema == nil || field == "" {
+		return warnings
+	}
+	if !lintSchemaHasField(schema, field) {
+		warnings = append(warnings, LintWarning{
+			Code:    LintUnknownField,
+			Field:   field,
+			Message: "filter field is not defined in the collection schema; check the spelling",
+		})
+		return warnings
+	}
+	switch op {
+	case "Regex", "Contains", "EndsWith":
+		if fs, ok := schema.Fields[field]; ok && fs.Index == nil {
+			warnings = append(warnings, LintWarning{
+				Code:    LintUnindexedPattern,
+				Field:   field,
+				Message: fmt.Sprintf("%s on an unindexed field scans every record; add a text index or use StartsWith/Eq", op),
+			})
+		}
+	}
+	return warnings
+}
+
+// lintContradictions reports ANDed conditions on one field that no record can
+// satisfy together: two different Eq values, or an empty numeric range.
+func lintContradictions(conds []map[string]interface{}) []LintWarning {
+	type bounds struct {
+		eq                   []interface{}
+		lower, upper         *float64
+		lowerIncl, upperIncl bool
+	}
+	byField := map[string]*bounds{}
+	var order []string
+	for _, cond := range conds {
+		field, _ := cond["field"].(string)
+		if field == "" {
+			continue
+		}
+		b, ok := byField[field]
+		if !ok {
+			b = &bounds{}
+			byField[field] = b
+			order = append(order, field)
+		}
+		value := cond["value"]
+		n, isNum := lintNumber(value)
+		switch cond["operator"] {
+		case "Eq":
+			b.eq = append(b.eq, value)
+		case "Gt", "Gte":
+			if isNum && (b.lower == nil || n > *b.lower || (n == *b.lower && cond["operator"] == "Gt")) {
+				b.lower, b.lowerIncl = &n, cond["operator"] == "Gte"
+			}
+		case "Lt", "Lte":
+			if isNum && (b.upper == nil || n < *b.upper || (n == *b.upper && cond["operator"] == "Lt")) {
+				b.upper, b.upperIncl = &n, cond["operator"] == "Lte"
+			}
+		}
+	}
+
+	var warnings []LintWarning
+	sort.Strings(order)
+	for _, field := range order {
+		b := byField[field]
+		for i := 1; i < len(b.eq); i++ {
+			if !reflect.DeepEqual(b.eq[0], b.eq[i]) {
+				warnings = append(warnings, LintWarning{
+					Code:    LintContradictoryFilter,
+					Field:   field,
+					Message: fmt.Sprintf("field must equal both %v and %v; use In or Or for alternatives", b.eq[0], b.eq[i]),
+				})
+				break
+			}
+		}
+		if b.lower != nil && b.upper != nil {
+			empty := *b.lower > *b.upper || (*b.lower == *b.upper && !(b.lowerIncl && b.upperIncl))
+			if empty {
+				warnings = append(warnings, LintWarning{
+					Code:    LintContradictoryFilter,
+					Field:   field,
+					Message: fmt.Sprintf("range is empty (lower bound %v, upper bound %v); no record can match", *b.lower, *b.upper),
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+// lintSortFields returns the field names of a sort spec in either the
+// QueryBuilder shape ([]map[string]interface{}) or a decoded one.
+func lintSortFields(sortSpec interface{}) []string {
+	var fields []string
+	for _, item := range lintSlice(sortSpec) {
+		if m, ok := lintMap(item); ok {
+			if f, ok := m["field"].(string); ok {
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
+
+// lintSchemaHasField reports whether the schema defines field. "id" always
+// exists, and a dotted path is checked by its top-level segment.
+func lintSchemaHasField(schema *Schema, field string) bool {
+	root := strings.SplitN(field, ".", 2)[0]
+	if root == "id" {
+		return true
+	}
+	_, ok := schema.Fields[root]
+	return ok
+}
+
+func lintMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case Record:
+		return m, true
+	}
+	return nil, false
+}
+
+func lintSlice(v interface{}) []interface{} {
+	switch s := v.(type) {
+	case []interface{}:
+		return s
+	case []map[string]interface{}:
+		out := make([]interface{}, len(s))
+		for i, m := range s {
+			out[i] = m
+		}
+		return out
+	case []string:
+		out := make([]interface{}, len(s))
+		for i, m := range s {
+			out[i] = m
+		}
+		return out
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil
+	}
+	out := make([]interface{}, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Interface()
+	}
+	return out
+}
+
+func lintNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return GetFloatValue(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,121 @@
+package ekodb
+
+import "testing"
+
+func lintCodes(warnings []LintWarning) map[string]string {
+	codes := map[string]string{}
+	for _, w := range warnings {
+		codes[w.Code+":"+w.Field] = w.Message
+	}
+	return codes
+}
+
+func TestLintQueryCleanQuery(t *testing.T) {
+	query := NewQueryBuilder().Eq("status", "active").Limit(10).Build()
+	if warnings := LintQuery(query, nil); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+}
+
+func TestLintQueryMissingLimit(t *testing.T) {
+	query := NewQueryBuilder().Eq("status", "active").Build()
+	codes := lintCodes(LintQuery(query, nil))
+	if _, ok := codes["missing_limit:"]; !ok {
+		t.Errorf("expected missing_limit, got %v", codes)
+	}
+}
+
+func TestLintQueryContradictions(t *testing.T) {
+	query := NewQueryBuilder().
+		Eq("status", "active").
+		Eq("status", "archived").
+		Gt("age", 40).
+		Lt("age", 30).
+		Gte("score", 5).
+		Lte("score", 5).
+		Limit(1).
+		Build()
+	codes := lintCodes(LintQuery(query, nil))
+	if _, ok := codes["contradictory_filter:status"]; !ok {
+		t.Errorf("expected contradictory Eq on status, got %v", codes)
+	}
+	if _, ok := codes["contradictory_filter:age"]; !ok {
+		t.Errorf("expected empty range on age, got %v", codes)
+	}
+	if _, ok := codes["contradictory_filter:score"]; ok {
+		t.Errorf("inclusive 5..5 range is satisfiable, got %v", codes)
+	}
+}
+
+func TestLintQueryOrIsNotContradictory(t *testing.T) {
+	query := NewQueryBuilder().Or([]map[string]interface{}{
+		{"type": "Condition", "content": map[string]interface{}{"field": "status", "operator": "Eq", "value": "a"}},
+		{"type": "Condition", "content": map[string]interface{}{"field": "status", "operator": "Eq", "value": "b"}},
+	}).Limit(5).Build()
+	if warnings := LintQuery(query, nil); len(warnings) != 0 {
+		t.Errorf("alternatives under Or are fine, got %v", warnings)
+	}
+}
+
+func TestLintQueryEmptyIn(t *testing.T) {
+	query := NewQueryBuilder().In("role", []interface{}{}).Limit(5).Build()
+	codes := lintCodes(LintQuery(query, nil))
+	if _, ok := codes["empty_in:role"]; !ok {
+		t.Errorf("expected empty_in, got %v", codes)
+	}
+}
+
+func TestLintQueryEmptyContains(t *testing.T) {
+	query := NewQueryBuilder().
+		ContainsAny("tags", []interface{}{}).
+		ContainsAll("roles", []interface{}{}).
+		Limit(5).
+		Build()
+	codes := lintCodes(LintQuery(query, nil))
+	if _, ok := codes["empty_in:tags"]; !ok {
+		t.Errorf("expected empty_in for ContainsAny, got %v", codes)
+	}
+	if _, ok := codes["empty_in:roles"]; !ok {
+		t.Errorf("expected empty_in for ContainsAll, got %v", codes)
+	}
+}
+
+func TestLintQuerySchemaChecks(t *testing.T) {
+	schema := NewSchemaBuilder().
+		AddField("name", NewFieldTypeSchemaBuilder("String").Build()).
+		AddField("bio", NewFieldTypeSchemaBuilder("String").TextIndex("english").Build()).
+		AddField("created_at", NewFieldTypeSchemaBuilder("DateTime").Build()).
+		Build()
+
+	query := NewQueryBuilder().
+		Contains("name", "ann").
+		Contains("bio", "golang").
+		Eq("emial", "x@example.com").
+		Eq("id", "abc").
+		SortDescending("created").
+		SortAscending("created_at").
+		Limit(20).
+		Build()
+
+	codes := lintCodes(LintQuery(query, &schema))
+	for _, want := range []string{"unindexed_pattern:name", "unknown_field:emial", "unknown_field:created"} {
+		if _, ok := codes[want]; !ok {
+			t.Errorf("expected %s, got %v", want, codes)
+		}
+	}
+	for _, unwanted := range []string{"unindexed_pattern:bio", "unknown_field:id", "unknown_field:created_at"} {
+		if _, ok := codes[unwanted]; ok {
+			t.Errorf("did not expect %s", unwanted)
+		}
+	}
+	if len(codes) != 3 {
+		t.Errorf("expected exactly 3 warnings, got %v", codes)
+	}
+}
+
+func TestLintWarningString(t *testing.T) {
+	w := LintWarning{Code: LintUnknownField, Field: "x", Message: "m"}
+	if w.String() != "unknown_field (x): m" {
+		t.Errorf("unexpected String(): %q", w.String())
+	}
+}
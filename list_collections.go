@@ -0,0 +1,82 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CollectionInfo describes one collection in ListCollectionsDetailed.
+type CollectionInfo struct {
+	Name          string    `json:"name"`
+	SchemaVersion int       `json:"schema_version"`
+	RecordCount   int64     `json:"record_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	ModifiedAt    time.Time `json:"last_modified"`
+}
+
+// ListCollectionsOptions contains optional parameters for
+// ListCollectionsDetailed
+type ListCollectionsOptions struct {
+	// Limit caps the collections returned; 0 returns them all.
+	Limit int
+	// Offset skips that many collections, in name order.
+	Offset int
+	// ExcludeInternal leaves out the server's chat and system collections,
+	// as ListUserCollections does.
+	ExcludeInternal bool
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
+}
+
+// ListCollectionsDetailed lists collections with their schema version,
+// record count, and timestamps, a page at a time. It also returns the total
+// number of collections, so callers can page through them:
+//
+//	for offset := 0; ; offset += 100 {
+//	    page, total, err := client.ListCollectionsDetailed(ekodb.ListCollectionsOptions{Limit: 100, Offset: offset})
+//	    ...
+//	    if offset+len(page) >= total {
+//	        break
+//	    }
+//	}
+func (c *Client) ListCollectionsDetailed(opts ...ListCollectionsOptions) ([]CollectionInfo, int, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	params := url.Values{}
+	params.Set("detailed", "true")
+	var offset int
+	if len(opts) > 0 {
+		if opts[0].Limit > 0 {
+			params.Set("limit", strconv.Itoa(opts[0].Limit))
+		}
+		if opts[0].Offset > 0 {
+			offset = opts[0].Offset
+			params.Set("offset", strconv.Itoa(offset))
+		}
+		if opts[0].ExcludeInternal {
+			params.Set("exclude_internal", "true")
+		}
+	}
+
+	respBody, err := c.makeRequest("GET", "/api/collections?"+params.Encode(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result struct {
+		Collections []CollectionInfo `json:"collections"`
+		Total       *int             `json:"total"`
+	}
+	// Always use JSON for metadata endpoints
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, 0, err
+	}
+	total := offset + len(result.Collections)
+	if result.Total != nil {
+		total = *result.Total
+	}
+	return result.Collections, total, nil
+}
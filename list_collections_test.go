@@ -0,0 +1,42 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestListCollectionsDetailed(t *testing.T) {
+	var query string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/collections": func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"collections": []map[string]interface{}{
+					{"name": "orders", "schema_version": 3, "record_count": 1500, "created_at": "2026-01-02T03:04:05Z", "last_modified": "2026-02-03T04:05:06Z"},
+					{"name": "users", "schema_version": 1, "record_count": 20},
+				},
+				"total": 2040,
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	page, total, err := client.ListCollectionsDetailed(ListCollectionsOptions{Limit: 2, Offset: 10, ExcludeInternal: true})
+	if err != nil {
+		t.Fatalf("ListCollectionsDetailed failed: %v", err)
+	}
+	if query != "detailed=true&exclude_internal=true&limit=2&offset=10" {
+		t.Errorf("query = %q", query)
+	}
+	if total != 2040 || len(page) != 2 {
+		t.Fatalf("got %d collections of %d", len(page), total)
+	}
+	if page[0].Name != "orders" || page[0].SchemaVersion != 3 || page[0].RecordCount != 1500 || page[0].CreatedAt.Year() != 2026 {
+		t.Errorf("unexpected collection %+v", page[0])
+	}
+	if !page[1].ModifiedAt.IsZero() {
+		t.Errorf("missing timestamp should be zero, got %v", page[1].ModifiedAt)
+	}
+}
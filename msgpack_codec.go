@@ -0,0 +1,366 @@
+package ekodb
+
+import (
+	"encoding/json"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Hand-written MessagePack codecs for the types on the ingestion and query hot
+// paths. msgpack/v5 otherwise resolves an encoder per value through
+// reflection; these implement msgpack.CustomEncoder / CustomDecoder so maps,
+// arrays, and numeric vectors are written directly. The wire format is
+// identical to the reflective encoding, so servers see no difference.
+
+var (
+	_ msgpack.CustomEncoder = Record(nil)
+	_ msgpack.CustomDecoder = (*Record)(nil)
+	_ msgpack.CustomEncoder = Float32Vector(nil)
+	_ msgpack.CustomDecoder = (*Float32Vector)(nil)
+	_ msgpack.CustomEncoder = (*SearchQuery)(nil)
+	_ msgpack.CustomEncoder = batchInsertQuery{}
+	_ msgpack.CustomEncoder = batchUpdateQuery{}
+	_ msgpack.CustomEncoder = batchDeleteQuery{}
+)
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+func (r Record) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if r == nil {
+		return enc.EncodeNil()
+	}
+	if err := enc.EncodeMapLen(len(r)); err != nil {
+		return err
+	}
+	for k, v := range r {
+		if err := enc.EncodeString(k); err != nil {
+			return err
+		}
+		if err := encodeMsgpackValue(enc, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder. Nested values decode
+// exactly as they would into a map[string]interface{}.
+func (r *Record) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n, err := dec.DecodeMapLen()
+	if err != nil {
+		return err
+	}
+	if n == -1 {
+		*r = nil
+		return nil
+	}
+	m := make(Record, n)
+	if err := decodeRecordEntries(dec, m, n); err != nil {
+		return err
+	}
+	*r = m
+	return nil
+}
+
+// decodeRecordInto decodes a MessagePack map into rec, which the caller has
+// emptied, so one map can be reused across many records. A nil map leaves rec
+// empty.
+func decodeRecordInto(dec *msgpack.Decoder, rec Record) error {
+	n, err := dec.DecodeMapLen()
+	if err != nil {
+		return err
+	}
+	if n == -1 {
+		return nil
+	}
+	return decodeRecordEntries(dec, rec, n)
+}
+
+func decodeRecordEntries(dec *msgpack.Decoder, rec Record, n int) error {
+	for i := 0; i < n; i++ {
+		k, err := dec.DecodeString()
+		if err != nil {
+			return err
+		}
+		v, err := dec.DecodeInterface()
+		if err != nil {
+			return err
+		}
+		rec[k] = v
+	}
+	return nil
+}
+
+// encodeMsgpackValue writes a record value, taking direct paths for the
+// containers and vectors records are made of and deferring scalars (which
+// msgpack already special-cases) to Encode.
+func encodeMsgpackValue(enc *msgpack.Encoder, v interface{}) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if v == nil {
+			return enc.EncodeNil()
+		}
+		return Record(v).EncodeMsgpack(enc)
+	case Record:
+		return v.EncodeMsgpack(enc)
+	case []interface{}:
+		if v == nil {
+			return enc.EncodeNil()
+		}
+		if err := enc.EncodeArrayLen(len(v)); err != nil {
+			return err
+		}
+		for _, item := range v {
+			if err := encodeMsgpackValue(enc, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []float64:
+		if v == nil {
+			return enc.EncodeNil()
+		}
+		if err := enc.EncodeArrayLen(len(v)); err != nil {
+			return err
+		}
+		for _, f := range v {
+			if err := enc.EncodeFloat64(f); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []float32:
+		return Float32Vector(v).EncodeMsgpack(enc)
+	case json.Number:
+		// Numbers kept by ClientConfig.UseNumber go back out as numbers.
+		if i, err := v.Int64(); err == nil {
+			return enc.EncodeInt(i)
+		}
+		if f, err := v.Float64(); err == nil {
+			return enc.EncodeFloat64(f)
+		}
+		return enc.EncodeString(v.String())
+	case []string:
+		if v == nil {
+			return enc.EncodeNil()
+		}
+		if err := enc.EncodeArrayLen(len(v)); err != nil {
+			return err
+		}
+		for _, s := range v {
+			if err := enc.EncodeString(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return enc.Encode(v)
+}
+
+// Float32Vector is an embedding stored as 32-bit floats. Over MessagePack each
+// element takes 5 bytes instead of the 9 a float64 needs, roughly halving the
+// payload for large embeddings at no loss for models that emit float32
+// anyway. Build a Vector field from one with FieldVectorFloat32.
+type Float32Vector []float32
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+func (v Float32Vector) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if v == nil {
+		return enc.EncodeNil()
+	}
+	if err := enc.EncodeArrayLen(len(v)); err != nil {
+		return err
+	}
+	for _, f := range v {
+		if err := enc.EncodeFloat32(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder. Elements encoded as any
+// MessagePack number are accepted.
+func (v *Float32Vector) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n, err := dec.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if n == -1 {
+		*v = nil
+		return nil
+	}
+	out := make(Float32Vector, n)
+	for i := range out {
+		f, err := dec.DecodeFloat64()
+		if err != nil {
+			return err
+		}
+		out[i] = float32(f)
+	}
+	*v = out
+	return nil
+}
+
+// FieldVectorFloat32 creates a Vector field value from float32 elements (see
+// Float32Vector).
+func FieldVectorFloat32(values []float32) map[string]interface{} {
+	return map[string]interface{}{
+		"type":  "Vector",
+		"value": Float32Vector(values),
+	}
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder. SearchQuery only carries
+// json tags, so the reflective encoder would emit Go field names and nil
+// pointers; this writes the same keys, omitting the same empty fields, as its
+// JSON encoding.
+func (q *SearchQuery) EncodeMsgpack(enc *msgpack.Encoder) error {
+	type pair struct {
+		key   string
+		value interface{}
+	}
+	fields := make([]pair, 0, 8)
+	fields = append(fields, pair{"query", q.Query})
+	addPtr := func(key string, set bool, value func() interface{}) {
+		if set {
+			fields = append(fields, pair{key, value()})
+		}
+	}
+	addPtr("language", q.Language != nil, func() interface{} { return *q.Language })
+	addPtr("case_sensitive", q.CaseSensitive != nil, func() interface{} { return *q.CaseSensitive })
+	addPtr("fuzzy", q.Fuzzy != nil, func() interface{} { return *q.Fuzzy })
+	addPtr("min_score", q.MinScore != nil, func() interface{} { return *q.MinScore })
+	addPtr("fields", q.Fields != nil, func() interface{} { return *q.Fields })
+	addPtr("weights", q.Weights != nil, func() interface{} { return *q.Weights })
+	addPtr("enable_stemming", q.EnableStemming != nil, func() interface{} { return *q.EnableStemming })
+	addPtr("boost_exact", q.BoostExact != nil, func() interface{} { return *q.BoostExact })
+	addPtr("max_edit_distance", q.MaxEditDistance != nil, func() interface{} { return *q.MaxEditDistance })
+	addPtr("vector", len(q.Vector) > 0, func() interface{} { return q.Vector })
+	addPtr("vector_field", q.VectorField != nil, func() interface{} { return *q.VectorField })
+	addPtr("vector_metric", q.VectorMetric != nil, func() interface{} { return *q.VectorMetric })
+	addPtr("vector_k", q.VectorK != nil, func() interface{} { return *q.VectorK })
+	addPtr("vector_threshold", q.VectorThreshold != nil, func() interface{} { return *q.VectorThreshold })
+	addPtr("text_weight", q.TextWeight != nil, func() interface{} { return *q.TextWeight })
+	addPtr("vector_weight", q.VectorWeight != nil, func() interface{} { return *q.VectorWeight })
+	addPtr("bypass_ripple", q.BypassRipple != nil, func() interface{} { return *q.BypassRipple })
+	addPtr("bypass_cache", q.BypassCache != nil, func() interface{} { return *q.BypassCache })
+	addPtr("limit", q.Limit != nil, func() interface{} { return *q.Limit })
+	addPtr("offset", q.Offset != nil, func() interface{} { return *q.Offset })
+	addPtr("snapshot_token", q.SnapshotToken != "", func() interface{} { return q.SnapshotToken })
+	addPtr("select_fields", len(q.SelectFields) > 0, func() interface{} { return q.SelectFields })
+	addPtr("exclude_fields", len(q.ExcludeFields) > 0, func() interface{} { return q.ExcludeFields })
+	addPtr("filters", q.Filters != nil, func() interface{} { return q.Filters })
+
+	if err := enc.EncodeMapLen(len(fields)); err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if err := enc.EncodeString(f.key); err != nil {
+			return err
+		}
+		if err := encodeMsgpackValue(enc, f.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+func (q batchInsertQuery) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if err := enc.EncodeMapLen(1); err != nil {
+		return err
+	}
+	if err := enc.EncodeString("inserts"); err != nil {
+		return err
+	}
+	if err := enc.EncodeArrayLen(len(q.Inserts)); err != nil {
+		return err
+	}
+	for _, item := range q.Inserts {
+		if err := encodeBatchItem(enc, nil, item.Data, true, item.BypassRipple); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+func (q batchUpdateQuery) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if err := enc.EncodeMapLen(1); err != nil {
+		return err
+	}
+	if err := enc.EncodeString("updates"); err != nil {
+		return err
+	}
+	if err := enc.EncodeArrayLen(len(q.Updates)); err != nil {
+		return err
+	}
+	for _, item := range q.Updates {
+		if err := encodeBatchItem(enc, &item.ID, item.Data, true, item.BypassRipple); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+func (q batchDeleteQuery) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if err := enc.EncodeMapLen(1); err != nil {
+		return err
+	}
+	if err := enc.EncodeString("deletes"); err != nil {
+		return err
+	}
+	if err := enc.EncodeArrayLen(len(q.Deletes)); err != nil {
+		return err
+	}
+	for _, item := range q.Deletes {
+		if err := encodeBatchItem(enc, &item.ID, nil, false, item.BypassRipple); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeBatchItem writes one batch element as {"id"?, "data"?,
+// "bypass_ripple"?}, matching the msgpack tags on the batch item structs.
+func encodeBatchItem(enc *msgpack.Encoder, id *string, data Record, hasData bool, bypassRipple *bool) error {
+	n := 0
+	if id != nil {
+		n++
+	}
+	if hasData {
+		n++
+	}
+	if bypassRipple != nil {
+		n++
+	}
+	if err := enc.EncodeMapLen(n); err != nil {
+		return err
+	}
+	if id != nil {
+		if err := enc.EncodeString("id"); err != nil {
+			return err
+		}
+		if err := enc.EncodeString(*id); err != nil {
+			return err
+		}
+	}
+	if hasData {
+		if err := enc.EncodeString("data"); err != nil {
+			return err
+		}
+		if err := data.EncodeMsgpack(enc); err != nil {
+			return err
+		}
+	}
+	if bypassRipple != nil {
+		if err := enc.EncodeString("bypass_ripple"); err != nil {
+			return err
+		}
+		if err := enc.EncodeBool(*bypassRipple); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package ekodb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func sampleRecord() Record {
+	return Record{
+		"name":      "Alice",
+		"age":       30,
+		"score":     98.5,
+		"active":    true,
+		"missing":   nil,
+		"tags":      []string{"a", "b"},
+		"embedding": []float64{0.1, 0.2, 0.3},
+		"address": map[string]interface{}{
+			"city": "Paris",
+			"zip":  int64(75001),
+		},
+		"items": []interface{}{1, "two", map[string]interface{}{"three": 3.0}},
+	}
+}
+
+// decodeGeneric decodes b into plain interface{} values so encodings can be
+// compared independently of map iteration order.
+func decodeGeneric(t *testing.T, b []byte) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := msgpack.Unmarshal(b, &v); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	return v
+}
+
+func TestRecordMsgpackMatchesReflectiveEncoding(t *testing.T) {
+	rec := sampleRecord()
+
+	custom, err := msgpack.Marshal(rec)
+	if err != nil {
+		t.Fatalf("Marshal(Record) failed: %v", err)
+	}
+	reflective, err := msgpack.Marshal(map[string]interface{}(rec))
+	if err != nil {
+		t.Fatalf("Marshal(map) failed: %v", err)
+	}
+	if !reflect.DeepEqual(decodeGeneric(t, custom), decodeGeneric(t, reflective)) {
+		t.Errorf("custom encoding differs from reflective encoding")
+	}
+
+	var decoded Record
+	if err := msgpack.Unmarshal(custom, &decoded); err != nil {
+		t.Fatalf("Unmarshal(Record) failed: %v", err)
+	}
+	var expected map[string]interface{}
+	if err := msgpack.Unmarshal(reflective, &expected); err != nil {
+		t.Fatalf("Unmarshal(map) failed: %v", err)
+	}
+	if !reflect.DeepEqual(map[string]interface{}(decoded), expected) {
+		t.Errorf("decoded Record = %v, want %v", decoded, expected)
+	}
+}
+
+func TestRecordMsgpackNil(t *testing.T) {
+	b, err := msgpack.Marshal(Record(nil))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	decoded := Record{"stale": true}
+	if err := msgpack.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded != nil {
+		t.Errorf("expected nil Record, got %v", decoded)
+	}
+}
+
+func TestFloat32VectorMsgpack(t *testing.T) {
+	vec := Float32Vector{0.5, -1.25, 3}
+	b, err := msgpack.Marshal(vec)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	// fixarray header + 5 bytes per float32
+	if want := 1 + 5*len(vec); len(b) != want {
+		t.Errorf("encoded length = %d, want %d", len(b), want)
+	}
+
+	var decoded Float32Vector
+	if err := msgpack.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, vec) {
+		t.Errorf("decoded = %v, want %v", decoded, vec)
+	}
+
+	// float64 arrays decode into a Float32Vector too.
+	b, _ = msgpack.Marshal([]float64{1.5, 2})
+	if err := msgpack.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Unmarshal from float64 failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, Float32Vector{1.5, 2}) {
+		t.Errorf("decoded = %v, want [1.5 2]", decoded)
+	}
+
+	field := FieldVectorFloat32([]float32{1, 2})
+	if field["type"] != "Vector" {
+		t.Errorf("type = %v, want Vector", field["type"])
+	}
+}
+
+func TestSearchQueryMsgpackUsesJSONKeys(t *testing.T) {
+	limit, offset := 5, 10
+	query := SearchQuery{
+		Query:         "hello",
+		Limit:         &limit,
+		Offset:        &offset,
+		SnapshotToken: "snap",
+		Vector:        []float64{0.1, 0.2},
+		SelectFields:  []string{"title"},
+	}
+	b, err := msgpack.Marshal(&query)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	got := decodeGeneric(t, b)
+	want := map[string]interface{}{
+		"query":          "hello",
+		"limit":          int8(5),
+		"offset":         int8(10),
+		"snapshot_token": "snap",
+		"vector":         []interface{}{0.1, 0.2},
+		"select_fields":  []interface{}{"title"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("encoded = %#v, want %#v", got, want)
+	}
+}
+
+func TestBatchPayloadMsgpackMatchesTags(t *testing.T) {
+	bypass := true
+	payloads := []interface{}{
+		batchInsertQuery{Inserts: []batchInsertItem{
+			{Data: Record{"n": 1}},
+			{Data: Record{"n": 2}, BypassRipple: &bypass},
+		}},
+		batchUpdateQuery{Updates: []batchUpdateItem{
+			{ID: "r1", Data: Record{"n": 1}},
+			{ID: "", Data: nil, BypassRipple: &bypass},
+		}},
+		batchDeleteQuery{Deletes: []batchDeleteItem{
+			{ID: "r1"},
+			{ID: "r2", BypassRipple: &bypass},
+		}},
+	}
+	for _, payload := range payloads {
+		custom, err := msgpack.Marshal(payload)
+		if err != nil {
+			t.Fatalf("Marshal(%T) failed: %v", payload, err)
+		}
+		reflective := reflectiveMarshal(t, payload)
+		if !reflect.DeepEqual(decodeGeneric(t, custom), decodeGeneric(t, reflective)) {
+			t.Errorf("%T: custom encoding %v differs from reflective %v",
+				payload, decodeGeneric(t, custom), decodeGeneric(t, reflective))
+		}
+	}
+}
+
+// reflectiveMarshal encodes the batch payload via mirror types that carry the
+// same tags but no custom encoder.
+func reflectiveMarshal(t *testing.T, payload interface{}) []byte {
+	t.Helper()
+	type insertItem struct {
+		Data         map[string]interface{} `msgpack:"data"`
+		BypassRipple *bool                  `msgpack:"bypass_ripple,omitempty"`
+	}
+	type updateItem struct {
+		ID           string                 `msgpack:"id"`
+		Data         map[string]interface{} `msgpack:"data"`
+		BypassRipple *bool                  `msgpack:"bypass_ripple,omitempty"`
+	}
+	type deleteItem struct {
+		ID           string `msgpack:"id"`
+		BypassRipple *bool  `msgpack:"bypass_ripple,omitempty"`
+	}
+
+	var v interface{}
+	switch p := payload.(type) {
+	case batchInsertQuery:
+		items := make([]insertItem, len(p.Inserts))
+		for i, it := range p.Inserts {
+			items[i] = insertItem{it.Data, it.BypassRipple}
+		}
+		v = map[string]interface{}{"inserts": items}
+	case batchUpdateQuery:
+		items := make([]updateItem, len(p.Updates))
+		for i, it := range p.Updates {
+			items[i] = updateItem{it.ID, it.Data, it.BypassRipple}
+		}
+		v = map[string]interface{}{"updates": items}
+	case batchDeleteQuery:
+		items := make([]deleteItem, len(p.Deletes))
+		for i, it := range p.Deletes {
+			items[i] = deleteItem{it.ID, it.BypassRipple}
+		}
+		v = map[string]interface{}{"deletes": items}
+	}
+	b, err := msgpack.Marshal(v)
+	if err != nil {
+		t.Fatalf("reflective Marshal failed: %v", err)
+	}
+	return b
+}
+
+func benchmarkBatch(n int) batchInsertQuery {
+	items := make([]batchInsertItem, n)
+	for i := range items {
+		items[i] = batchInsertItem{Data: sampleRecord()}
+	}
+	return batchInsertQuery{Inserts: items}
+}
+
+func BenchmarkRecordEncodeCustom(b *testing.B) {
+	rec := sampleRecord()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := msgpack.Marshal(rec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRecordEncodeReflective(b *testing.B) {
+	rec := map[string]interface{}(sampleRecord())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := msgpack.Marshal(rec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRecordDecodeCustom(b *testing.B) {
+	data, _ := msgpack.Marshal(sampleRecord())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var rec Record
+		if err := msgpack.Unmarshal(data, &rec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRecordDecodeReflective(b *testing.B) {
+	data, _ := msgpack.Marshal(sampleRecord())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var rec map[string]interface{}
+		if err := msgpack.Unmarshal(data, &rec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBatchInsertEncode(b *testing.B) {
+	payload := benchmarkBatch(100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := msgpack.Marshal(payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVectorEncodeFloat32(b *testing.B) {
+	vec := make(Float32Vector, 1536)
+	for i := range vec {
+		vec[i] = float32(i) / 1536
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := msgpack.Marshal(vec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVectorEncodeFloat64(b *testing.B) {
+	vec := make([]float64, 1536)
+	for i := range vec {
+		vec[i] = float64(i) / 1536
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := msgpack.Marshal(vec); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,392 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// openAPISpecPath is where servers publish their OpenAPI document.
+const openAPISpecPath = "/api/openapi.json"
+
+// maxValidationProblems caps the problems listed in one RequestValidationError.
+const maxValidationProblems = 20
+
+// RequestValidationError is returned, before anything is sent, when
+// ClientConfig.ValidateRequests finds that a request does not match the
+// server's OpenAPI spec. It usually means the client and server versions
+// have drifted apart.
+type RequestValidationError struct {
+	Method   string
+	Path     string
+	Problems []string // One entry per mismatch, e.g. `body.limit: expected integer, got string`
+}
+
+func (e *RequestValidationError) Error() string {
+	return fmt.Sprintf("request %s %s does not match the server's OpenAPI spec: %s", e.Method, e.Path, strings.Join(e.Problems, "; "))
+}
+
+// openAPISpec is the subset of an OpenAPI 3 document the validator reads.
+type openAPISpec struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPIOperation struct {
+	Parameters  []openAPIParameter `json:"parameters"`
+	RequestBody *struct {
+		Required bool                                       `json:"required"`
+		Content  map[string]struct{ Schema *openAPISchema } `json:"content"`
+	} `json:"requestBody"`
+}
+
+type openAPIParameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+}
+
+// openAPISchema is the subset of JSON Schema the validator checks.
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref"`
+	Type                 json.RawMessage           `json:"type"` // A string, or a list of strings in OpenAPI 3.1
+	Nullable             bool                      `json:"nullable"`
+	Properties           map[string]*openAPISchema `json:"properties"`
+	Required             []string                  `json:"required"`
+	AdditionalProperties json.RawMessage           `json:"additionalProperties"` // false, or a schema
+	Items                *openAPISchema            `json:"items"`
+	Enum                 []interface{}             `json:"enum"`
+	OneOf                []*openAPISchema          `json:"oneOf"`
+	AnyOf                []*openAPISchema          `json:"anyOf"`
+	AllOf                []*openAPISchema          `json:"allOf"`
+}
+
+// openAPIRoute is one path template and its operations by method.
+type openAPIRoute struct {
+	template   string
+	segments   []string
+	literals   int // Non-parameter segments, to prefer "/a/stats" over "/a/{id}"
+	operations map[string]*openAPIOperation
+	parameters []openAPIParameter // Shared by every operation on the path
+}
+
+// openAPIValidator checks outgoing requests against a parsed spec.
+type openAPIValidator struct {
+	routes  []openAPIRoute
+	schemas map[string]*openAPISchema
+}
+
+// newOpenAPIValidator parses an OpenAPI 3 JSON document.
+func newOpenAPIValidator(spec []byte) (*openAPIValidator, error) {
+	var doc openAPISpec
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+	if len(doc.Paths) == 0 {
+		return nil, fmt.Errorf("invalid OpenAPI spec: no paths")
+	}
+	v := &openAPIValidator{schemas: doc.Components.Schemas}
+	for template, item := range doc.Paths {
+		route := openAPIRoute{
+			template:   template,
+			segments:   strings.Split(strings.Trim(template, "/"), "/"),
+			operations: make(map[string]*openAPIOperation),
+		}
+		for _, seg := range route.segments {
+			if !strings.HasPrefix(seg, "{") {
+				route.literals++
+			}
+		}
+		for key, raw := range item {
+			if key == "parameters" {
+				if err := json.Unmarshal(raw, &route.parameters); err != nil {
+					return nil, fmt.Errorf("invalid OpenAPI spec: %s parameters: %w", template, err)
+				}
+				continue
+			}
+			var op openAPIOperation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				continue // Not an operation (summary, servers, ...)
+			}
+			route.operations[strings.ToUpper(key)] = &op
+		}
+		v.routes = append(v.routes, route)
+	}
+	return v, nil
+}
+
+// loadOpenAPIValidator builds the validator for ClientConfig.ValidateRequests
+// from spec, or from the server's published spec when spec is nil.
+func (c *Client) loadOpenAPIValidator(spec []byte) (*openAPIValidator, error) {
+	if spec == nil {
+		var err error
+		if spec, err = c.makeRequest("GET", openAPISpecPath, nil); err != nil {
+			return nil, fmt.Errorf("failed to fetch OpenAPI spec: %w", err)
+		}
+	}
+	return newOpenAPIValidator(spec)
+}
+
+// route finds the operation for method and path, preferring the template
+// with the most literal segments.
+func (v *openAPIValidator) route(method, path string) (*openAPIRoute, *openAPIOperation, string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var best *openAPIRoute
+	for i := range v.routes {
+		r := &v.routes[i]
+		if len(r.segments) != len(segments) {
+			continue
+		}
+		match := true
+		for j, seg := range r.segments {
+			if !strings.HasPrefix(seg, "{") && seg != segments[j] {
+				match = false
+				break
+			}
+		}
+		if match && (best == nil || r.literals > best.literals) {
+			best = r
+		}
+	}
+	if best == nil {
+		return nil, nil, "no such path in the server's spec"
+	}
+	op, ok := best.operations[method]
+	if !ok {
+		methods := make([]string, 0, len(best.operations))
+		for m := range best.operations {
+			methods = append(methods, m)
+		}
+		sort.Strings(methods)
+		return best, nil, fmt.Sprintf("%s does not allow %s (allowed: %s)", best.template, method, strings.Join(methods, ", "))
+	}
+	return best, op, ""
+}
+
+// validate checks a request's path, query parameters, and body.
+func (v *openAPIValidator) validate(method, rawPath string, data interface{}) error {
+	path, rawQuery, _ := strings.Cut(rawPath, "?")
+	fail := func(problems ...string) error {
+		return &RequestValidationError{Method: method, Path: path, Problems: problems}
+	}
+
+	route, op, problem := v.route(method, path)
+	if op == nil {
+		return fail(problem)
+	}
+
+	var problems []string
+	if query, err := url.ParseQuery(rawQuery); err == nil {
+		declared := make(map[string]bool)
+		for _, p := range append(append([]openAPIParameter(nil), route.parameters...), op.Parameters...) {
+			if p.In != "query" {
+				continue
+			}
+			declared[p.Name] = true
+			if p.Required && !query.Has(p.Name) {
+				problems = append(problems, fmt.Sprintf("query parameter %q is required", p.Name))
+			}
+		}
+		names := make([]string, 0, len(query))
+		for name := range query {
+			if !declared[name] {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			problems = append(problems, fmt.Sprintf("query parameter %q is not accepted by %s %s", name, method, route.template))
+		}
+	}
+
+	switch {
+	case data == nil:
+		if op.RequestBody != nil && op.RequestBody.Required {
+			problems = append(problems, "a request body is required")
+		}
+	case op.RequestBody == nil:
+		problems = append(problems, fmt.Sprintf("%s %s takes no request body", method, route.template))
+	default:
+		if media, ok := op.RequestBody.Content["application/json"]; ok && media.Schema != nil {
+			body, err := jsonValue(data)
+			if err != nil {
+				return err
+			}
+			v.check(media.Schema, body, "body", &problems, 0)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	if len(problems) > maxValidationProblems {
+		problems = append(problems[:maxValidationProblems], fmt.Sprintf("and %d more", len(problems)-maxValidationProblems))
+	}
+	return fail(problems...)
+}
+
+// jsonValue returns data as the generic value its JSON encoding decodes to.
+func jsonValue(data interface{}) (interface{}, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	err = json.Unmarshal(b, &out)
+	return out, err
+}
+
+// resolve follows $ref to a component schema.
+func (v *openAPIValidator) resolve(s *openAPISchema) *openAPISchema {
+	for s != nil && s.Ref != "" {
+		s = v.schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	return s
+}
+
+// check appends a problem for each way value fails to match s.
+func (v *openAPIValidator) check(s *openAPISchema, value interface{}, at string, problems *[]string, depth int) {
+	s = v.resolve(s)
+	if s == nil || depth > 32 {
+		return
+	}
+	if value == nil {
+		if !s.Nullable && !s.allowsType("null") && len(s.types()) > 0 {
+			*problems = append(*problems, fmt.Sprintf("%s: must not be null", at))
+		}
+		return
+	}
+
+	for _, sub := range s.AllOf {
+		v.check(sub, value, at, problems, depth+1)
+	}
+	for _, alternatives := range [][]*openAPISchema{s.OneOf, s.AnyOf} {
+		if len(alternatives) > 0 && !v.matchesAny(alternatives, value, depth) {
+			*problems = append(*problems, fmt.Sprintf("%s: matches none of the allowed shapes", at))
+		}
+	}
+
+	if types := s.types(); len(types) > 0 {
+		got := jsonTypeOf(value)
+		if !s.allowsType(got) && !(got == "integer" && s.allowsType("number")) {
+			*problems = append(*problems, fmt.Sprintf("%s: expected %s, got %s", at, strings.Join(types, " or "), got))
+			return
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if fmt.Sprint(e) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			*problems = append(*problems, fmt.Sprintf("%s: %v is not one of %v", at, value, s.Enum))
+		}
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := val[name]; !ok {
+				*problems = append(*problems, fmt.Sprintf("%s.%s: required field is missing", at, name))
+			}
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		extra := s.additionalSchema()
+		for _, k := range keys {
+			if prop, ok := s.Properties[k]; ok {
+				v.check(prop, val[k], at+"."+k, problems, depth+1)
+			} else if string(s.AdditionalProperties) == "false" {
+				*problems = append(*problems, fmt.Sprintf("%s.%s: unknown field", at, k))
+			} else if extra != nil {
+				v.check(extra, val[k], at+"."+k, problems, depth+1)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range val {
+				v.check(s.Items, item, fmt.Sprintf("%s[%d]", at, i), problems, depth+1)
+			}
+		}
+	}
+}
+
+func (v *openAPIValidator) matchesAny(alternatives []*openAPISchema, value interface{}, depth int) bool {
+	for _, alt := range alternatives {
+		var p []string
+		v.check(alt, value, "", &p, depth+1)
+		if len(p) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// types returns the schema's allowed JSON types.
+func (s *openAPISchema) types() []string {
+	if len(s.Type) == 0 {
+		return nil
+	}
+	var one string
+	if json.Unmarshal(s.Type, &one) == nil {
+		return []string{one}
+	}
+	var many []string
+	_ = json.Unmarshal(s.Type, &many)
+	return many
+}
+
+func (s *openAPISchema) allowsType(t string) bool {
+	for _, allowed := range s.types() {
+		if allowed == t {
+			return true
+		}
+	}
+	return false
+}
+
+// additionalSchema returns the schema for properties not listed in
+// Properties, if one is given.
+func (s *openAPISchema) additionalSchema() *openAPISchema {
+	if len(s.AdditionalProperties) == 0 || s.AdditionalProperties[0] != '{' {
+		return nil
+	}
+	var extra openAPISchema
+	if json.Unmarshal(s.AdditionalProperties, &extra) != nil {
+		return nil
+	}
+	return &extra
+}
+
+// jsonTypeOf names the JSON Schema type of a decoded JSON value.
+func jsonTypeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,119 @@
+package ekodb
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testOpenAPISpec = `{
+  "openapi": "3.0.3",
+  "paths": {
+    "/api/insert/{collection}": {
+      "parameters": [{"name": "collection", "in": "path", "required": true}],
+      "post": {
+        "parameters": [{"name": "bypass_ripple", "in": "query"}],
+        "requestBody": {"required": true, "content": {"application/json": {"schema": {"type": "object"}}}}
+      }
+    },
+    "/api/find/{collection}": {
+      "post": {
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/FindBody"}}}}
+      }
+    },
+    "/api/find/{collection}/{id}": {"get": {}}
+  },
+  "components": {
+    "schemas": {
+      "FindBody": {
+        "type": "object",
+        "additionalProperties": false,
+        "properties": {
+          "limit": {"type": "integer"},
+          "filter": {"type": "object", "nullable": true},
+          "sort": {"type": "array", "items": {"$ref": "#/components/schemas/Sort"}}
+        }
+      },
+      "Sort": {
+        "type": "object",
+        "required": ["field"],
+        "properties": {"field": {"type": "string"}, "ascending": {"type": "boolean"}}
+      }
+    }
+  }
+}`
+
+func TestValidateRequests(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/openapi.json": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(testOpenAPISpec))
+		},
+		"POST /api/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id": "u1"}`))
+		},
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[]`))
+		},
+		"GET /api/find/users/u1": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id": "u1"}`))
+		},
+	})
+	defer server.Close()
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:          server.URL,
+		APIKey:           "test-api-key",
+		Timeout:          5 * time.Second,
+		Format:           JSON,
+		ValidateRequests: true,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+
+	if _, err := client.Insert("users", Record{"name": "Ada"}); err != nil {
+		t.Errorf("valid Insert failed: %v", err)
+	}
+	if _, err := client.Find("users", map[string]interface{}{"limit": 5, "sort": []interface{}{map[string]interface{}{"field": "name"}}}); err != nil {
+		t.Errorf("valid Find failed: %v", err)
+	}
+
+	_, err = client.Find("users", map[string]interface{}{"limit": "ten", "sort": []interface{}{map[string]interface{}{"ascending": "yes"}}, "skipp": 1})
+	var vErr *RequestValidationError
+	if !errors.As(err, &vErr) {
+		t.Fatalf("expected a RequestValidationError, got %v", err)
+	}
+	want := []string{
+		"body.limit: expected integer, got string",
+		"body.skipp: unknown field",
+		"body.sort[0].field: required field is missing",
+		"body.sort[0].ascending: expected boolean, got string",
+	}
+	msg := vErr.Error()
+	for _, w := range want {
+		if !strings.Contains(msg, w) {
+			t.Errorf("error %q does not mention %q", msg, w)
+		}
+	}
+
+	bypass := true
+	_, err = client.Insert("users", Record{"name": "Ada"}, InsertOptions{BypassCache: &bypass})
+	if !errors.As(err, &vErr) || !strings.Contains(err.Error(), `query parameter "bypass_cache" is not accepted`) {
+		t.Errorf("expected an unknown query parameter error, got %v", err)
+	}
+
+	_, err = client.FindByID("users", "u1")
+	if err != nil {
+		t.Errorf("FindByID should not be rejected by validation: %v", err)
+	}
+	if err := client.DeleteCollection("users"); !errors.As(err, &vErr) || !strings.Contains(err.Error(), "no such path") {
+		t.Errorf("expected an unknown path error, got %v", err)
+	}
+}
+
+func TestNewOpenAPIValidatorRejectsInvalidSpec(t *testing.T) {
+	if _, err := newOpenAPIValidator([]byte(`{"openapi": "3.0.0"}`)); err == nil {
+		t.Error("expected an error for a spec without paths")
+	}
+}
@@ -0,0 +1,159 @@
+package ekodb
+
+import (
+	"context"
+	"fmt"
+)
+
+// bulkFeature is the ServerInfo feature flag for the bulk operation envelope.
+const bulkFeature = "bulk"
+
+// Pipeline queues writes to send together with Execute:
+//
+//	results, err := client.Pipeline().
+//	    Insert("users", ekodb.Record{"name": "Ada"}).
+//	    Update("users", "u1", ekodb.Record{"status": "active"}).
+//	    Delete("sessions", "s9").
+//	    Execute(ctx)
+//
+// On servers that advertise the "bulk" feature the operations go in one
+// request; elsewhere Execute sends them one at a time, in order. Either way
+// the operations are not atomic: use a transaction for that.
+type Pipeline struct {
+	client *Client
+	ops    []pipelineOp
+}
+
+// pipelineOp is one operation in the bulk envelope.
+type pipelineOp struct {
+	Op         string `json:"op" msgpack:"op"`
+	Collection string `json:"collection" msgpack:"collection"`
+	ID         string `json:"id,omitempty" msgpack:"id,omitempty"`
+	Data       Record `json:"data,omitempty" msgpack:"data,omitempty"`
+}
+
+// PipelineResult is the outcome of one pipelined operation.
+type PipelineResult struct {
+	Op         string // "insert", "update", or "delete"
+	Collection string
+	ID         string
+	// Record is the inserted or updated record, as Insert and Update return it.
+	Record Record
+	// Err is the operation's error; the other operations are unaffected.
+	Err error
+}
+
+// Pipeline starts an empty pipeline.
+func (c *Client) Pipeline(opts ...RequestOption) *Pipeline {
+	c = c.withOptions(opts)
+	return &Pipeline{client: c}
+}
+
+// Insert queues an insert of record
+func (p *Pipeline) Insert(collection string, record Record) *Pipeline {
+	p.ops = append(p.ops, pipelineOp{Op: "insert", Collection: collection, Data: record})
+	return p
+}
+
+// Update queues an update of record id
+func (p *Pipeline) Update(collection, id string, record Record) *Pipeline {
+	p.ops = append(p.ops, pipelineOp{Op: "update", Collection: collection, ID: id, Data: record})
+	return p
+}
+
+// Delete queues a delete of record id
+func (p *Pipeline) Delete(collection, id string) *Pipeline {
+	p.ops = append(p.ops, pipelineOp{Op: "delete", Collection: collection, ID: id})
+	return p
+}
+
+// Len returns the number of queued operations.
+func (p *Pipeline) Len() int {
+	return len(p.ops)
+}
+
+// Execute sends the queued operations and returns one result per operation,
+// in order. The error is non-nil only if the pipeline could not be sent at
+// all, or a record failed client-side schema validation (in which case
+// nothing is sent); failures of single operations are in their results.
+func (p *Pipeline) Execute(ctx context.Context) ([]PipelineResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	c := p.client.WithContext(ctx)
+	for i, op := range p.ops {
+		if op.Data == nil {
+			continue
+		}
+		c.softSchemaObserve(op.Collection, op.Data)
+		if err := c.validateWrite(op.Collection, op.Op == "update", op.Data); err != nil {
+			return nil, fmt.Errorf("pipeline operation %d (%s %s): %w", i, op.Op, op.Collection, err)
+		}
+	}
+	if len(p.ops) == 0 {
+		return nil, nil
+	}
+	if c.serverFeature(bulkFeature) {
+		return c.executeBulk(p.ops)
+	}
+
+	results := make([]PipelineResult, len(p.ops))
+	for i, op := range p.ops {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		r := PipelineResult{Op: op.Op, Collection: op.Collection, ID: op.ID}
+		switch op.Op {
+		case "insert":
+			// Execute already ran soft schema and validation on op.Data.
+			r.Record, r.Err = c.insert(op.Collection, op.Data)
+			if r.Err == nil {
+				r.ID = c.ExtractRecordID(op.Collection, r.Record)
+			}
+		case "update":
+			r.Record, r.Err = c.update(op.Collection, op.ID, op.Data)
+		case "delete":
+			r.Err = c.Delete(op.Collection, op.ID)
+		}
+		results[i] = r
+	}
+	return results, nil
+}
+
+// executeBulk sends ops in one request to the bulk endpoint.
+func (c *Client) executeBulk(ops []pipelineOp) ([]PipelineResult, error) {
+	path := "/api/bulk"
+	respBody, err := c.makeRequest("POST", path, map[string]interface{}{"operations": ops})
+	if err != nil {
+		return nil, err
+	}
+	var response struct {
+		Results []struct {
+			ID     string `json:"id" msgpack:"id"`
+			Record Record `json:"record" msgpack:"record"`
+			Error  string `json:"error" msgpack:"error"`
+		} `json:"results" msgpack:"results"`
+	}
+	if err := c.unmarshal(path, respBody, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Results) != len(ops) {
+		return nil, fmt.Errorf("bulk: %d results for %d operations", len(response.Results), len(ops))
+	}
+	results := make([]PipelineResult, len(ops))
+	for i, op := range ops {
+		res := response.Results[i]
+		if res.Record != nil {
+			c.finishRecord(res.Record)
+		}
+		r := PipelineResult{Op: op.Op, Collection: op.Collection, ID: op.ID, Record: res.Record}
+		if res.ID != "" {
+			r.ID = res.ID
+		}
+		if res.Error != "" {
+			r.Err = fmt.Errorf("%s %s: %s", op.Op, op.Collection, res.Error)
+		}
+		results[i] = r
+	}
+	return results, nil
+}
@@ -0,0 +1,128 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPipelineBulk(t *testing.T) {
+	var body struct {
+		Operations []map[string]interface{} `json:"operations"`
+	}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"bulk"}})
+		},
+		"POST /api/bulk": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": []map[string]interface{}{
+				{"id": "u1", "record": map[string]interface{}{"id": "u1", "name": "Ada"}},
+				{"id": "u2", "error": "not found"},
+				{"id": "s9"},
+			}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	results, err := client.Pipeline().
+		Insert("users", Record{"name": "Ada"}).
+		Update("users", "u2", Record{"status": "active"}).
+		Delete("sessions", "s9").
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(body.Operations) != 3 || body.Operations[1]["op"] != "update" || body.Operations[2]["id"] != "s9" {
+		t.Errorf("unexpected operations %+v", body.Operations)
+	}
+	if results[0].ID != "u1" || results[0].Record["name"] != "Ada" || results[0].Err != nil {
+		t.Errorf("insert result %+v", results[0])
+	}
+	if results[1].Err == nil || results[2].Err != nil {
+		t.Errorf("unexpected errors: %v, %v", results[1].Err, results[2].Err)
+	}
+}
+
+func TestPipelineBulkFinishesRecords(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"bulk"}})
+		},
+		"POST /api/bulk": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": []map[string]interface{}{
+				{"id": "u1", "record": map[string]interface{}{"id": "u1", "name": map[string]interface{}{"type": "String", "value": "Ada"}}},
+			}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server).With(WithAutoExtract(true))
+
+	results, err := client.Pipeline().Insert("users", Record{"name": "Ada"}).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if results[0].Record["name"] != "Ada" {
+		t.Errorf("bulk record was not auto-extracted: %v", results[0].Record)
+	}
+}
+
+func TestPipelineFallback(t *testing.T) {
+	var order []string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0"})
+		},
+		"POST /api/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "insert")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "u1"})
+		},
+		"DELETE /api/delete/users/u1": func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "delete")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	results, err := client.Pipeline().
+		Insert("users", Record{"name": "Ada"}).
+		Delete("users", "u1").
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(order) != 2 || order[0] != "insert" {
+		t.Errorf("order = %v", order)
+	}
+	var httpErr *HTTPError
+	if results[0].ID != "u1" || !errors.As(results[1].Err, &httpErr) || !httpErr.IsNotFound() {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+func TestPipelineNilContext(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0"})
+		},
+		"POST /api/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "u1"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	// Must not panic on a nil context.
+	results, err := client.Pipeline().Insert("users", Record{"name": "Ada"}).Execute(nil)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "u1" {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
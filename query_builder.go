@@ -1,7 +1,10 @@
 // Package ekodb provides a Go client for ekoDB
 package ekodb
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"log"
+)
 
 // SortOrder represents the sort direction
 type SortOrder string
@@ -19,11 +22,16 @@ type QueryBuilder struct {
 	sortFields    []map[string]interface{}
 	limit         *int
 	skip          *int
+	sample        *int
+	vectorNear    map[string]interface{}
 	join          map[string]interface{}
+	joins         []JoinConfig
 	bypassCache   bool
 	bypassRipple  bool
 	selectFields  []string
 	excludeFields []string
+	// err is the validation error found by the last Build.
+	err error
 }
 
 // NewQueryBuilder creates a new QueryBuilder
@@ -177,6 +185,151 @@ func (qb *QueryBuilder) EndsWith(field string, suffix string) *QueryBuilder {
 	return qb
 }
 
+// ContainsAny adds a filter matching Array/Set fields that contain at least
+// one of values (ContainsAny operator)
+func (qb *QueryBuilder) ContainsAny(field string, values []interface{}) *QueryBuilder {
+	qb.filters = append(qb.filters, map[string]interface{}{
+		"type": "Condition",
+		"content": map[string]interface{}{
+			"field":    field,
+			"operator": "ContainsAny",
+			"value":    values,
+		},
+	})
+	return qb
+}
+
+// ContainsAll adds a filter matching Array/Set fields that contain every one
+// of values (ContainsAll operator)
+func (qb *QueryBuilder) ContainsAll(field string, values []interface{}) *QueryBuilder {
+	qb.filters = append(qb.filters, map[string]interface{}{
+		"type": "Condition",
+		"content": map[string]interface{}{
+			"field":    field,
+			"operator": "ContainsAll",
+			"value":    values,
+		},
+	})
+	return qb
+}
+
+// ElemMatch adds a filter matching Array fields with at least one element
+// that satisfies all of match's filters (ElemMatch operator). Fields in match
+// are relative to the element:
+//
+//	// orders with an item of sku "A1" and quantity over 2
+//	qb.ElemMatch("items", ekodb.NewQueryBuilder().Eq("sku", "A1").Gt("qty", 2))
+//
+// Only match's filters are used; its sort, limit, and other options are
+// ignored.
+func (qb *QueryBuilder) ElemMatch(field string, match *QueryBuilder) *QueryBuilder {
+	qb.filters = append(qb.filters, map[string]interface{}{
+		"type": "Condition",
+		"content": map[string]interface{}{
+			"field":    field,
+			"operator": "ElemMatch",
+			"value":    match.filterExpression(),
+		},
+	})
+	return qb
+}
+
+// NearPoint adds a filter matching records whose geo point field lies within
+// radiusMeters of (lat, lon) (GeoNear operator). The field holds an object
+// with "lat" and "lon" members, as built by FieldGeoPoint.
+func (qb *QueryBuilder) NearPoint(field string, lat, lon, radiusMeters float64) *QueryBuilder {
+	qb.filters = append(qb.filters, map[string]interface{}{
+		"type": "Condition",
+		"content": map[string]interface{}{
+			"field":    field,
+			"operator": "GeoNear",
+			"value": map[string]interface{}{
+				"lat":           lat,
+				"lon":           lon,
+				"radius_meters": radiusMeters,
+			},
+		},
+	})
+	return qb
+}
+
+// WithinBox adds a filter matching records whose geo point field lies inside
+// the box with south-west corner (minLat, minLon) and north-east corner
+// (maxLat, maxLon) (GeoWithinBox operator). A box whose minLon is greater
+// than its maxLon crosses the antimeridian.
+func (qb *QueryBuilder) WithinBox(field string, minLat, minLon, maxLat, maxLon float64) *QueryBuilder {
+	qb.filters = append(qb.filters, map[string]interface{}{
+		"type": "Condition",
+		"content": map[string]interface{}{
+			"field":    field,
+			"operator": "GeoWithinBox",
+			"value": map[string]interface{}{
+				"min_lat": minLat,
+				"min_lon": minLon,
+				"max_lat": maxLat,
+				"max_lon": maxLon,
+			},
+		},
+	})
+	return qb
+}
+
+// TextMatchOptions tunes a TextMatches condition. The zero value matches
+// records containing any of the query's terms, exactly (after the server's
+// normal tokenization).
+type TextMatchOptions struct {
+	// MatchAll requires every term of the query instead of any one
+	MatchAll bool
+	// Fuzzy tolerates typos, up to MaxEditDistance edits per term when set
+	Fuzzy           bool
+	MaxEditDistance int
+	// Language selects stemming rules, e.g. "english"; empty uses the
+	// collection's default
+	Language string
+}
+
+// TextMatches adds a full-text condition (TextMatch operator), matching
+// records whose field contains the terms of text, so keyword matching can be
+// combined with structured filters in one Find:
+//
+//	qb.Eq("in_stock", true).TextMatches("description", "wireless headphones")
+//
+// Unlike Search, results are not ranked by relevance; use a sort as usual.
+func (qb *QueryBuilder) TextMatches(field, text string, opts ...TextMatchOptions) *QueryBuilder {
+	value := map[string]interface{}{"query": text}
+	if len(opts) > 0 {
+		o := opts[0]
+		if o.MatchAll {
+			value["match_all"] = true
+		}
+		if o.Fuzzy {
+			value["fuzzy"] = true
+		}
+		if o.MaxEditDistance > 0 {
+			value["max_edit_distance"] = o.MaxEditDistance
+		}
+		if o.Language != "" {
+			value["language"] = o.Language
+		}
+	}
+	qb.filters = append(qb.filters, map[string]interface{}{
+		"type": "Condition",
+		"content": map[string]interface{}{
+			"field":    field,
+			"operator": "TextMatch",
+			"value":    value,
+		},
+	})
+	return qb
+}
+
+// Where adds filter expressions, such as those built by TypedField, which are
+// ANDed with the other filters
+func (qb *QueryBuilder) Where(filters ...map[string]interface{}) *QueryBuilder {
+	qb.filters = append(qb.filters, filters...)
+	return qb
+}
+
 // Note: regex filtering is pending server-side support. The server has no
 // Regex filter operator; use Contains/StartsWith/EndsWith instead.
 
@@ -216,21 +369,108 @@ func (qb *QueryBuilder) Not(condition map[string]interface{}) *QueryBuilder {
 	return qb
 }
 
-// SortAscending adds a sort field in ascending order
-func (qb *QueryBuilder) SortAscending(field string) *QueryBuilder {
-	qb.sortFields = append(qb.sortFields, map[string]interface{}{
-		"field":     field,
-		"ascending": true,
-	})
+// OrGroup adds a group of filters combined with OR logic. build receives a
+// fresh builder; each filter added to it becomes one alternative, and groups
+// nest to any depth:
+//
+//	// status = "active" AND (role = "admin" OR (team = "ops" AND level >= 3))
+//	qb.Eq("status", "active").OrGroup(func(g *ekodb.QueryBuilder) {
+//	    g.Eq("role", "admin").AndGroup(func(g *ekodb.QueryBuilder) {
+//	        g.Eq("team", "ops").Gte("level", 3)
+//	    })
+//	})
+//
+// An empty group adds nothing, and a group of one adds that filter alone.
+func (qb *QueryBuilder) OrGroup(build func(g *QueryBuilder)) *QueryBuilder {
+	return qb.group("Or", build)
+}
+
+// AndGroup adds a group of filters combined with AND logic, for use inside
+// OrGroup and NotGroup (top-level filters are already ANDed)
+func (qb *QueryBuilder) AndGroup(build func(g *QueryBuilder)) *QueryBuilder {
+	return qb.group("And", build)
+}
+
+// NotGroup adds the negation of a group of filters, which are ANDed together
+// before being negated
+func (qb *QueryBuilder) NotGroup(build func(g *QueryBuilder)) *QueryBuilder {
+	g := NewQueryBuilder()
+	build(g)
+	if expr := g.filterExpression(); expr != nil {
+		qb.Not(expr)
+	}
+	return qb
+}
+
+func (qb *QueryBuilder) group(operator string, build func(g *QueryBuilder)) *QueryBuilder {
+	g := NewQueryBuilder()
+	build(g)
+	switch len(g.filters) {
+	case 0:
+	case 1:
+		qb.filters = append(qb.filters, g.filters[0])
+	default:
+		qb.filters = append(qb.filters, map[string]interface{}{
+			"type": "Logical",
+			"content": map[string]interface{}{
+				"operator":    operator,
+				"expressions": g.filters,
+			},
+		})
+	}
 	return qb
 }
 
-// SortDescending adds a sort field in descending order
-func (qb *QueryBuilder) SortDescending(field string) *QueryBuilder {
-	qb.sortFields = append(qb.sortFields, map[string]interface{}{
+// NullsOrder places records whose sort field is null or missing
+type NullsOrder string
+
+const (
+	// NullsFirst sorts null and missing values before all others
+	NullsFirst NullsOrder = "first"
+	// NullsLast sorts null and missing values after all others
+	NullsLast NullsOrder = "last"
+)
+
+// SortOptions adjusts how one sort field orders records. The zero value keeps
+// the server's default ordering.
+type SortOptions struct {
+	// Nulls places null and missing values first or last regardless of the
+	// sort direction. Empty keeps the server default.
+	Nulls NullsOrder
+
+	// CaseInsensitive compares strings without regard to case, so "apple"
+	// sorts next to "Apple" rather than after every capitalized value.
+	CaseInsensitive bool
+}
+
+// SortAscending adds a sort field in ascending order, with optional
+// null ordering and collation:
+//
+//	qb.SortAscending("name", ekodb.SortOptions{Nulls: ekodb.NullsLast, CaseInsensitive: true})
+func (qb *QueryBuilder) SortAscending(field string, opts ...SortOptions) *QueryBuilder {
+	return qb.sort(field, true, opts)
+}
+
+// SortDescending adds a sort field in descending order, with optional
+// null ordering and collation
+func (qb *QueryBuilder) SortDescending(field string, opts ...SortOptions) *QueryBuilder {
+	return qb.sort(field, false, opts)
+}
+
+func (qb *QueryBuilder) sort(field string, ascending bool, opts []SortOptions) *QueryBuilder {
+	entry := map[string]interface{}{
 		"field":     field,
-		"ascending": false,
-	})
+		"ascending": ascending,
+	}
+	if len(opts) > 0 {
+		if opts[0].Nulls != "" {
+			entry["nulls"] = string(opts[0].Nulls)
+		}
+		if opts[0].CaseInsensitive {
+			entry["case_insensitive"] = true
+		}
+	}
+	qb.sortFields = append(qb.sortFields, entry)
 	return qb
 }
 
@@ -261,12 +501,24 @@ func (qb *QueryBuilder) Page(page, pageSize int) *QueryBuilder {
 	return qb
 }
 
-// Join adds a join configuration
+// Join sets a raw join configuration, replacing any set before. Joins added
+// with JoinWith are kept alongside it.
 func (qb *QueryBuilder) Join(joinConfig map[string]interface{}) *QueryBuilder {
 	qb.join = joinConfig
 	return qb
 }
 
+// JoinWith adds a join. Several joins run in order, so a later join can use a
+// field an earlier one added:
+//
+//	qb.JoinWith(ekodb.NewSingleJoin("customers", "customer_id", "id", "customer").Select("name", "tier")).
+//	    JoinWith(ekodb.NewSingleJoin("addresses", "customer_id", "customer_id", "customer.address").
+//	        Where(ekodb.NewQueryBuilder().Eq("primary", true)))
+func (qb *QueryBuilder) JoinWith(join JoinConfig) *QueryBuilder {
+	qb.joins = append(qb.joins, join)
+	return qb
+}
+
 // BypassCache bypasses cache for this query
 func (qb *QueryBuilder) BypassCache(bypass bool) *QueryBuilder {
 	qb.bypassCache = bypass
@@ -296,23 +548,29 @@ func (qb *QueryBuilder) ExcludeFields(fields ...string) *QueryBuilder {
 	return qb
 }
 
-// Build builds the final query map
+// Build builds the final query map, running Validate first. A query that
+// fails validation is logged, its error kept for Err, and built anyway, since
+// Build has no error to return; BuildValidated returns the error instead, as
+// do Find, Count, and the other methods when passed the *QueryBuilder itself.
 func (qb *QueryBuilder) Build() map[string]interface{} {
+	qb.err = qb.Validate()
+	if qb.err != nil {
+		log.Printf("ekodb: QueryBuilder.Build: %v", qb.err)
+	}
+	return qb.build()
+}
+
+// Err returns the validation error found by the last Build, or nil
+func (qb *QueryBuilder) Err() error {
+	return qb.err
+}
+
+// build builds the final query map without validating it.
+func (qb *QueryBuilder) build() map[string]interface{} {
 	query := make(map[string]interface{})
 
-	// Combine all filters with AND logic if multiple filters exist
-	if len(qb.filters) > 0 {
-		if len(qb.filters) == 1 {
-			query["filter"] = qb.filters[0]
-		} else {
-			query["filter"] = map[string]interface{}{
-				"type": "Logical",
-				"content": map[string]interface{}{
-					"operator":    "And",
-					"expressions": qb.filters,
-				},
-			}
-		}
+	if filter := qb.filterExpression(); filter != nil {
+		query["filter"] = filter
 	}
 
 	// Add sort fields
@@ -327,10 +585,18 @@ func (qb *QueryBuilder) Build() map[string]interface{} {
 	if qb.skip != nil {
 		query["skip"] = *qb.skip
 	}
+	if qb.sample != nil {
+		query["sample"] = *qb.sample
+	}
+	if qb.vectorNear != nil {
+		query["vector_near"] = qb.vectorNear
+	}
 
-	// Add join
-	if qb.join != nil {
-		query["join"] = qb.join
+	// Add join: a single join is sent as an object, several as a list
+	if joins := qb.joinMaps(); len(joins) == 1 {
+		query["join"] = joins[0]
+	} else if len(joins) > 1 {
+		query["join"] = joins
 	}
 
 	// Add bypass flags
@@ -352,8 +618,63 @@ func (qb *QueryBuilder) Build() map[string]interface{} {
 	return query
 }
 
-// BuildJSON builds the final query as JSON bytes
+// joinMaps returns the raw join, if any, followed by the JoinWith joins.
+func (qb *QueryBuilder) joinMaps() []map[string]interface{} {
+	var joins []map[string]interface{}
+	if qb.join != nil {
+		joins = append(joins, qb.join)
+	}
+	for _, j := range qb.joins {
+		joins = append(joins, j.ToMap())
+	}
+	return joins
+}
+
+// filterExpression returns the builder's filters as one expression, combined
+// with AND logic if there are several, or nil if there are none.
+func (qb *QueryBuilder) filterExpression() map[string]interface{} {
+	switch len(qb.filters) {
+	case 0:
+		return nil
+	case 1:
+		return qb.filters[0]
+	}
+	return map[string]interface{}{
+		"type": "Logical",
+		"content": map[string]interface{}{
+			"operator":    "And",
+			"expressions": qb.filters,
+		},
+	}
+}
+
+// filterOf normalizes a filter argument that may be nil, a *QueryBuilder, or
+// a filter expression into a filter expression, or nil for none.
+func filterOf(filter interface{}) interface{} {
+	if qb, ok := filter.(*QueryBuilder); ok {
+		if expr := qb.filterExpression(); expr != nil {
+			return expr
+		}
+		return nil
+	}
+	return filter
+}
+
+// BuildValidated builds the final query map, or returns the
+// *QueryValidationError if Validate finds problems.
+func (qb *QueryBuilder) BuildValidated() (map[string]interface{}, error) {
+	if qb.err = qb.Validate(); qb.err != nil {
+		return nil, qb.err
+	}
+	return qb.build(), nil
+}
+
+// BuildJSON builds the final query as JSON bytes, returning the
+// *QueryValidationError if Validate finds problems
 func (qb *QueryBuilder) BuildJSON() ([]byte, error) {
-	query := qb.Build()
+	query, err := qb.BuildValidated()
+	if err != nil {
+		return nil, err
+	}
 	return json.Marshal(query)
 }
@@ -2,6 +2,8 @@ package ekodb
 
 import (
 	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -141,6 +143,106 @@ func TestQueryBuilderIn(t *testing.T) {
 	}
 }
 
+func TestQueryBuilderContainsAnyAll(t *testing.T) {
+	query := NewQueryBuilder().
+		ContainsAny("tags", []interface{}{"go", "rust"}).
+		ContainsAll("roles", []interface{}{"admin", "owner"}).
+		Build()
+
+	filter := query["filter"].(map[string]interface{})
+	expressions := filter["content"].(map[string]interface{})["expressions"].([]map[string]interface{})
+	for i, want := range []string{"ContainsAny", "ContainsAll"} {
+		content := expressions[i]["content"].(map[string]interface{})
+		if content["operator"] != want || len(content["value"].([]interface{})) != 2 {
+			t.Errorf("expression %d = %v, want operator %s", i, content, want)
+		}
+	}
+}
+
+func TestQueryBuilderElemMatch(t *testing.T) {
+	query := NewQueryBuilder().
+		ElemMatch("items", NewQueryBuilder().Eq("sku", "A1").Gt("qty", 2).Limit(99)).
+		Build()
+
+	content := query["filter"].(map[string]interface{})["content"].(map[string]interface{})
+	if content["field"] != "items" || content["operator"] != "ElemMatch" {
+		t.Fatalf("unexpected condition %v", content)
+	}
+	match := content["value"].(map[string]interface{})
+	sub := match["content"].(map[string]interface{})
+	if match["type"] != "Logical" || sub["operator"] != "And" || len(sub["expressions"].([]map[string]interface{})) != 2 {
+		t.Errorf("unexpected element match %v", match)
+	}
+
+	single := NewQueryBuilder().ElemMatch("tags", NewQueryBuilder().StartsWith("", "go")).Build()
+	value := single["filter"].(map[string]interface{})["content"].(map[string]interface{})["value"].(map[string]interface{})
+	if value["type"] != "Condition" {
+		t.Errorf("a single sub-condition should not be wrapped, got %v", value)
+	}
+}
+
+func TestQueryBuilderGroups(t *testing.T) {
+	query := NewQueryBuilder().
+		Eq("status", "active").
+		OrGroup(func(g *QueryBuilder) {
+			g.Eq("role", "admin").AndGroup(func(g *QueryBuilder) {
+				g.Eq("team", "ops").Gte("level", 3)
+			})
+		}).
+		NotGroup(func(g *QueryBuilder) {
+			g.Eq("banned", true)
+		}).
+		OrGroup(func(g *QueryBuilder) {}).
+		Build()
+
+	top := query["filter"].(map[string]interface{})["content"].(map[string]interface{})
+	expressions := top["expressions"].([]map[string]interface{})
+	if len(expressions) != 3 {
+		t.Fatalf("expected 3 top-level expressions (empty group dropped), got %d", len(expressions))
+	}
+
+	or := expressions[1]["content"].(map[string]interface{})
+	alternatives := or["expressions"].([]map[string]interface{})
+	if or["operator"] != "Or" || len(alternatives) != 2 {
+		t.Fatalf("unexpected OR group %v", or)
+	}
+	and := alternatives[1]["content"].(map[string]interface{})
+	if and["operator"] != "And" || len(and["expressions"].([]map[string]interface{})) != 2 {
+		t.Errorf("unexpected nested AND group %v", and)
+	}
+
+	not := expressions[2]["content"].(map[string]interface{})
+	negated := not["expressions"].([]map[string]interface{})
+	if not["operator"] != "Not" || len(negated) != 1 || negated[0]["type"] != "Condition" {
+		t.Errorf("unexpected NOT group %v", not)
+	}
+}
+
+func TestQueryBuilderGeo(t *testing.T) {
+	query := NewQueryBuilder().
+		NearPoint("location", 51.5, -0.12, 2000).
+		WithinBox("location", 51, -1, 52, 1).
+		Build()
+
+	expressions := query["filter"].(map[string]interface{})["content"].(map[string]interface{})["expressions"].([]map[string]interface{})
+	near := expressions[0]["content"].(map[string]interface{})
+	if near["operator"] != "GeoNear" || near["field"] != "location" {
+		t.Errorf("unexpected near condition %v", near)
+	}
+	if v := near["value"].(map[string]interface{}); v["lat"] != 51.5 || v["lon"] != -0.12 || v["radius_meters"] != 2000.0 {
+		t.Errorf("unexpected near value %v", v)
+	}
+	box := expressions[1]["content"].(map[string]interface{})
+	if v := box["value"].(map[string]interface{}); box["operator"] != "GeoWithinBox" || v["min_lat"] != 51.0 || v["max_lon"] != 1.0 {
+		t.Errorf("unexpected box condition %v", box)
+	}
+
+	point := FieldGeoPoint(51.5, -0.12)
+	if point["type"] != "Object" || GetObjectValue(point)["lat"] != 51.5 {
+		t.Errorf("unexpected geo point %v", point)
+	}
+}
+
 func TestQueryBuilderNin(t *testing.T) {
 	qb := NewQueryBuilder().Nin("role", []interface{}{"blocked", "deleted"})
 	query := qb.Build()
@@ -356,6 +458,59 @@ func TestQueryBuilderMultipleSorts(t *testing.T) {
 	}
 }
 
+func TestQueryBuilderSortOptions(t *testing.T) {
+	qb := NewQueryBuilder().
+		SortAscending("name", SortOptions{Nulls: NullsLast, CaseInsensitive: true}).
+		SortDescending("rank", SortOptions{Nulls: NullsFirst}).
+		SortAscending("id")
+
+	sort := qb.Build()["sort"].([]map[string]interface{})
+	if sort[0]["nulls"] != "last" || sort[0]["case_insensitive"] != true {
+		t.Errorf("Unexpected first sort field %v", sort[0])
+	}
+	if sort[1]["nulls"] != "first" || sort[1]["ascending"] != false {
+		t.Errorf("Unexpected second sort field %v", sort[1])
+	}
+	if _, ok := sort[1]["case_insensitive"]; ok {
+		t.Errorf("case_insensitive should be omitted, got %v", sort[1])
+	}
+	if len(sort[2]) != 2 {
+		t.Errorf("Plain sort should only have field and ascending, got %v", sort[2])
+	}
+
+	if err := NewQueryBuilder().SortAscending("name", SortOptions{Nulls: "middle"}).Validate(); err == nil {
+		t.Error("Expected invalid nulls order to fail validation")
+	}
+}
+
+func TestQueryBuilderTextMatches(t *testing.T) {
+	query := NewQueryBuilder().
+		Eq("in_stock", true).
+		TextMatches("description", "wireless headphones", TextMatchOptions{MatchAll: true, Fuzzy: true, MaxEditDistance: 1}).
+		Build()
+
+	exprs := query["filter"].(map[string]interface{})["content"].(map[string]interface{})["expressions"].([]map[string]interface{})
+	content := exprs[1]["content"].(map[string]interface{})
+	if content["operator"] != "TextMatch" || content["field"] != "description" {
+		t.Errorf("Unexpected condition %v", content)
+	}
+	value := content["value"].(map[string]interface{})
+	if value["query"] != "wireless headphones" || value["match_all"] != true || value["fuzzy"] != true || value["max_edit_distance"] != 1 {
+		t.Errorf("Unexpected value %v", value)
+	}
+	if _, ok := value["language"]; ok {
+		t.Errorf("Empty language should be omitted, got %v", value)
+	}
+
+	plain := NewQueryBuilder().TextMatches("title", "go")
+	if v := plain.filters[0]["content"].(map[string]interface{})["value"]; len(v.(map[string]interface{})) != 1 {
+		t.Errorf("Default options should only send the query, got %v", v)
+	}
+	if err := NewQueryBuilder().TextMatches("title", "  ").Validate(); err == nil {
+		t.Error("Expected an empty text query to fail validation")
+	}
+}
+
 // ============================================================================
 // Pagination Tests
 // ============================================================================
@@ -423,6 +578,40 @@ func TestQueryBuilderJoin(t *testing.T) {
 	}
 }
 
+func TestQueryBuilderJoinWith(t *testing.T) {
+	customers := NewSingleJoin("customers", "customer_id", "id", "customer").Select("name", "tier")
+	qb := NewQueryBuilder().JoinWith(customers)
+
+	join := qb.Build()["join"].(map[string]interface{})
+	if join["as_field"] != "customer" || !reflect.DeepEqual(join["select_fields"], []string{"name", "tier"}) {
+		t.Errorf("Unexpected single join %v", join)
+	}
+	if _, ok := join["filter"]; ok {
+		t.Errorf("Unset filter should be omitted, got %v", join)
+	}
+
+	addresses := NewSingleJoin("addresses", "customer_id", "customer_id", "customer.address").
+		Where(NewQueryBuilder().Eq("primary", true))
+	joins := qb.JoinWith(addresses).Build()["join"].([]map[string]interface{})
+	if len(joins) != 2 || joins[1]["as_field"] != "customer.address" {
+		t.Fatalf("Expected two joins, got %v", joins)
+	}
+	filter := joins[1]["filter"].(map[string]interface{})["content"].(map[string]interface{})
+	if filter["field"] != "primary" {
+		t.Errorf("Unexpected join filter %v", filter)
+	}
+
+	if err := qb.Validate(); err != nil {
+		t.Errorf("Valid joins rejected: %v", err)
+	}
+	bad := NewQueryBuilder().JoinWith(NewSingleJoin("orders", "", "id", "customer..orders"))
+	err := bad.Validate()
+	if err == nil || !strings.Contains(err.Error(), "join[0]: empty local_field") ||
+		!strings.Contains(err.Error(), `join[0]: as_field "customer..orders" has an empty path segment`) {
+		t.Errorf("Unexpected validation error %v", err)
+	}
+}
+
 // ============================================================================
 // Bypass Flags Tests
 // ============================================================================
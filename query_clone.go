@@ -0,0 +1,106 @@
+package ekodb
+
+// Clone returns a deep copy of the builder, so a base query can be extended
+// per call without the copies sharing filters, sorts, or options:
+//
+//	base := ekodb.NewQueryBuilder().Eq("tenant_id", tenant).Eq("deleted", false)
+//	active := base.Clone().Eq("status", "active").Limit(50)
+//	recent := base.Clone().SortDescending("created_at").Limit(10)
+func (qb *QueryBuilder) Clone() *QueryBuilder {
+	clone := &QueryBuilder{
+		filters:       copyQueryMaps(qb.filters),
+		sortFields:    copyQueryMaps(qb.sortFields),
+		limit:         copyIntPtr(qb.limit),
+		skip:          copyIntPtr(qb.skip),
+		sample:        copyIntPtr(qb.sample),
+		bypassCache:   qb.bypassCache,
+		bypassRipple:  qb.bypassRipple,
+		selectFields:  append([]string(nil), qb.selectFields...),
+		excludeFields: append([]string(nil), qb.excludeFields...),
+		joins:         copyJoins(qb.joins),
+	}
+	if qb.vectorNear != nil {
+		clone.vectorNear = copyQueryValue(qb.vectorNear).(map[string]interface{})
+	}
+	if qb.join != nil {
+		clone.join = copyQueryValue(qb.join).(map[string]interface{})
+	}
+	return clone
+}
+
+// Merge adds other's filters, sort fields, and JoinWith joins after qb's and
+// takes each of other's options that is set (limit, skip, sample, VectorNear,
+// join, and projections), so other wins where both set one. Bypass flags set
+// on either are kept. other is copied and can be reused. Merge modifies qb;
+// merge into a Clone to keep a base query unchanged:
+//
+//	query := base.Clone().Merge(perRequest)
+func (qb *QueryBuilder) Merge(other *QueryBuilder) *QueryBuilder {
+	if other == nil {
+		return qb
+	}
+	o := other.Clone()
+	qb.filters = append(qb.filters, o.filters...)
+	qb.sortFields = append(qb.sortFields, o.sortFields...)
+	if o.limit != nil {
+		qb.limit = o.limit
+	}
+	if o.skip != nil {
+		qb.skip = o.skip
+	}
+	if o.sample != nil {
+		qb.sample = o.sample
+	}
+	if o.vectorNear != nil {
+		qb.vectorNear = o.vectorNear
+	}
+	if o.join != nil {
+		qb.join = o.join
+	}
+	qb.joins = append(qb.joins, o.joins...)
+	if len(o.selectFields) > 0 {
+		qb.selectFields = o.selectFields
+	}
+	if len(o.excludeFields) > 0 {
+		qb.excludeFields = o.excludeFields
+	}
+	qb.bypassCache = qb.bypassCache || o.bypassCache
+	qb.bypassRipple = qb.bypassRipple || o.bypassRipple
+	return qb
+}
+
+// copyQueryValue deep-copies the maps and slices of a built query.
+func copyQueryValue(v interface{}) interface{} {
+	return bindQueryValue(v, func(p QueryParam) interface{} { return p })
+}
+
+func copyQueryMaps(maps []map[string]interface{}) []map[string]interface{} {
+	out := make([]map[string]interface{}, len(maps))
+	for i, m := range maps {
+		out[i] = copyQueryValue(m).(map[string]interface{})
+	}
+	return out
+}
+
+func copyJoins(joins []JoinConfig) []JoinConfig {
+	if joins == nil {
+		return nil
+	}
+	out := make([]JoinConfig, len(joins))
+	for i, j := range joins {
+		j.Collections = append([]string(nil), j.Collections...)
+		j.SelectFields = append([]string(nil), j.SelectFields...)
+		j.ExcludeFields = append([]string(nil), j.ExcludeFields...)
+		j.Filter = copyQueryValue(j.Filter)
+		out[i] = j
+	}
+	return out
+}
+
+func copyIntPtr(p *int) *int {
+	if p == nil {
+		return nil
+	}
+	n := *p
+	return &n
+}
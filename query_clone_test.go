@@ -0,0 +1,57 @@
+package ekodb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryBuilderClone(t *testing.T) {
+	// Capacity beyond length is what makes a shared append unsafe.
+	base := NewQueryBuilder().Eq("tenant_id", "t1").Eq("deleted", false)
+	base.filters = append(make([]map[string]interface{}, 0, 8), base.filters...)
+	before := base.Build()
+
+	a := base.Clone().Eq("status", "active").Limit(10)
+	b := base.Clone().Eq("status", "archived").SortDescending("created_at")
+
+	if !reflect.DeepEqual(base.Build(), before) {
+		t.Errorf("base changed: %v", base.Build())
+	}
+	aFilters := a.Build()["filter"].(map[string]interface{})["content"].(map[string]interface{})["expressions"].([]map[string]interface{})
+	if len(aFilters) != 3 || aFilters[2]["content"].(map[string]interface{})["value"] != "active" {
+		t.Errorf("unexpected clone filters %v", aFilters)
+	}
+	if _, ok := b.Build()["limit"]; ok {
+		t.Error("limit leaked between clones")
+	}
+
+	// Nested values are copied too.
+	a.filters[0]["content"].(map[string]interface{})["value"] = "t2"
+	if base.filters[0]["content"].(map[string]interface{})["value"] != "t1" {
+		t.Error("clone shares filter maps with base")
+	}
+}
+
+func TestQueryBuilderMerge(t *testing.T) {
+	base := NewQueryBuilder().Eq("tenant_id", "t1").SortAscending("name").Limit(100).BypassCache(true)
+	extra := NewQueryBuilder().Gt("age", 18).SortDescending("age").Limit(10).SelectFields("name")
+
+	merged := base.Clone().Merge(extra)
+	want := NewQueryBuilder().Eq("tenant_id", "t1").Gt("age", 18).
+		SortAscending("name").SortDescending("age").
+		Limit(10).BypassCache(true).SelectFields("name").Build()
+	if got := merged.Build(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge = %v, want %v", got, want)
+	}
+	if base.Build()["limit"] != 100 {
+		t.Error("Merge into a clone changed the base")
+	}
+
+	extra.Eq("later", true)
+	if len(merged.filters) != 2 {
+		t.Error("merged builder shares filters with other")
+	}
+	if merged.Merge(nil) != merged {
+		t.Error("Merge(nil) should return the builder")
+	}
+}
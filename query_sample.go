@@ -0,0 +1,66 @@
+package ekodb
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// randomSampleFeature is the ServerInfo feature flag for server-side
+// sampling of find results.
+const randomSampleFeature = "random_sample"
+
+// Sample makes the query return n records chosen at random from the matching
+// set, for spot checks and dataset sampling. A limit and skip still bound
+// the set sampled from, and a sort only orders it; the sample itself comes
+// back in random order.
+//
+// Servers that advertise the "random_sample" feature sample server-side.
+// Otherwise Find streams the matching records and keeps a uniform sample of
+// n in memory (reservoir sampling), so the whole set is transferred once but
+// never held.
+func (qb *QueryBuilder) Sample(n int) *QueryBuilder {
+	qb.sample = &n
+	return qb
+}
+
+// querySample returns the sample size a find query asks for, and an error if
+// it is not a positive integer.
+func querySample(query interface{}) (int, bool, error) {
+	m, ok := query.(map[string]interface{})
+	if !ok || m["sample"] == nil {
+		return 0, false, nil
+	}
+	n, ok := codecInt(m["sample"])
+	if !ok || n <= 0 {
+		return 0, false, fmt.Errorf("sample size %v is not a positive integer", m["sample"])
+	}
+	return int(n), true, nil
+}
+
+// findSample runs a sampled find client-side for servers without the
+// random_sample feature.
+func (c *Client) findSample(collection string, query map[string]interface{}, n int, opts []FindOptions) ([]Record, error) {
+	stripped := make(map[string]interface{}, len(query))
+	for k, v := range query {
+		if k != "sample" {
+			stripped[k] = v
+		}
+	}
+
+	sample := make([]Record, 0, n)
+	seen := 0
+	err := c.FindEach(collection, stripped, func(rec Record) error {
+		seen++
+		if len(sample) < n {
+			sample = append(sample, copyRecord(rec))
+		} else if i := rand.IntN(seen); i < n {
+			sample[i] = copyRecord(rec)
+		}
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	rand.Shuffle(len(sample), func(i, j int) { sample[i], sample[j] = sample[j], sample[i] })
+	return sample, nil
+}
@@ -0,0 +1,104 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestFindSampleClientFallback(t *testing.T) {
+	var sent map[string]interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{}})
+		},
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			sent = nil
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+			records := make([]map[string]interface{}, 50)
+			for i := range records {
+				records[i] = map[string]interface{}{"id": fmt.Sprintf("u%d", i)}
+			}
+			_ = json.NewEncoder(w).Encode(records)
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	records, err := client.Find("users", NewQueryBuilder().Eq("active", true).Sample(5).Build())
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(records) != 5 {
+		t.Fatalf("got %d records, want 5", len(records))
+	}
+	if _, ok := sent["sample"]; ok {
+		t.Error("sample sent to a server without the feature")
+	}
+	if sent["filter"] == nil {
+		t.Error("filter not sent")
+	}
+	ids := map[interface{}]bool{}
+	for _, r := range records {
+		ids[r["id"]] = true
+	}
+	if len(ids) != 5 {
+		t.Errorf("sample has duplicates: %v", records)
+	}
+
+	// A sample larger than the set returns all of it.
+	records, err = client.Find("users", NewQueryBuilder().Sample(80).Build())
+	if err != nil || len(records) != 50 {
+		t.Errorf("got %d records, err %v; want 50", len(records), err)
+	}
+}
+
+func TestFindSampleServerSide(t *testing.T) {
+	infoCalls := 0
+	var sent map[string]interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			infoCalls++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"random_sample"}})
+		},
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": "u1"}, {"id": "u2"}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	for i := 0; i < 2; i++ {
+		records, err := client.WithContext(t.Context()).Find("users", NewQueryBuilder().Sample(2).Build())
+		if err != nil || len(records) != 2 {
+			t.Fatalf("Find: %d records, err %v", len(records), err)
+		}
+	}
+	if sent["sample"] != float64(2) {
+		t.Errorf("sample not sent: %v", sent)
+	}
+	if infoCalls != 1 {
+		t.Errorf("server features fetched %d times, want 1", infoCalls)
+	}
+	if err := NewQueryBuilder().Sample(0).Validate(); err == nil {
+		t.Error("expected Sample(0) to fail validation")
+	}
+}
+
+func TestFindRejectsNonPositiveSample(t *testing.T) {
+	server := createTestServer(t, nil)
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	for _, query := range []interface{}{
+		map[string]interface{}{"sample": -1},
+		map[string]interface{}{"sample": 0},
+		NewQueryBuilder().Sample(-1),
+	} {
+		if _, err := client.Find("users", query); err == nil {
+			t.Errorf("Find(%v): expected an error", query)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package ekodb
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// QueryParam is a named placeholder in a query built for a QueryTemplate.
+// Create one with Param and use it anywhere a filter value goes.
+type QueryParam struct {
+	Name string
+}
+
+// Param returns a placeholder for the named parameter, bound later by
+// QueryTemplate.Bind:
+//
+//	qb.Gte("age", ekodb.Param("min_age"))
+func Param(name string) QueryParam {
+	return QueryParam{Name: name}
+}
+
+// MarshalJSON fails, so a query with unbound parameters is never sent.
+func (p QueryParam) MarshalJSON() ([]byte, error) {
+	return nil, p.unbound()
+}
+
+// EncodeMsgpack fails, like MarshalJSON.
+func (p QueryParam) EncodeMsgpack(*msgpack.Encoder) error {
+	return p.unbound()
+}
+
+func (p QueryParam) unbound() error {
+	return fmt.Errorf("query parameter %q is not bound; use QueryTemplate.Bind", p.Name)
+}
+
+// EqParam adds an equality filter whose value is the named parameter
+func (qb *QueryBuilder) EqParam(field, param string) *QueryBuilder {
+	return qb.Eq(field, Param(param))
+}
+
+// InParam adds an in-array filter whose list is the named parameter, which
+// must be bound to a slice
+func (qb *QueryBuilder) InParam(field, param string) *QueryBuilder {
+	qb.filters = append(qb.filters, map[string]interface{}{
+		"type": "Condition",
+		"content": map[string]interface{}{
+			"field":    field,
+			"operator": "In",
+			"value":    Param(param),
+		},
+	})
+	return qb
+}
+
+// QueryTemplate is a query built once with named parameters and bound to
+// values per execution. It is safe for concurrent use.
+//
+//	tmpl := ekodb.NewQueryBuilder().
+//	    EqParam("status", "status").
+//	    Gte("age", ekodb.Param("min_age")).
+//	    Limit(50).
+//	    Template()
+//
+//	query, err := tmpl.Bind(map[string]interface{}{"status": "active", "min_age": 18})
+//	records, err := client.Find("users", query)
+type QueryTemplate struct {
+	query  map[string]interface{}
+	params []string
+}
+
+// Template snapshots the builder's query as a QueryTemplate. Later changes to
+// the builder do not affect it.
+func (qb *QueryBuilder) Template() *QueryTemplate {
+	names := map[string]bool{}
+	query := bindQueryValue(qb.Build(), func(p QueryParam) interface{} {
+		names[p.Name] = true
+		return p
+	}).(map[string]interface{})
+	t := &QueryTemplate{query: query}
+	for name := range names {
+		t.params = append(t.params, name)
+	}
+	sort.Strings(t.params)
+	return t
+}
+
+// Params returns the template's parameter names, sorted.
+func (t *QueryTemplate) Params() []string {
+	return append([]string(nil), t.params...)
+}
+
+// Bind returns a copy of the query with every parameter replaced by its
+// value. It fails if a parameter has no value or if values names a parameter
+// the template does not use, which catches misspelled names.
+func (t *QueryTemplate) Bind(values map[string]interface{}) (map[string]interface{}, error) {
+	var missing, unknown []string
+	used := make(map[string]bool, len(t.params))
+	for _, name := range t.params {
+		used[name] = true
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	for name := range values {
+		if !used[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing query parameters: %s", strings.Join(missing, ", "))
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown query parameters: %s", strings.Join(unknown, ", "))
+	}
+	return bindQueryValue(t.query, func(p QueryParam) interface{} {
+		return values[p.Name]
+	}).(map[string]interface{}), nil
+}
+
+// bindQueryValue deep-copies a built query, replacing each QueryParam with
+// bind's result.
+func bindQueryValue(v interface{}, bind func(QueryParam) interface{}) interface{} {
+	switch v := v.(type) {
+	case QueryParam:
+		return bind(v)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			out[k] = bindQueryValue(e, bind)
+		}
+		return out
+	case []map[string]interface{}:
+		out := make([]map[string]interface{}, len(v))
+		for i, e := range v {
+			out[i] = bindQueryValue(e, bind).(map[string]interface{})
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = bindQueryValue(e, bind)
+		}
+		return out
+	}
+	return v
+}
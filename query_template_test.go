@@ -0,0 +1,66 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestQueryTemplate(t *testing.T) {
+	tmpl := NewQueryBuilder().
+		EqParam("status", "status").
+		Gte("age", Param("min_age")).
+		InParam("role", "roles").
+		Limit(50).
+		Template()
+
+	if got := tmpl.Params(); !reflect.DeepEqual(got, []string{"min_age", "roles", "status"}) {
+		t.Errorf("Params = %v", got)
+	}
+
+	query, err := tmpl.Bind(map[string]interface{}{
+		"status":  "active",
+		"min_age": 18,
+		"roles":   []interface{}{"admin", "user"},
+	})
+	if err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	want := NewQueryBuilder().
+		Eq("status", "active").
+		Gte("age", 18).
+		In("role", []interface{}{"admin", "user"}).
+		Limit(50).
+		Build()
+	if !reflect.DeepEqual(query, want) {
+		t.Errorf("bound query = %v, want %v", query, want)
+	}
+
+	// Binding again does not see the previous values.
+	second, err := tmpl.Bind(map[string]interface{}{"status": "pending", "min_age": 21, "roles": []interface{}{}})
+	if err != nil {
+		t.Fatalf("Bind failed: %v", err)
+	}
+	if reflect.DeepEqual(second, query) {
+		t.Error("second Bind returned the first query")
+	}
+
+	if _, err := tmpl.Bind(map[string]interface{}{"status": "active", "min_age": 18}); err == nil {
+		t.Error("expected an error for a missing parameter")
+	}
+	if _, err := tmpl.Bind(map[string]interface{}{"status": "active", "min_age": 18, "roles": nil, "mni_age": 1}); err == nil {
+		t.Error("expected an error for an unknown parameter")
+	}
+}
+
+func TestQueryParamUnboundMarshal(t *testing.T) {
+	query := NewQueryBuilder().EqParam("status", "status").Build()
+	if _, err := json.Marshal(query); err == nil {
+		t.Error("expected marshaling an unbound parameter to fail")
+	}
+	if _, err := msgpack.Marshal(query); err == nil {
+		t.Error("expected MessagePack encoding of an unbound parameter to fail")
+	}
+}
@@ -0,0 +1,201 @@
+package ekodb
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// QueryValidationError lists the problems QueryBuilder.Validate found.
+type QueryValidationError struct {
+	Problems []string // One entry per problem, e.g. `filter: Gt on "age" has no value`
+}
+
+func (e *QueryValidationError) Error() string {
+	return "invalid query: " + strings.Join(e.Problems, "; ")
+}
+
+// valueOptionalOperators may compare against null; every other condition
+// operator needs a value.
+var valueOptionalOperators = map[string]bool{"Eq": true, "Ne": true}
+
+// Validate checks the query for mistakes the server would reject or that
+// make it meaningless, returning a *QueryValidationError that lists them:
+//
+//   - empty field names in filters, sorts, and projections
+//   - a nil value for an operator that needs one (anything but Eq and Ne),
+//     including an ElemMatch with no filters, or a TextMatches with no terms
+//   - a negative limit or skip, a skip with a limit of 0, or a sample size
+//     below 1
+//   - a field both selected and excluded, or an excluded field outside the
+//     selected set
+//   - a JoinWith join missing its collections or fields, or with an empty
+//     segment in its as_field path
+//   - a VectorNear with no field or vector, or combined with a sort, sample,
+//     or join
+//
+// BuildValidated and BuildJSON run the same checks, as do Find, Count, and
+// the other methods when passed a *QueryBuilder, which return the error
+// without sending a request. Build runs them too, logging any problems and
+// keeping the error for Err.
+func (qb *QueryBuilder) Validate() error {
+	var problems []string
+	for _, f := range qb.filters {
+		problems = append(problems, validateFilter(f, "filter")...)
+	}
+	for i, s := range qb.sortFields {
+		if s["field"] == "" {
+			problems = append(problems, fmt.Sprintf("sort[%d]: empty field name", i))
+		}
+		if nulls, ok := s["nulls"]; ok && nulls != string(NullsFirst) && nulls != string(NullsLast) {
+			problems = append(problems, fmt.Sprintf("sort[%d]: nulls must be %q or %q, got %q", i, NullsFirst, NullsLast, nulls))
+		}
+	}
+
+	if qb.limit != nil && *qb.limit < 0 {
+		problems = append(problems, fmt.Sprintf("limit %d is negative", *qb.limit))
+	}
+	if qb.skip != nil && *qb.skip < 0 {
+		problems = append(problems, fmt.Sprintf("skip %d is negative", *qb.skip))
+	}
+	if qb.sample != nil && *qb.sample <= 0 {
+		problems = append(problems, fmt.Sprintf("sample size %d is not positive", *qb.sample))
+	}
+	if qb.limit != nil && *qb.limit == 0 && qb.skip != nil && *qb.skip > 0 {
+		problems = append(problems, fmt.Sprintf("skip %d with limit 0 returns nothing", *qb.skip))
+	}
+
+	for i, j := range qb.joins {
+		for _, p := range j.validate() {
+			problems = append(problems, fmt.Sprintf("join[%d]: %s", i, p))
+		}
+	}
+
+	if near := qb.vectorNear; near != nil {
+		if near["field"] == "" {
+			problems = append(problems, "VectorNear: empty field name")
+		}
+		if v, _ := near["vector"].([]float64); len(v) == 0 {
+			problems = append(problems, "VectorNear: empty vector")
+		}
+		if len(qb.sortFields) > 0 || qb.sample != nil || qb.join != nil || len(qb.joins) > 0 {
+			problems = append(problems, "VectorNear returns records nearest first and cannot be combined with a sort, sample, or join")
+		}
+	}
+
+	problems = append(problems, validateProjection(qb.selectFields, qb.excludeFields)...)
+
+	if len(problems) > 0 {
+		return &QueryValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validateQueryArg returns the Validate error of a *QueryBuilder passed as a
+// query or filter argument; other arguments are sent as they are.
+func validateQueryArg(q interface{}) error {
+	if qb, ok := q.(*QueryBuilder); ok && qb != nil {
+		return qb.Validate()
+	}
+	return nil
+}
+
+// builtQuery returns the validated map of a *QueryBuilder passed as a query
+// argument, and any other query unchanged.
+func builtQuery(query interface{}) (interface{}, error) {
+	if qb, ok := query.(*QueryBuilder); ok && qb != nil {
+		return qb.BuildValidated()
+	}
+	return query, nil
+}
+
+// validateFilter checks one filter expression; at names its position.
+func validateFilter(expr map[string]interface{}, at string) []string {
+	content, _ := expr["content"].(map[string]interface{})
+	switch expr["type"] {
+	case "Condition":
+		field, _ := content["field"].(string)
+		op, _ := content["operator"].(string)
+		var problems []string
+		if field == "" {
+			problems = append(problems, fmt.Sprintf("%s: %s condition has an empty field name", at, op))
+		}
+		value := content["value"]
+		if sub, ok := value.(map[string]interface{}); ok && sub != nil && op == "ElemMatch" {
+			return append(problems, validateFilter(sub, fmt.Sprintf("%s.%s", at, field))...)
+		}
+		if !valueOptionalOperators[op] && isNilValue(value) {
+			problems = append(problems, fmt.Sprintf("%s: %s on %q has no value", at, op, field))
+		}
+		if text, ok := value.(map[string]interface{}); ok && op == "TextMatch" && strings.TrimSpace(fmt.Sprint(text["query"])) == "" {
+			problems = append(problems, fmt.Sprintf("%s: TextMatch on %q has an empty query", at, field))
+		}
+		return problems
+	case "Logical":
+		op, _ := content["operator"].(string)
+		var problems []string
+		switch exprs := content["expressions"].(type) {
+		case []map[string]interface{}:
+			for i, e := range exprs {
+				problems = append(problems, validateFilter(e, fmt.Sprintf("%s.%s[%d]", at, op, i))...)
+			}
+		case []interface{}:
+			for i, e := range exprs {
+				if m, ok := e.(map[string]interface{}); ok {
+					problems = append(problems, validateFilter(m, fmt.Sprintf("%s.%s[%d]", at, op, i))...)
+				}
+			}
+		}
+		return problems
+	}
+	return nil
+}
+
+// isNilValue reports whether v is nil or a nil slice, map, or pointer.
+func isNilValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// validateProjection checks the select and exclude lists against each other.
+// An exclusion must narrow a selected field (such as "metadata.internal"
+// under "metadata"); anything else has no effect.
+func validateProjection(selected, excluded []string) []string {
+	var problems []string
+	for _, f := range selected {
+		if f == "" {
+			problems = append(problems, "select_fields: empty field name")
+		}
+	}
+	for _, f := range excluded {
+		if f == "" {
+			problems = append(problems, "exclude_fields: empty field name")
+			continue
+		}
+		if len(selected) == 0 {
+			continue
+		}
+		within := false
+		for _, s := range selected {
+			if s == f {
+				problems = append(problems, fmt.Sprintf("field %q is both selected and excluded", f))
+				within = true
+				break
+			}
+			if s != "" && strings.HasPrefix(f, s+".") {
+				within = true
+				break
+			}
+		}
+		if !within {
+			problems = append(problems, fmt.Sprintf("excluded field %q is not in the selected fields", f))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,83 @@
+package ekodb
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQueryBuilderValidate(t *testing.T) {
+	if err := NewQueryBuilder().Eq("status", nil).Gt("age", 18).Limit(10).Skip(20).
+		SelectFields("name", "metadata").ExcludeFields("metadata.internal").Validate(); err != nil {
+		t.Fatalf("valid query rejected: %v", err)
+	}
+
+	qb := NewQueryBuilder().
+		Eq("", "x").
+		Gt("age", nil).
+		In("role", nil).
+		OrGroup(func(g *QueryBuilder) { g.Lt("", 3).Eq("a", 1) }).
+		ElemMatch("items", NewQueryBuilder()).
+		SortAscending("").
+		Limit(0).Skip(5).
+		SelectFields("name").ExcludeFields("name", "email")
+
+	err := qb.Validate()
+	var verr *QueryValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *QueryValidationError, got %v", err)
+	}
+	want := []string{
+		`filter: Eq condition has an empty field name`,
+		`filter: Gt on "age" has no value`,
+		`filter: In on "role" has no value`,
+		`filter.Or[0]: Lt condition has an empty field name`,
+		`filter: ElemMatch on "items" has no value`,
+		`sort[0]: empty field name`,
+		`skip 5 with limit 0 returns nothing`,
+		`field "name" is both selected and excluded`,
+		`excluded field "email" is not in the selected fields`,
+	}
+	if strings.Join(verr.Problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problems:\n%s\nwant:\n%s", strings.Join(verr.Problems, "\n"), strings.Join(want, "\n"))
+	}
+
+	if err := NewQueryBuilder().Limit(-1).Skip(-2).Validate(); err == nil ||
+		!strings.Contains(err.Error(), "limit -1 is negative") || !strings.Contains(err.Error(), "skip -2 is negative") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestInvalidQueryBuilderFailsToSend(t *testing.T) {
+	server := createTestServer(t, nil)
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	qb := NewQueryBuilder().Gt("age", nil)
+	if _, ok := qb.Build()["invalid_query"]; ok {
+		t.Fatal("Build embedded the validation error in the query")
+	}
+	var verr *QueryValidationError
+	if !errors.As(qb.Err(), &verr) {
+		t.Errorf("Build: expected Err to return *QueryValidationError, got %v", qb.Err())
+	}
+	valid := NewQueryBuilder().Eq("a", 1).Limit(5)
+	if valid.Build(); valid.Err() != nil {
+		t.Errorf("Build: valid query recorded %v", valid.Err())
+	}
+	if _, err := qb.BuildValidated(); !errors.As(err, &verr) {
+		t.Errorf("BuildValidated: expected *QueryValidationError, got %v", err)
+	}
+	if _, err := client.Find("users", qb); !errors.As(err, &verr) {
+		t.Errorf("Find: expected *QueryValidationError, got %v", err)
+	}
+	if _, err := client.Count("users", qb); !errors.As(err, &verr) {
+		t.Errorf("Count: expected *QueryValidationError, got %v", err)
+	}
+	if _, err := NewQueryBuilder().Eq("", 1).BuildJSON(); !errors.As(err, &verr) {
+		t.Errorf("BuildJSON: expected *QueryValidationError, got %v", err)
+	}
+	if _, err := NewQueryBuilder().Eq("a", 1).BuildValidated(); err != nil {
+		t.Errorf("valid query rejected: %v", err)
+	}
+}
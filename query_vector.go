@@ -0,0 +1,120 @@
+package ekodb
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// VectorNear restricts the query to the k records whose vector field is most
+// similar to vector, ignoring any scoring below threshold (0 for no
+// threshold). The other filters pre-filter the candidates, so structured
+// constraints and nearest-neighbor search combine in one Find:
+//
+//	qb := ekodb.NewQueryBuilder().
+//	    Eq("category", "audio").
+//	    VectorNear("embedding", vec, 10, 0.75)
+//	records, err := client.Find("products", qb.Build())
+//
+// Find runs such a query as a vector search (see Search) with the filters as
+// its pre-filter, and returns the records nearest first. It cannot be
+// combined with a sort, a sample, a join, or a transaction.
+func (qb *QueryBuilder) VectorNear(field string, vector []float64, k int, threshold float64) *QueryBuilder {
+	qb.vectorNear = map[string]interface{}{
+		"field":     field,
+		"vector":    vector,
+		"k":         k,
+		"threshold": threshold,
+	}
+	return qb
+}
+
+// findVectorNear runs a Find whose query has a vector_near clause as a
+// vector search.
+func (c *Client) findVectorNear(collection string, query interface{}, opts []FindOptions) ([]Record, error) {
+	if len(opts) > 0 && opts[0].TransactionId != nil {
+		return nil, fmt.Errorf("VectorNear queries cannot be read within a transaction")
+	}
+	body, err := c.mergeFindOptions("/api/find/"+url.PathEscape(collection), query, opts)
+	if err != nil {
+		return nil, err
+	}
+	search, err := vectorNearSearch(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts) > 0 && opts[0].BypassRipple != nil {
+		search.BypassRipple = opts[0].BypassRipple
+	}
+	resp, err := c.Search(collection, search)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]Record, len(resp.Results))
+	for i, r := range resp.Results {
+		records[i] = Record(r.Record)
+	}
+	return records, nil
+}
+
+// vectorNearSearch compiles a find body with a vector_near clause into the
+// equivalent SearchQuery.
+func vectorNearSearch(body map[string]interface{}) (SearchQuery, error) {
+	near, _ := asObject(body["vector_near"])
+	field, _ := near["field"].(string)
+	vector, ok := vectorElements(near["vector"])
+	if !ok || field == "" {
+		return SearchQuery{}, fmt.Errorf("invalid vector_near clause: %v", body["vector_near"])
+	}
+	for _, key := range []string{"sort", "sample", "join"} {
+		if body[key] != nil {
+			return SearchQuery{}, fmt.Errorf("VectorNear cannot be combined with %s", key)
+		}
+	}
+
+	q := SearchQuery{
+		Vector:      vector,
+		VectorField: &field,
+		Filters:     body["filter"],
+	}
+	if k, ok := codecInt(near["k"]); ok && k > 0 {
+		n := int(k)
+		q.VectorK = &n
+	}
+	if t, ok := codecFloat(near["threshold"]); ok && t > 0 {
+		q.VectorThreshold = &t
+	}
+	if limit, ok := codecInt(body["limit"]); ok {
+		n := int(limit)
+		q.Limit = &n
+	}
+	if skip, ok := codecInt(body["skip"]); ok {
+		n := int(skip)
+		q.Offset = &n
+	}
+	if b, ok := body["bypass_cache"].(bool); ok && b {
+		q.BypassCache = &b
+	}
+	if b, ok := body["bypass_ripple"].(bool); ok && b {
+		q.BypassRipple = &b
+	}
+	q.SelectFields = stringList(body["select_fields"])
+	q.ExcludeFields = stringList(body["exclude_fields"])
+	return q, nil
+}
+
+// stringList returns a []string or []interface{} of strings as a []string.
+func stringList(v interface{}) []string {
+	switch v := v.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestFindVectorNear(t *testing.T) {
+	var sent map[string]interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/search/products": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"results": []interface{}{
+					map[string]interface{}{"record": map[string]interface{}{"id": "p2"}, "score": 0.9},
+					map[string]interface{}{"record": map[string]interface{}{"id": "p1"}, "score": 0.8},
+				},
+				"total": 2,
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	query := NewQueryBuilder().
+		Eq("category", "audio").
+		VectorNear("embedding", []float64{0.1, 0.2}, 10, 0.75).
+		Limit(5).
+		SelectFields("name")
+	records, err := client.Find("products", query.Build())
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(records) != 2 || records[0]["id"] != "p2" {
+		t.Errorf("unexpected records %v", records)
+	}
+
+	if !reflect.DeepEqual(sent["vector"], []interface{}{0.1, 0.2}) || sent["vector_field"] != "embedding" ||
+		sent["vector_k"] != float64(10) || sent["vector_threshold"] != 0.75 || sent["limit"] != float64(5) {
+		t.Errorf("unexpected search body %v", sent)
+	}
+	filters, _ := sent["filters"].(map[string]interface{})
+	if content, _ := filters["content"].(map[string]interface{}); content["field"] != "category" {
+		t.Errorf("filters not sent as pre-filter: %v", sent["filters"])
+	}
+	if !reflect.DeepEqual(sent["select_fields"], []interface{}{"name"}) {
+		t.Errorf("select_fields not sent: %v", sent["select_fields"])
+	}
+
+	tx := "tx1"
+	if _, err := client.Find("products", query.Build(), FindOptions{TransactionId: &tx}); err == nil {
+		t.Error("expected an error for VectorNear in a transaction")
+	}
+}
+
+func TestVectorNearValidation(t *testing.T) {
+	if err := NewQueryBuilder().VectorNear("embedding", []float64{1}, 3, 0).Validate(); err != nil {
+		t.Errorf("valid VectorNear rejected: %v", err)
+	}
+	if err := NewQueryBuilder().VectorNear("", nil, 3, 0).Validate(); err == nil {
+		t.Error("expected empty field and vector to fail validation")
+	}
+	if err := NewQueryBuilder().VectorNear("embedding", []float64{1}, 3, 0).SortAscending("name").Validate(); err == nil {
+		t.Error("expected VectorNear with a sort to fail validation")
+	}
+}
@@ -2,6 +2,7 @@ package ekodb
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -16,7 +17,8 @@ import (
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Generated %d dimensions\n", len(embedding))
-func (c *Client) Embed(text, model string) ([]float64, error) {
+func (c *Client) Embed(text, model string, opts ...RequestOption) ([]float64, error) {
+	c = c.withOptions(opts)
 	request := EmbedRequest{
 		Text:  &text,
 		Model: &model,
@@ -47,7 +49,8 @@ func (c *Client) Embed(text, model string) ([]float64, error) {
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func (c *Client) EmbedBatch(texts []string, model string) ([][]float64, error) {
+func (c *Client) EmbedBatch(texts []string, model string, opts ...RequestOption) ([][]float64, error) {
+	c = c.withOptions(opts)
 	if len(texts) == 0 {
 		return nil, fmt.Errorf("texts must not be empty")
 	}
@@ -87,7 +90,8 @@ func (c *Client) EmbedBatch(texts []string, model string) ([][]float64, error) {
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func (c *Client) TextSearch(collection, queryText string, limit int) ([]Record, error) {
+func (c *Client) TextSearch(collection, queryText string, limit int, opts ...RequestOption) ([]Record, error) {
+	c = c.withOptions(opts)
 	searchQuery := SearchQuery{
 		Query: queryText,
 		Limit: &limit,
@@ -124,7 +128,8 @@ func (c *Client) TextSearch(collection, queryText string, limit int) ([]Record,
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-func (c *Client) HybridSearch(collection, queryText string, queryVector []float64, limit int) ([]Record, error) {
+func (c *Client) HybridSearch(collection, queryText string, queryVector []float64, limit int, opts ...RequestOption) ([]Record, error) {
+	c = c.withOptions(opts)
 	searchQuery := SearchQuery{
 		Query:  queryText,
 		Vector: queryVector,
@@ -152,7 +157,10 @@ func (c *Client) HybridSearch(collection, queryText string, queryVector []float6
 
 // FindAll finds all records in a collection with a limit
 //
-// Simplified method to query all documents in a collection.
+// Simplified method to query all documents in a collection. At most limit
+// records are returned; a collection with more is truncated, which is logged.
+// Use FindAllLimited to detect truncation, or FindAllPages to fetch
+// everything.
 //
 // Example:
 //
@@ -161,7 +169,54 @@ func (c *Client) HybridSearch(collection, queryText string, queryVector []float6
 //	    log.Fatal(err)
 //	}
 //	fmt.Printf("Found %d messages\n", len(allMessages))
-func (c *Client) FindAll(collection string, limit int) ([]Record, error) {
-	query := NewQueryBuilder().Limit(limit).Build()
-	return c.Find(collection, query)
+func (c *Client) FindAll(collection string, limit int, opts ...RequestOption) ([]Record, error) {
+	c = c.withOptions(opts)
+	records, truncated, err := c.FindAllLimited(collection, limit)
+	if truncated {
+		c.logf("FindAll(%s): truncated at %d records; use FindAllPages for all of them", collection, limit)
+	}
+	return records, err
+}
+
+// FindAllLimited is FindAll that also reports whether the collection holds
+// more than limit records, by asking for one more than it returns.
+func (c *Client) FindAllLimited(collection string, limit int, opts ...RequestOption) ([]Record, bool, error) {
+	c = c.withOptions(opts)
+	records, err := c.Find(collection, NewQueryBuilder().Limit(limit+1).Build())
+	if err != nil {
+		return nil, false, err
+	}
+	if len(records) > limit {
+		return records[:limit], true, nil
+	}
+	return records, false, nil
+}
+
+// MaxFindAllPagesRecords caps how many records FindAllPages collects, so a
+// collection that is far larger than expected fails instead of exhausting
+// memory.
+const MaxFindAllPagesRecords = 1_000_000
+
+// ErrTooManyRecords is returned by FindAllPages when a collection holds more
+// than MaxFindAllPagesRecords records.
+var ErrTooManyRecords = errors.New("too many records")
+
+// FindAllPages returns every record in a collection, fetching pageSize at a
+// time in id order until the collection is exhausted. It fails with
+// ErrTooManyRecords past MaxFindAllPagesRecords; stream larger collections
+// with FindIter or FindEach instead.
+func (c *Client) FindAllPages(collection string, pageSize int, opts ...RequestOption) ([]Record, error) {
+	c = c.withOptions(opts)
+	var records []Record
+	cur := c.FindCursor(collection, NewQueryBuilder().SortAscending("id").Build()).PageSize(pageSize)
+	for cur.Next(c.context()) {
+		if len(records) == MaxFindAllPagesRecords {
+			return nil, fmt.Errorf("FindAllPages(%s): more than %d records: %w", collection, MaxFindAllPagesRecords, ErrTooManyRecords)
+		}
+		records = append(records, cur.Record())
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
 }
@@ -0,0 +1,102 @@
+package ekodb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is returned instead of sending a request when the client is
+// holding requests for a rate limit to reset and ClientConfig.RateLimitQueueDepth
+// requests are already waiting.
+var ErrQueueFull = errors.New("rate limit queue is full")
+
+// rateLimitQueue holds new requests while the server is rate limiting the
+// client, so that goroutines wait together for the reset instead of each
+// discovering the limit with its own 429 and sleeping independently.
+type rateLimitQueue struct {
+	mu       sync.Mutex
+	until    time.Time // Requests are held until this time
+	waiting  int       // Requests currently held
+	maxDepth int
+}
+
+func newRateLimitQueue(maxDepth int) *rateLimitQueue {
+	if maxDepth <= 0 {
+		return nil
+	}
+	return &rateLimitQueue{maxDepth: maxDepth}
+}
+
+// holdUntil holds requests until t, extending (never shortening) the current
+// hold.
+func (q *rateLimitQueue) holdUntil(t time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if t.After(q.until) {
+		q.until = t
+	}
+}
+
+// wait blocks while requests are held. A new request is turned away with
+// ErrQueueFull when the queue is at capacity; a retry (admitted) already
+// holds its place and is not. It returns ctx's error if ctx ends first.
+func (q *rateLimitQueue) wait(ctx context.Context, admitted bool) error {
+	q.mu.Lock()
+	if time.Until(q.until) <= 0 {
+		q.mu.Unlock()
+		return nil
+	}
+	if !admitted && q.waiting >= q.maxDepth {
+		q.mu.Unlock()
+		return ErrQueueFull
+	}
+	q.waiting++
+	q.mu.Unlock()
+
+	defer func() {
+		q.mu.Lock()
+		q.waiting--
+		q.mu.Unlock()
+	}()
+
+	// Loop because another 429 may extend the hold while we sleep.
+	for {
+		q.mu.Lock()
+		delay := time.Until(q.until)
+		q.mu.Unlock()
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// admit waits in the rate-limit queue, when it is enabled, before a request
+// is sent. retry marks a retry of a request that was already admitted.
+func (c *Client) admit(retry bool) error {
+	if c.rateLimitQueue == nil {
+		return nil
+	}
+	return c.rateLimitQueue.wait(c.context(), retry)
+}
+
+// QueuedRequests returns the number of requests currently waiting in the
+// rate-limit queue (see ClientConfig.RateLimitQueueDepth), e.g. for metrics.
+// It is always 0 when the queue is disabled.
+func (c *Client) QueuedRequests() int {
+	q := c.rateLimitQueue
+	if q == nil {
+		return 0
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.waiting
+}
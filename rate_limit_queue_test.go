@@ -0,0 +1,103 @@
+package ekodb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitQueueDepth(t *testing.T) {
+	q := newRateLimitQueue(1)
+	q.holdUntil(time.Now().Add(100 * time.Millisecond))
+
+	started := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		close(started)
+		done <- q.wait(context.Background(), false)
+	}()
+	<-started
+	for (&Client{rateLimitQueue: q}).QueuedRequests() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := q.wait(context.Background(), false); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("queued request should be released, got %v", err)
+	}
+	if err := q.wait(context.Background(), false); err != nil {
+		t.Errorf("expected no wait after the hold expired, got %v", err)
+	}
+}
+
+func TestRateLimitQueueHonorsContext(t *testing.T) {
+	q := newRateLimitQueue(4)
+	q.holdUntil(time.Now().Add(time.Minute))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := q.wait(ctx, false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRateLimitQueueHoldsRequestsAfter429(t *testing.T) {
+	var limited atomic.Bool
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			if limited.CompareAndSwap(false, true) {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		},
+	})
+	defer server.Close()
+
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:             server.URL,
+		APIKey:              "test-api-key",
+		Format:              JSON,
+		RateLimitQueueDepth: 2,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+
+	var rateErr *RateLimitError
+	if err := client.Health(); !errors.As(err, &rateErr) {
+		t.Fatalf("expected RateLimitError, got %v", err)
+	}
+
+	// The 429 holds the queue for a second: two requests wait, a third is
+	// turned away.
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.WithContext(context.Background()).Health(); err != nil {
+				t.Errorf("queued request failed: %v", err)
+			}
+		}()
+	}
+	for client.QueuedRequests() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	if err := client.Health(); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("queued requests should wait for the reset, finished after %v", elapsed)
+	}
+}
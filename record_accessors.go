@@ -0,0 +1,128 @@
+package ekodb
+
+import "time"
+
+// Typed accessors read a field of a Record, unwrapping typed field values the
+// way the package-level GetXxxValue helpers do:
+//
+//	user, _ := client.FindByID("users", userID)
+//	email := user.String("email")
+//	if created, ok := user.TimeOK("created_at"); ok {
+//	    ...
+//	}
+//
+// Fields may be dot paths into nested objects, as for Get. The plain forms
+// return the zero value when the field is missing or has another type; the OK
+// forms also report whether the field could be read.
+
+// String returns a String field, or "" if it is missing or not a string
+func (r Record) String(field string) string {
+	s, _ := r.StringOK(field)
+	return s
+}
+
+// StringOK returns a String field and whether it was present as a string
+func (r Record) StringOK(field string) (string, bool) {
+	s, ok := GetValue(r.Get(field)).(string)
+	return s, ok
+}
+
+// Int returns an Integer field, converting numbers and numeric strings as
+// GetIntValue does, or 0 if it cannot be read
+func (r Record) Int(field string) int {
+	n, _ := r.IntOK(field)
+	return n
+}
+
+// IntOK returns an Integer field and whether it could be read as an int
+func (r Record) IntOK(field string) (int, bool) {
+	return GetIntValue(r.Get(field))
+}
+
+// Float returns a Float or Number field, or 0 if it cannot be read
+func (r Record) Float(field string) float64 {
+	f, _ := r.FloatOK(field)
+	return f
+}
+
+// FloatOK returns a Float or Number field and whether it could be read as a
+// number
+func (r Record) FloatOK(field string) (float64, bool) {
+	value := GetValue(r.Get(field))
+	if value == nil {
+		return 0, false
+	}
+	return codecFloat(value)
+}
+
+// Bool returns a Boolean field, or false if it is missing or not a bool
+func (r Record) Bool(field string) bool {
+	b, _ := r.BoolOK(field)
+	return b
+}
+
+// BoolOK returns a Boolean field and whether it was present as a bool
+func (r Record) BoolOK(field string) (bool, bool) {
+	b, ok := GetValue(r.Get(field)).(bool)
+	return b, ok
+}
+
+// Time returns a DateTime field, or the zero time if it cannot be read
+func (r Record) Time(field string) time.Time {
+	t, _ := r.TimeOK(field)
+	return t
+}
+
+// TimeOK returns a DateTime field and whether it could be read as a time.Time
+// or an RFC 3339 string
+func (r Record) TimeOK(field string) (time.Time, bool) {
+	if t := GetDateTimeValue(r.Get(field)); t != nil {
+		return *t, true
+	}
+	return time.Time{}, false
+}
+
+// Vector returns a Vector field, or nil if it cannot be read
+func (r Record) Vector(field string) Vector {
+	v, _ := r.VectorOK(field)
+	return v
+}
+
+// VectorOK returns a Vector field, plain or packed, and whether it could be
+// read as one
+func (r Record) VectorOK(field string) (Vector, bool) {
+	raw := r.Get(field)
+	if raw == nil {
+		return nil, false
+	}
+	var v Vector
+	if err := v.set(raw); err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// Object returns an Object field, or nil if it is missing or not an object
+func (r Record) Object(field string) map[string]interface{} {
+	m, _ := r.ObjectOK(field)
+	return m
+}
+
+// ObjectOK returns an Object field and whether it was present as an object
+func (r Record) ObjectOK(field string) (map[string]interface{}, bool) {
+	return asObject(GetValue(r.Get(field)))
+}
+
+// Array returns an Array or Set field, or nil if it is missing or not an
+// array
+func (r Record) Array(field string) []interface{} {
+	a, _ := r.ArrayOK(field)
+	return a
+}
+
+// ArrayOK returns an Array or Set field and whether it was present as an
+// array
+func (r Record) ArrayOK(field string) ([]interface{}, bool) {
+	a, ok := GetValue(r.Get(field)).([]interface{})
+	return a, ok
+}
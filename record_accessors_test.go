@@ -0,0 +1,70 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestRecordAccessors(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	packed := PackVector([]float64{0.5, 1})
+	var rec Record
+	if err := json.Unmarshal([]byte(`{
+		"email": {"type": "String", "value": "ada@example.com"},
+		"age": {"type": "Integer", "value": 36},
+		"score": 9.5,
+		"active": {"type": "Boolean", "value": true},
+		"created_at": {"type": "DateTime", "value": "2024-05-01T12:00:00Z"},
+		"embedding": {"type": "Vector", "value": [1, 2, 3]},
+		"address": {"type": "Object", "value": {"city": "London"}},
+		"tags": ["a", "b"]
+	}`), &rec); err != nil {
+		t.Fatal(err)
+	}
+	rec["packed"] = map[string]interface{}{"encoding": PackedVectorEncoding, "value": packed}
+
+	if got := rec.String("email"); got != "ada@example.com" {
+		t.Errorf("String = %q", got)
+	}
+	if got := rec.Int("age"); got != 36 {
+		t.Errorf("Int = %d", got)
+	}
+	if got := rec.Float("score"); got != 9.5 {
+		t.Errorf("Float = %v", got)
+	}
+	if !rec.Bool("active") {
+		t.Error("Bool = false")
+	}
+	if got := rec.Time("created_at"); !got.Equal(created) {
+		t.Errorf("Time = %v", got)
+	}
+	if got := rec.Vector("embedding"); got.Dim() != 3 || got[2] != 3 {
+		t.Errorf("Vector = %v", got)
+	}
+	if got := rec.Vector("packed"); got.Dim() != 2 || got[0] != 0.5 {
+		t.Errorf("packed Vector = %v", got)
+	}
+	if got := rec.Object("address"); got["city"] != "London" {
+		t.Errorf("Object = %v", got)
+	}
+	if got := rec.Array("tags"); len(got) != 2 {
+		t.Errorf("Array = %v", got)
+	}
+
+	if _, ok := rec.StringOK("age"); ok {
+		t.Error("StringOK accepted an integer")
+	}
+	if _, ok := rec.IntOK("missing"); ok {
+		t.Error("IntOK accepted a missing field")
+	}
+	if _, ok := rec.TimeOK("email"); ok {
+		t.Error("TimeOK accepted a non-time string")
+	}
+	if v, ok := rec.VectorOK("tags"); ok || v != nil {
+		t.Errorf("VectorOK(tags) = %v, %v", v, ok)
+	}
+	if got := rec.String("missing"); got != "" {
+		t.Errorf("String(missing) = %q", got)
+	}
+}
@@ -0,0 +1,184 @@
+package ekodb
+
+import (
+	"fmt"
+	"time"
+)
+
+// RecordBuilder provides a fluent API for building records with wrapped field
+// types, as an alternative to hand-built maps of FieldXxx calls:
+//
+//	record := ekodb.NewRecord().
+//	    Set("name", "Ada").
+//	    SetDecimal("total", "9.99").
+//	    SetDateTime("at", time.Now()).
+//	    Build()
+//
+// Setters that parse their input (SetDecimal, SetUUID) record the first
+// invalid value instead of panicking; check Err before sending the record.
+type RecordBuilder struct {
+	record Record
+	err    error
+}
+
+// NewRecord creates a new RecordBuilder
+func NewRecord() *RecordBuilder {
+	return &RecordBuilder{record: Record{}}
+}
+
+// NewRecordFrom creates a RecordBuilder starting from a copy of rec
+func NewRecordFrom(rec Record) *RecordBuilder {
+	rb := NewRecord()
+	for k, v := range rec {
+		rb.record[k] = v
+	}
+	return rb
+}
+
+// fail records the first error.
+func (rb *RecordBuilder) fail(err error) *RecordBuilder {
+	if rb.err == nil {
+		rb.err = err
+	}
+	return rb
+}
+
+// Set sets a field to a plain value, sent as is
+func (rb *RecordBuilder) Set(name string, value interface{}) *RecordBuilder {
+	rb.record[name] = value
+	return rb
+}
+
+// SetID sets the record's id
+func (rb *RecordBuilder) SetID(id string) *RecordBuilder {
+	return rb.Set("id", id)
+}
+
+// SetString sets an explicitly typed String field
+func (rb *RecordBuilder) SetString(name, value string) *RecordBuilder {
+	return rb.Set(name, FieldString(value))
+}
+
+// SetInteger sets an explicitly typed Integer field
+func (rb *RecordBuilder) SetInteger(name string, value int64) *RecordBuilder {
+	return rb.Set(name, FieldInteger(value))
+}
+
+// SetFloat sets an explicitly typed Float field
+func (rb *RecordBuilder) SetFloat(name string, value float64) *RecordBuilder {
+	return rb.Set(name, FieldFloat(value))
+}
+
+// SetBoolean sets an explicitly typed Boolean field
+func (rb *RecordBuilder) SetBoolean(name string, value bool) *RecordBuilder {
+	return rb.Set(name, FieldBoolean(value))
+}
+
+// SetNumber sets a Number field
+func (rb *RecordBuilder) SetNumber(name string, value interface{}) *RecordBuilder {
+	return rb.Set(name, FieldNumber(value))
+}
+
+// SetDecimal sets a Decimal field from a decimal string such as "9.99". An
+// unparsable string is reported by Err and the field is left unset.
+func (rb *RecordBuilder) SetDecimal(name, value string) *RecordBuilder {
+	if _, err := ParseDecimal(value); err != nil {
+		return rb.fail(fmt.Errorf("field %s: %w", name, err))
+	}
+	return rb.Set(name, FieldDecimal(value))
+}
+
+// SetDecimalValue sets a Decimal field from a Decimal
+func (rb *RecordBuilder) SetDecimalValue(name string, value Decimal) *RecordBuilder {
+	return rb.Set(name, FieldDecimalOf(value))
+}
+
+// SetDateTime sets a DateTime field
+func (rb *RecordBuilder) SetDateTime(name string, value time.Time) *RecordBuilder {
+	return rb.Set(name, FieldDateTime(value))
+}
+
+// SetUUID sets a UUID field. A value that is not a canonical UUID is reported
+// by Err and the field is left unset.
+func (rb *RecordBuilder) SetUUID(name, value string) *RecordBuilder {
+	if !isUUID(value) {
+		return rb.fail(fmt.Errorf("field %s: invalid UUID %q", name, value))
+	}
+	return rb.Set(name, FieldUUID(value))
+}
+
+// SetDuration sets a Duration field
+func (rb *RecordBuilder) SetDuration(name string, value time.Duration) *RecordBuilder {
+	return rb.Set(name, FieldDurationFromGo(value))
+}
+
+// SetSet sets a Set field (unique elements)
+func (rb *RecordBuilder) SetSet(name string, values interface{}) *RecordBuilder {
+	return rb.Set(name, FieldSet(values))
+}
+
+// SetArray sets an Array field
+func (rb *RecordBuilder) SetArray(name string, values interface{}) *RecordBuilder {
+	return rb.Set(name, FieldArray(values))
+}
+
+// SetObject sets an Object field
+func (rb *RecordBuilder) SetObject(name string, value map[string]interface{}) *RecordBuilder {
+	return rb.Set(name, FieldObject(value))
+}
+
+// SetVector sets a Vector field
+func (rb *RecordBuilder) SetVector(name string, values []float64) *RecordBuilder {
+	return rb.Set(name, FieldVector(values))
+}
+
+// SetBinary sets a Binary field
+func (rb *RecordBuilder) SetBinary(name string, value []byte) *RecordBuilder {
+	return rb.Set(name, FieldBinary(value))
+}
+
+// SetBytes sets a Bytes field
+func (rb *RecordBuilder) SetBytes(name string, value []byte) *RecordBuilder {
+	return rb.Set(name, FieldBytes(value))
+}
+
+// Remove deletes a field set earlier
+func (rb *RecordBuilder) Remove(name string) *RecordBuilder {
+	delete(rb.record, name)
+	return rb
+}
+
+// Err returns the first invalid value passed to a setter, or nil
+func (rb *RecordBuilder) Err() error {
+	return rb.err
+}
+
+// Build returns the record. Each call returns a new copy, so the builder can
+// keep being used as a template.
+func (rb *RecordBuilder) Build() Record {
+	out := make(Record, len(rb.record))
+	for k, v := range rb.record {
+		out[k] = v
+	}
+	return out
+}
+
+// isUUID reports whether s is a canonical 8-4-4-4-12 hexadecimal UUID.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f' || 'A' <= r && r <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
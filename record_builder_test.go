@@ -0,0 +1,54 @@
+package ekodb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordBuilder(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	builder := NewRecord().
+		SetID("o1").
+		Set("name", "x").
+		SetDecimal("total", "9.99").
+		SetDateTime("at", at).
+		SetUUID("ref", "550e8400-e29b-41d4-a716-446655440000").
+		SetVector("embedding", []float64{1, 2}).
+		Set("draft", true).
+		Remove("draft")
+	if err := builder.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rec := builder.Build()
+	if rec["id"] != "o1" || rec["name"] != "x" || len(rec) != 6 {
+		t.Errorf("unexpected record: %v", rec)
+	}
+	if GetValue(rec["total"]) != "9.99" || rec["total"].(map[string]interface{})["type"] != "Decimal" {
+		t.Errorf("total = %v", rec["total"])
+	}
+	if GetValue(rec["at"]) != "2026-01-02T03:04:05Z" {
+		t.Errorf("at = %v", rec["at"])
+	}
+
+	// Build returns independent copies.
+	rec["name"] = "changed"
+	if builder.Build()["name"] != "x" {
+		t.Error("Build shares its map with earlier results")
+	}
+}
+
+func TestRecordBuilderErrors(t *testing.T) {
+	builder := NewRecord().SetDecimal("total", "9,99").SetUUID("ref", "not-a-uuid")
+	if builder.Err() == nil || builder.Err().Error() != `field total: invalid decimal "9,99"` {
+		t.Errorf("Err = %v", builder.Err())
+	}
+	if rec := builder.Build(); len(rec) != 0 {
+		t.Errorf("invalid fields should be left unset: %v", rec)
+	}
+
+	base := Record{"tenant": "t1"}
+	rec := NewRecordFrom(base).Set("n", 1).Build()
+	if rec["tenant"] != "t1" || len(base) != 1 {
+		t.Errorf("NewRecordFrom = %v, base = %v", rec, base)
+	}
+}
@@ -0,0 +1,81 @@
+package ekodb
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// DecodeRecord decodes rec into out, a non-nil pointer to a struct, map, or
+// interface. Structs are filled as by UnmarshalRecord: fields are matched by
+// their ekodb or json tag, wrapped field objects are unwrapped, and DateTime
+// strings, Decimals, and Vectors are converted to the field's Go type
+// (time.Time, float64 or string, []float64 or []float32). Maps receive the
+// unwrapped values.
+//
+// Example:
+//
+//	rec, _ := client.FindByID("users", id)
+//	var user User
+//	err := ekodb.DecodeRecord(rec, &user)
+func DecodeRecord(rec Record, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("DecodeRecord: expected a non-nil pointer, got %T", out)
+	}
+	return decodeRecordValue(rv.Elem(), rec)
+}
+
+// DecodeRecords decodes recs into out, a pointer to a slice of structs, maps,
+// or pointers to structs (e.g. *[]User or *[]*User), replacing its contents.
+// Each record is decoded as by DecodeRecord.
+func DecodeRecords(recs []Record, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("DecodeRecords: expected a pointer to a slice, got %T", out)
+	}
+	slice := reflect.MakeSlice(rv.Elem().Type(), len(recs), len(recs))
+	for i, rec := range recs {
+		elem := slice.Index(i)
+		if elem.Kind() == reflect.Pointer {
+			elem.Set(reflect.New(elem.Type().Elem()))
+			elem = elem.Elem()
+		}
+		if err := decodeRecordValue(elem, rec); err != nil {
+			return fmt.Errorf("record %d: %w", i, err)
+		}
+	}
+	rv.Elem().Set(slice)
+	return nil
+}
+
+// decodeRecordValue decodes rec into dst. The record itself is never treated
+// as a wrapped value, even when it has "type" and "value" fields.
+func decodeRecordValue(dst reflect.Value, rec Record) error {
+	switch {
+	case dst.Kind() == reflect.Struct && dst.Type() != timeType:
+		return unmarshalStruct(dst, rec)
+	case dst.Kind() == reflect.Map && dst.Type().Key().Kind() == reflect.String:
+		return unmarshalMap(dst, rec)
+	case dst.Kind() == reflect.Interface && dst.NumMethod() == 0:
+		dst.Set(reflect.ValueOf(ExtractRecord(rec)))
+		return nil
+	}
+	return fmt.Errorf("cannot decode a record into %s", dst.Type())
+}
+
+// FindDecode runs a Find and decodes the results into out, a pointer to a
+// slice, with DecodeRecords. Unlike FindInto, which decodes the wire format
+// straight into out, it understands wrapped field objects and ekodb struct
+// tags, at the cost of building the intermediate records.
+//
+// Example:
+//
+//	var orders []Order
+//	err := client.FindDecode("orders", query, &orders)
+func (c *Client) FindDecode(collection string, query interface{}, out interface{}, opts ...FindOptions) error {
+	records, err := c.Find(collection, query, opts...)
+	if err != nil {
+		return err
+	}
+	return DecodeRecords(records, out)
+}
@@ -0,0 +1,80 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type decodeUser struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Balance   float64   `json:"balance"`
+	Joined    time.Time `json:"joined"`
+	Embedding []float64 `json:"embedding"`
+}
+
+func TestDecodeRecord(t *testing.T) {
+	rec := Record{
+		"id":        "u1",
+		"name":      map[string]interface{}{"type": "String", "value": "Ada"},
+		"balance":   map[string]interface{}{"type": "Decimal", "value": "12.50"},
+		"joined":    map[string]interface{}{"type": "DateTime", "value": "2026-01-02T03:04:05Z"},
+		"embedding": map[string]interface{}{"type": "Vector", "value": []interface{}{0.1, 0.2}},
+	}
+
+	var user decodeUser
+	if err := DecodeRecord(rec, &user); err != nil {
+		t.Fatalf("DecodeRecord failed: %v", err)
+	}
+	if user.ID != "u1" || user.Name != "Ada" || user.Balance != 12.5 || len(user.Embedding) != 2 {
+		t.Errorf("unexpected user: %+v", user)
+	}
+	if !user.Joined.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("Joined = %v", user.Joined)
+	}
+
+	var plain map[string]interface{}
+	if err := DecodeRecord(rec, &plain); err != nil {
+		t.Fatalf("DecodeRecord into a map failed: %v", err)
+	}
+	if plain["name"] != "Ada" || plain["balance"] != "12.50" {
+		t.Errorf("map values not unwrapped: %v", plain)
+	}
+
+	var event map[string]interface{}
+	if err := DecodeRecord(Record{"type": "login", "value": 1}, &event); err != nil || event["type"] != "login" {
+		t.Errorf("a record with type and value fields was unwrapped: %v, %v", event, err)
+	}
+
+	if err := DecodeRecord(rec, user); err == nil {
+		t.Error("expected an error for a non-pointer")
+	}
+}
+
+func TestFindDecode(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": "u1", "name": map[string]interface{}{"type": "String", "value": "Ada"}},
+				{"id": "u2", "name": "Grace", "balance": map[string]interface{}{"type": "Decimal", "value": "3"}},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	var users []*decodeUser
+	if err := client.FindDecode("users", nil, &users); err != nil {
+		t.Fatalf("FindDecode failed: %v", err)
+	}
+	if len(users) != 2 || users[0].Name != "Ada" || users[1].Name != "Grace" || users[1].Balance != 3 {
+		t.Errorf("unexpected users: %+v %+v", users[0], users[1])
+	}
+
+	var wrong []decodeUser
+	if err := DecodeRecords([]Record{{"balance": "lots"}}, &wrong); err == nil {
+		t.Error("expected an error for an undecodable field")
+	}
+}
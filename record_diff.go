@@ -0,0 +1,120 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// DiffRecords returns the fields of updated that differ from old, suitable as
+// a partial Update payload that leaves unrelated fields (and concurrent
+// writes to them) alone:
+//
+//	changes := ekodb.DiffRecords(before, after)
+//	if len(changes) > 0 {
+//	    client.Update("users", id, changes)
+//	}
+//
+// Fields present in old but missing from updated are included as nil, which
+// clears them. Values are compared after unwrapping typed fields, with numbers
+// compared by value, so FieldInteger(36) and float64(36) are equal. A changed
+// nested object is sent whole. The "id" field is never included.
+func DiffRecords(old, updated Record) Record {
+	diff := Record{}
+	for k, v := range updated {
+		if k == "id" {
+			continue
+		}
+		if prev, ok := old[k]; !ok || !diffValueEqual(prev, v) {
+			diff[k] = v
+		}
+	}
+	for k := range old {
+		if _, ok := updated[k]; !ok && k != "id" {
+			diff[k] = nil
+		}
+	}
+	return diff
+}
+
+// DiffStructs is DiffRecords for two values of a struct type, converted with
+// MarshalRecord.
+func DiffStructs(old, updated interface{}) (Record, error) {
+	before, err := MarshalRecord(old)
+	if err != nil {
+		return nil, err
+	}
+	after, err := MarshalRecord(updated)
+	if err != nil {
+		return nil, err
+	}
+	return DiffRecords(before, after), nil
+}
+
+// diffValueEqual reports whether two record values hold the same data.
+func diffValueEqual(a, b interface{}) bool {
+	a, b = GetValue(a), GetValue(b)
+	if diffIsNumber(a) && diffIsNumber(b) {
+		ai, aInt := codecInt(a)
+		bi, bInt := codecInt(b)
+		if aInt && bInt {
+			return ai == bi
+		}
+		af, _ := codecFloat(a)
+		bf, _ := codecFloat(b)
+		return af == bf
+	}
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	if am, ok := asObject(a); ok {
+		bm, ok := asObject(b)
+		if !ok || len(am) != len(bm) {
+			return false
+		}
+		for k, av := range am {
+			bv, ok := bm[k]
+			if !ok || !diffValueEqual(av, bv) {
+				return false
+			}
+		}
+		return true
+	}
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if diffIsList(av) && diffIsList(bv) {
+		if av.Len() != bv.Len() {
+			return false
+		}
+		for i := 0; i < av.Len(); i++ {
+			if !diffValueEqual(av.Index(i).Interface(), bv.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func diffIsNumber(v interface{}) bool {
+	if _, ok := v.(json.Number); ok {
+		return true
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// diffIsList reports whether v is a slice or array other than []byte, which
+// DeepEqual already compares by content.
+func diffIsList(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDiffRecords(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	old := Record{
+		"id":      "u1",
+		"name":    FieldString("Ada"),
+		"age":     FieldInteger(36),
+		"score":   json.Number("9.5"),
+		"tags":    []interface{}{"a", "b"},
+		"address": map[string]interface{}{"city": "London", "zip": "N1"},
+		"seen":    at,
+		"legacy":  true,
+	}
+	updated := Record{
+		"id":      "u1",
+		"name":    "Ada",
+		"age":     float64(37),
+		"score":   9.5,
+		"tags":    []string{"a", "b"},
+		"address": map[string]interface{}{"city": "Paris", "zip": "N1"},
+		"seen":    at.In(time.FixedZone("CEST", 2*60*60)),
+		"email":   "ada@example.com",
+	}
+
+	diff := DiffRecords(old, updated)
+	want := map[string]bool{"age": true, "address": true, "email": true, "legacy": true}
+	if len(diff) != len(want) {
+		t.Errorf("diff = %v", diff)
+	}
+	for k := range want {
+		if _, ok := diff[k]; !ok {
+			t.Errorf("diff missing %s: %v", k, diff)
+		}
+	}
+	if diff["legacy"] != nil {
+		t.Errorf("removed field = %v, want nil", diff["legacy"])
+	}
+	if len(DiffRecords(old, old)) != 0 {
+		t.Error("a record differs from itself")
+	}
+}
+
+func TestDiffStructs(t *testing.T) {
+	type user struct {
+		ID   string `ekodb:"id"`
+		Name string `ekodb:"name"`
+		Age  int    `ekodb:"age"`
+	}
+	diff, err := DiffStructs(user{ID: "u1", Name: "Ada", Age: 36}, user{ID: "u1", Name: "Ada", Age: 37})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff) != 1 || diff.Int("age") != 37 {
+		t.Errorf("diff = %v", diff)
+	}
+}
@@ -0,0 +1,100 @@
+package ekodb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Get returns the value at a dot-separated path such as
+// "profile.address.city", descending through nested objects (wrapped Object
+// fields included) and, for numeric segments, array elements. A key that
+// itself contains dots is matched as is before being treated as a path. The
+// value is returned as stored, so it may be a wrapped typed field; the typed
+// accessors (String, Int, ...) accept the same paths and unwrap it. Get
+// returns nil if the path does not exist.
+func (r Record) Get(path string) interface{} {
+	v, _ := r.Lookup(path)
+	return v
+}
+
+// Lookup is like Get but also reports whether the path exists.
+func (r Record) Lookup(path string) (interface{}, bool) {
+	if v, ok := r[path]; ok {
+		return v, true
+	}
+	if !strings.Contains(path, ".") {
+		return nil, false
+	}
+	var cur interface{} = map[string]interface{}(r)
+	for _, seg := range strings.Split(path, ".") {
+		next, ok := pathChild(cur, seg)
+		if !ok {
+			return nil, false
+		}
+		cur = next
+	}
+	return cur, true
+}
+
+// Set stores value at a dot-separated path, creating missing intermediate
+// objects. Existing objects are modified in place, including the value of a
+// wrapped Object field; numeric segments index existing array elements. It
+// fails if the path runs through a value that is neither an object nor an
+// array, or past the end of an array.
+//
+//	rec.Set("profile.address.city", "London")
+func (r Record) Set(path string, value interface{}) error {
+	if _, ok := r[path]; ok || !strings.Contains(path, ".") {
+		r[path] = value
+		return nil
+	}
+	segs := strings.Split(path, ".")
+	var cur interface{} = map[string]interface{}(r)
+	for i, seg := range segs[:len(segs)-1] {
+		next, ok := pathChild(cur, seg)
+		if !ok || next == nil {
+			next = map[string]interface{}{}
+			if !setPathChild(cur, seg, next) {
+				return fmt.Errorf("cannot set %s: %s has no element %s", path, strings.Join(segs[:i], "."), seg)
+			}
+		}
+		cur = next
+	}
+	if !setPathChild(cur, segs[len(segs)-1], value) {
+		return fmt.Errorf("cannot set %s: %s has no element %s", path, strings.Join(segs[:len(segs)-1], "."), segs[len(segs)-1])
+	}
+	return nil
+}
+
+// pathChild returns the seg child of an object or array, unwrapping typed
+// fields.
+func pathChild(container interface{}, seg string) (interface{}, bool) {
+	value := GetValue(container)
+	if m, ok := asObject(value); ok {
+		child, ok := m[seg]
+		return child, ok
+	}
+	if arr, ok := value.([]interface{}); ok {
+		if i, err := strconv.Atoi(seg); err == nil && i >= 0 && i < len(arr) {
+			return arr[i], true
+		}
+	}
+	return nil, false
+}
+
+// setPathChild stores the seg child of an object or array in place.
+func setPathChild(container interface{}, seg string, child interface{}) bool {
+	value := GetValue(container)
+	if m, ok := asObject(value); ok {
+		m[seg] = child
+		return true
+	}
+	if arr, ok := value.([]interface{}); ok {
+		if i, err := strconv.Atoi(seg); err == nil && i >= 0 && i < len(arr) {
+			arr[i] = child
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package ekodb
+
+import "testing"
+
+func TestRecordPathGetSet(t *testing.T) {
+	rec := Record{
+		"profile": FieldObject(map[string]interface{}{
+			"name":    "Ada",
+			"address": map[string]interface{}{"city": FieldString("London")},
+		}),
+		"tags":       []interface{}{map[string]interface{}{"label": "x"}},
+		"dotted.key": 1,
+	}
+
+	if got := rec.String("profile.address.city"); got != "London" {
+		t.Errorf("String(profile.address.city) = %q", got)
+	}
+	if got := rec.Get("tags.0.label"); got != "x" {
+		t.Errorf("Get(tags.0.label) = %v", got)
+	}
+	if got := rec.Int("dotted.key"); got != 1 {
+		t.Errorf("Int(dotted.key) = %d", got)
+	}
+	if _, ok := rec.Lookup("profile.missing.city"); ok {
+		t.Error("Lookup found a missing path")
+	}
+	if got := rec.Get("tags.5"); got != nil {
+		t.Errorf("Get(tags.5) = %v", got)
+	}
+
+	if err := rec.Set("profile.address.city", "Paris"); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.String("profile.address.city"); got != "Paris" {
+		t.Errorf("after Set, city = %q", got)
+	}
+	// The wrapped Object field is updated in place, not replaced
+	if m, ok := rec["profile"].(map[string]interface{}); !ok || m["type"] != "Object" {
+		t.Errorf("profile wrapper lost: %v", rec["profile"])
+	}
+	if err := rec.Set("settings.theme.color", "dark"); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.String("settings.theme.color"); got != "dark" {
+		t.Errorf("created path = %q", got)
+	}
+	if err := rec.Set("tags.0.label", "y"); err != nil || rec.Get("tags.0.label") != "y" {
+		t.Errorf("Set(tags.0.label) = %v, value %v", err, rec.Get("tags.0.label"))
+	}
+	if err := rec.Set("profile.name.first", "A"); err == nil {
+		t.Error("Set through a string succeeded")
+	}
+	if err := rec.Set("tags.3.label", "z"); err == nil {
+		t.Error("Set past the end of an array succeeded")
+	}
+}
@@ -0,0 +1,231 @@
+package ekodb
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// validationSchemaTTL is how long a fetched schema is trusted by
+// ValidateRecords and ValidateVectors before it is fetched again.
+const validationSchemaTTL = 5 * time.Minute
+
+// validationSchemas caches the collection schemas client-side validation
+// checks against. It is shared with derived clients.
+type validationSchemas struct {
+	mu      sync.Mutex
+	entries map[string]validationSchemaEntry
+}
+
+type validationSchemaEntry struct {
+	schema   *Schema // Nil if the schema could not be fetched
+	cachedAt time.Time
+}
+
+func newValidationSchemas() *validationSchemas {
+	return &validationSchemas{entries: make(map[string]validationSchemaEntry)}
+}
+
+// validationSchema returns collection's schema, fetching it when it is not
+// cached. A schema that cannot be fetched (for example, a collection that
+// does not exist yet) returns nil, which disables checking for that
+// collection until the entry expires.
+func (c *Client) validationSchema(collection string) *Schema {
+	cache := c.validationSchemas
+	if cache == nil {
+		return nil
+	}
+	cache.mu.Lock()
+	entry, ok := cache.entries[collection]
+	cache.mu.Unlock()
+	if ok && time.Since(entry.cachedAt) < validationSchemaTTL {
+		return entry.schema
+	}
+
+	schema, err := c.GetSchema(collection)
+	if err != nil {
+		schema = nil
+	}
+	cache.mu.Lock()
+	cache.entries[collection] = validationSchemaEntry{schema: schema, cachedAt: time.Now()}
+	cache.mu.Unlock()
+	return schema
+}
+
+// InvalidateValidationSchema forgets the schema cached for ValidateRecords
+// and ValidateVectors, e.g. after changing it, so the next write or search
+// fetches it again.
+func (c *Client) InvalidateValidationSchema(collection string) {
+	if cache := c.validationSchemas; cache != nil {
+		cache.mu.Lock()
+		delete(cache.entries, collection)
+		cache.mu.Unlock()
+	}
+}
+
+// FieldViolation is one way a record breaks its collection's schema.
+type FieldViolation struct {
+	Record  int    // Index of the record in the batch; 0 for single-record writes
+	ID      string // The record's id, when known
+	Field   string // Field name
+	Rule    string // "required", "enum", "min", "max", or "regex"
+	Message string
+}
+
+func (v FieldViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Field, v.Message)
+}
+
+// ValidationError is returned, before anything is sent, when
+// ClientConfig.ValidateRecords finds records that break their collection's
+// schema. It lists every violation rather than only the first.
+type ValidationError struct {
+	Collection string
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		switch {
+		case v.ID != "":
+			parts[i] = fmt.Sprintf("record %s: %s", v.ID, v)
+		case v.Record > 0:
+			parts[i] = fmt.Sprintf("record %d: %s", v.Record, v)
+		default:
+			parts[i] = v.String()
+		}
+	}
+	return fmt.Sprintf("%d schema violation(s) in %s: %s", len(e.Violations), e.Collection, strings.Join(parts, "; "))
+}
+
+// validateWrite runs the enabled client-side checks on records about to be
+// written to collection. partial is set for updates, which need not carry
+// required fields.
+func (c *Client) validateWrite(collection string, partial bool, records ...Record) error {
+	if err := c.checkVectorDims(collection, records...); err != nil {
+		return err
+	}
+	if !c.validateRecords {
+		return nil
+	}
+	schema := c.validationSchema(collection)
+	if schema == nil {
+		return nil
+	}
+	var violations []FieldViolation
+	for i, rec := range records {
+		violations = append(violations, validateRecord(schema, rec, i, partial)...)
+	}
+	if len(violations) > 0 {
+		return &ValidationError{Collection: collection, Violations: violations}
+	}
+	return nil
+}
+
+// validateUpdates runs validateWrite on BatchUpdate's records, in id order,
+// and labels violations with the id they were given under.
+func (c *Client) validateUpdates(collection string, updates map[string]Record) error {
+	if c.validationSchemas == nil {
+		return nil
+	}
+	ids := sortedKeys(updates)
+	records := make([]Record, len(ids))
+	for i, id := range ids {
+		records[i] = updates[id]
+	}
+	err := c.validateWrite(collection, true, records...)
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		for i := range verr.Violations {
+			verr.Violations[i].ID = ids[verr.Violations[i].Record]
+		}
+	}
+	return err
+}
+
+// validateRecord checks rec against schema's required, enum, min/max, and
+// regex constraints.
+func validateRecord(schema *Schema, rec Record, index int, partial bool) []FieldViolation {
+	var violations []FieldViolation
+	id, _ := GetValue(rec["id"]).(string)
+	add := func(field, rule, format string, args ...interface{}) {
+		violations = append(violations, FieldViolation{Record: index, ID: id, Field: field, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+	for _, name := range sortedKeys(schema.Fields) {
+		field := schema.Fields[name]
+		raw, present := rec[name]
+		value := GetValue(raw)
+		if value == nil {
+			if field.Required && (present || !partial) && field.Default == nil {
+				add(name, "required", "is required")
+			}
+			continue
+		}
+
+		if len(field.Enums) > 0 && !enumContains(field.Enums, value) {
+			add(name, "enum", "%v is not one of %v", value, field.Enums)
+		}
+		if n, ok := validationNumber(field.FieldType, value); ok {
+			if min, ok := validationNumber("Number", field.Min); ok && n < min {
+				add(name, "min", "%v is less than the minimum %v", value, field.Min)
+			}
+			if max, ok := validationNumber("Number", field.Max); ok && n > max {
+				add(name, "max", "%v is greater than the maximum %v", value, field.Max)
+			}
+		}
+		if field.Regex != nil {
+			if s, ok := value.(string); ok {
+				re, err := compileValidationRegex(*field.Regex)
+				if err == nil && !re.MatchString(s) {
+					add(name, "regex", "%q does not match %s", s, *field.Regex)
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// validationNumber returns value as a number if it is one, or if it is a
+// numeric string in a numeric field (e.g. a Decimal).
+func validationNumber(fieldType string, value interface{}) (float64, bool) {
+	if value == nil {
+		return 0, false
+	}
+	if _, isString := value.(string); isString {
+		switch fieldType {
+		case "Integer", "Float", "Number", "Decimal":
+		default:
+			return 0, false
+		}
+	}
+	return codecFloat(value)
+}
+
+func enumContains(enums []interface{}, value interface{}) bool {
+	want := fmt.Sprint(value)
+	for _, e := range enums {
+		if fmt.Sprint(GetValue(e)) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// validationRegexes caches compiled schema patterns.
+var validationRegexes sync.Map // string -> *regexp.Regexp
+
+func compileValidationRegex(pattern string) (*regexp.Regexp, error) {
+	if re, ok := validationRegexes.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	validationRegexes.Store(pattern, re)
+	return re, nil
+}
@@ -0,0 +1,91 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateRecords(t *testing.T) {
+	writes := 0
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/collections/users": func(w http.ResponseWriter, r *http.Request) {
+			schema := NewSchemaBuilder().
+				AddField("email", NewFieldTypeSchemaBuilder("String").Required().Pattern(`^[^@]+@[^@]+$`).Build()).
+				AddField("role", NewFieldTypeSchemaBuilder("String").Enums([]interface{}{"admin", "user"}).Build()).
+				AddField("age", NewFieldTypeSchemaBuilder("Integer").Range(0, 150).Build()).
+				Build()
+			_ = json.NewEncoder(w).Encode(CollectionMetadata{Collection: schema})
+		},
+		"GET /api/collections/*": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		},
+		"POST /api/insert/*": func(w http.ResponseWriter, r *http.Request) {
+			writes++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "u1"})
+		},
+		"PUT /api/update/users/*": func(w http.ResponseWriter, r *http.Request) {
+			writes++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "u1"})
+		},
+		"POST /api/batch/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			writes++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": []string{}, "failed": []interface{}{}})
+		},
+	})
+	defer server.Close()
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:         server.URL,
+		APIKey:          "test-api-key",
+		Timeout:         5 * time.Second,
+		Format:          JSON,
+		ValidateRecords: true,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+
+	if _, err := client.Insert("users", Record{"email": "a@b.c", "role": "admin", "age": 30}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	_, err = client.Insert("users", Record{"email": FieldString("nope"), "role": "root", "age": FieldInteger(200)})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+	rules := map[string]string{}
+	for _, v := range verr.Violations {
+		rules[v.Field] = v.Rule
+	}
+	if len(verr.Violations) != 3 || rules["email"] != "regex" || rules["role"] != "enum" || rules["age"] != "max" {
+		t.Errorf("violations = %+v", verr.Violations)
+	}
+
+	if _, err := client.Insert("users", Record{"age": -1}); !errors.As(err, &verr) || len(verr.Violations) != 2 {
+		t.Errorf("expected required and min violations, got %v", err)
+	}
+	// Updates are partial, so a missing required field is fine
+	if _, err := client.Update("users", "u1", Record{"age": 40}); err != nil {
+		t.Errorf("Update failed: %v", err)
+	}
+	_, err = client.BatchInsert("users", []Record{{"email": "a@b.c"}, {"email": "x@y.z", "role": "guest"}})
+	if !errors.As(err, &verr) || len(verr.Violations) != 1 || verr.Violations[0].Record != 1 {
+		t.Errorf("expected one violation in record 1, got %v", err)
+	}
+	_, err = client.BatchUpdate("users", map[string]Record{"u1": {"role": "admin"}, "u2": {"email": nil}})
+	if !errors.As(err, &verr) || len(verr.Violations) != 1 || verr.Violations[0].ID != "u2" || !strings.Contains(err.Error(), "record u2") {
+		t.Errorf("expected a required violation on u2, got %v", err)
+	}
+
+	// Collections without a schema are not checked
+	if _, err := client.Insert("other", Record{"anything": 1}); err != nil {
+		t.Errorf("Insert into unknown collection failed: %v", err)
+	}
+	if writes != 3 {
+		t.Errorf("writes = %d, want 3", writes)
+	}
+}
@@ -0,0 +1,45 @@
+package ekodb
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// renameCollectionFeature is the ServerInfo feature flag for the rename
+// endpoint.
+const renameCollectionFeature = "rename_collection"
+
+// RenameCollection renames a collection. Servers that advertise the
+// "rename_collection" feature rename it in place. Otherwise the collection
+// is copied to newName with its schema (see CopyCollection) and then
+// deleted; in that case writes to oldName during the copy may be lost, and
+// a failure leaves a partial copy in newName (and oldName intact).
+//
+// It fails if newName already exists.
+func (c *Client) RenameCollection(oldName, newName string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
+	if oldName == newName {
+		return fmt.Errorf("rename collection: %s is already named that", oldName)
+	}
+	exists, err := c.CollectionExists(newName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("rename collection %s: %s already exists", oldName, newName)
+	}
+
+	if c.serverFeature(renameCollectionFeature) {
+		path := fmt.Sprintf("/api/collections/%s/rename", url.PathEscape(oldName))
+		_, err := c.makeRequest("POST", path, map[string]string{"new_name": newName})
+		return err
+	}
+
+	if _, err := c.CopyCollection(oldName, newName); err != nil {
+		return fmt.Errorf("rename collection %s: %w", oldName, err)
+	}
+	if err := c.DeleteCollection(oldName); err != nil {
+		return fmt.Errorf("rename collection %s: copied to %s but delete failed: %w", oldName, newName, err)
+	}
+	return nil
+}
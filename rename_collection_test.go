@@ -0,0 +1,76 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRenameCollectionServerSide(t *testing.T) {
+	var body map[string]string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"rename_collection"}})
+		},
+		"GET /api/collections": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"collections": []string{"users", "teams"}})
+		},
+		"POST /api/collections/users/rename": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	if err := client.RenameCollection("users", "members"); err != nil {
+		t.Fatalf("RenameCollection failed: %v", err)
+	}
+	if body["new_name"] != "members" {
+		t.Errorf("rename body = %v", body)
+	}
+	if err := client.RenameCollection("users", "teams"); err == nil {
+		t.Error("expected an error renaming onto an existing collection")
+	}
+}
+
+func TestRenameCollectionFallback(t *testing.T) {
+	var deleted, inserted bool
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0"})
+		},
+		"GET /api/collections": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"collections": []string{"users"}})
+		},
+		"GET /api/collections/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"collection": map[string]interface{}{"fields": map[string]interface{}{}}})
+		},
+		"POST /api/collections/members": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": "u1"}})
+		},
+		"POST /api/batch/insert/members": func(w http.ResponseWriter, r *http.Request) {
+			inserted = true
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": []string{"u1"}, "failed": []interface{}{}})
+		},
+		"DELETE /api/collections/users": func(w http.ResponseWriter, r *http.Request) {
+			if !inserted {
+				t.Error("source deleted before the copy")
+			}
+			deleted = true
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	if err := client.RenameCollection("users", "members"); err != nil {
+		t.Fatalf("RenameCollection failed: %v", err)
+	}
+	if !inserted || !deleted {
+		t.Errorf("inserted %v, deleted %v", inserted, deleted)
+	}
+}
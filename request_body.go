@@ -0,0 +1,81 @@
+package ekodb
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// encodedBody is a request body that makeRequestWithRetry has already
+// serialized, so retries resend the same bytes instead of encoding again.
+type encodedBody []byte
+
+// bufferPool holds the scratch buffers used to encode request bodies and
+// read response bodies of unknown length.
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// maxPooledBuffer keeps an occasional huge body from pinning its buffer in
+// the pool.
+const maxPooledBuffer = 1 << 20
+
+// maxPresizedBody caps the allocation made up front for a response that
+// declares its Content-Length.
+const maxPresizedBody = 32 << 20
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBuffer {
+		bufferPool.Put(buf)
+	}
+}
+
+// encodeRequestBody serializes data as MessagePack or JSON.
+func encodeRequestBody(data interface{}, useMsgpack bool) (encodedBody, error) {
+	if !useMsgpack {
+		// json.Marshal already encodes into a pooled buffer and returns an
+		// exact-size copy.
+		return json.Marshal(data)
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	enc := msgpack.GetEncoder()
+	defer msgpack.PutEncoder(enc)
+	enc.Reset(buf)
+	if err := enc.Encode(data); err != nil {
+		return nil, err
+	}
+	body := make([]byte, buf.Len())
+	copy(body, buf.Bytes())
+	return body, nil
+}
+
+// readResponseBody reads a response body with a single allocation of the
+// final size: up front when the server declares size, otherwise after
+// reading into a pooled buffer.
+func readResponseBody(r io.Reader, size int64) ([]byte, error) {
+	if size > 0 && size <= maxPresizedBody {
+		body := make([]byte, size)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return nil, err
+		}
+		return body, nil
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	body := make([]byte, buf.Len())
+	copy(body, buf.Bytes())
+	return body, nil
+}
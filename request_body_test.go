@@ -0,0 +1,136 @@
+package ekodb
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// benchmarkRequestClient returns a client for a server that answers every
+// request with response, in the client's format.
+func benchmarkRequestClient(b *testing.B, format SerializationFormat, response []byte) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth/token" {
+			_, _ = w.Write([]byte(`{"token":"test-jwt-token"}`))
+			return
+		}
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = w.Write(response)
+	}))
+	b.Cleanup(server.Close)
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL: server.URL,
+		APIKey:  "test-api-key",
+		Timeout: 5 * time.Second,
+		Format:  format,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return client
+}
+
+func BenchmarkMakeRequestBatchInsertMsgpack(b *testing.B) {
+	payload := benchmarkBatch(100)
+	client := benchmarkRequestClient(b, MessagePack, bytes.Repeat([]byte{0xc0}, 1))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.makeRequest("POST", "/api/batch_insert/bench", payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMakeRequestBatchInsertJSON(b *testing.B) {
+	payload := benchmarkBatch(100)
+	client := benchmarkRequestClient(b, JSON, []byte(`{}`))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.makeRequest("POST", "/api/batch/insert/bench", payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMakeRequestLargeResponse(b *testing.B) {
+	response := bytes.Repeat([]byte("x"), 256*1024)
+	client := benchmarkRequestClient(b, JSON, response)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.makeRequest("GET", "/api/find/bench", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// countingBody counts how often it is encoded.
+type countingBody struct {
+	encodes *int
+}
+
+func (b countingBody) MarshalJSON() ([]byte, error) {
+	*b.encodes++
+	return []byte(`{"n":1}`), nil
+}
+
+func TestMakeRequestEncodesOnceAcrossRetries(t *testing.T) {
+	var bodies []string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/echo": func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			if len(bodies) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		},
+	})
+	defer server.Close()
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:     server.URL,
+		APIKey:      "test-api-key",
+		ShouldRetry: true,
+		MaxRetries:  1,
+		Timeout:     5 * time.Second,
+		Format:      JSON,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var encodes int
+	resp, err := client.makeRequest("POST", "/api/echo", countingBody{&encodes})
+	if err != nil {
+		t.Fatalf("makeRequest failed: %v", err)
+	}
+	if string(resp) != `{"ok":true}` {
+		t.Errorf("response = %q", resp)
+	}
+	if encodes != 1 {
+		t.Errorf("body encoded %d times, want 1", encodes)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[0] != `{"n":1}` {
+		t.Errorf("bodies = %q", bodies)
+	}
+}
+
+func TestReadResponseBody(t *testing.T) {
+	data := bytes.Repeat([]byte("abc"), 1000)
+	for _, size := range []int64{int64(len(data)), -1} {
+		got, err := readResponseBody(bytes.NewReader(data), size)
+		if err != nil || !bytes.Equal(got, data) {
+			t.Errorf("size %d: got %d bytes, %v", size, len(got), err)
+		}
+	}
+	if _, err := readResponseBody(bytes.NewReader(data[:10]), 20); err == nil {
+		t.Error("expected an error for a short body")
+	}
+	if got, err := readResponseBody(bytes.NewReader(nil), -1); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("empty body = %v, %v", got, err)
+	}
+}
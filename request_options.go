@@ -0,0 +1,149 @@
+package ekodb
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// RequestOption overrides client settings for a single call, or for every
+// call made through a derived client (see Client.With). Methods without an
+// options struct take them as trailing arguments:
+//
+//	value, err := client.KVGet("session:42", WithTimeout(500*time.Millisecond))
+//	result, err := client.CallFunction("rebuild_index", nil, WithTimeout(5*time.Minute), WithNoRetry())
+//
+// Methods that already take a variadic options struct carry them in its
+// RequestOptions field instead:
+//
+//	records, err := client.Find("orders", query, FindOptions{
+//	    RequestOptions: []RequestOption{WithTimeout(2 * time.Second)},
+//	})
+type RequestOption func(*Client)
+
+// WithTimeout sets the per-request timeout, replacing ClientConfig.Timeout.
+// Use a short timeout for latency-sensitive KV reads and a long one for
+// multi-minute function executions. Zero disables the timeout.
+//
+// The timeout also bounds streaming calls (chat streams, InsertStream),
+// which otherwise run without one.
+func WithTimeout(d time.Duration) RequestOption {
+	return func(c *Client) {
+		c.httpClient = &http.Client{
+			Transport: c.httpClient.Transport,
+			Timeout:   d,
+		}
+		if c.streamClient != nil {
+			c.streamClient = &http.Client{
+				Transport: c.streamClient.Transport,
+				Timeout:   d,
+			}
+		}
+	}
+}
+
+// WithNoRetry disables automatic retries (network errors, 429, 503).
+func WithNoRetry() RequestOption {
+	return func(c *Client) {
+		c.shouldRetry = false
+	}
+}
+
+// WithMaxRetries enables automatic retries with at most n attempts after the
+// first. n <= 0 is equivalent to WithNoRetry.
+func WithMaxRetries(n int) RequestOption {
+	return func(c *Client) {
+		c.shouldRetry = n > 0
+		c.maxRetries = n
+	}
+}
+
+// WithFormat sets the serialization format, e.g. JSON for a subsystem whose
+// traffic should be readable in proxies while the rest stays on MessagePack.
+func WithFormat(format SerializationFormat) RequestOption {
+	return func(c *Client) {
+		c.format = format
+	}
+}
+
+// WithLogger routes the derived client's diagnostic lines to logger instead
+// of ClientConfig.Logger. Nil restores the standard logger.
+func WithLogger(logger *log.Logger) RequestOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithMaxConcurrency caps the requests fan-out helpers keep in flight,
+// replacing ClientConfig.MaxConcurrency. n <= 0 restores the default.
+func WithMaxConcurrency(n int) RequestOption {
+	return func(c *Client) {
+		c.maxConcurrency = n
+	}
+}
+
+// WithHeader adds a header to every request made through the derived client,
+// on top of ClientConfig.DefaultHeaders — e.g. tagging a subsystem's traffic.
+func WithHeader(name, value string) RequestOption {
+	return func(c *Client) {
+		headers := make(map[string]string, len(c.defaultHeaders)+1)
+		for k, v := range c.defaultHeaders {
+			headers[k] = v
+		}
+		headers[name] = value
+		c.defaultHeaders = headers
+	}
+}
+
+// ShardHintHeader carries the shard key value set by ShardHint.
+const ShardHintHeader = "X-EkoDB-Shard-Key"
+
+// ShardHint tells the server that the requests made through the derived
+// client only touch records whose shard key (see SchemaBuilder.ShardKey)
+// equals value, so a sharded server can execute them on a single shard
+// instead of fanning out. The value is sent in the ShardHintHeader header,
+// formatted with fmt.Sprint; servers without sharding ignore it.
+//
+//	eu := client.With(ShardHint("eu-west"))
+//	orders, err := eu.Find("orders", query)
+func ShardHint(value interface{}) RequestOption {
+	return WithHeader(ShardHintHeader, fmt.Sprint(value))
+}
+
+// With returns a derived client whose calls apply opts, for settings shared by
+// several calls:
+//
+//	reports := client.With(WithTimeout(5*time.Minute), WithNoRetry())
+//
+// Deriving is cheap: the derived client shares the parent's authentication
+// token, rate-limit state, and connection pool, so it can be created per call
+// or kept for the lifetime of a subsystem:
+//
+//	analytics := client.With(WithTimeout(2*time.Minute), WithFormat(JSON),
+//	    WithLogger(log.New(os.Stderr, "[analytics] ", log.LstdFlags)))
+func (c *Client) With(opts ...RequestOption) *Client {
+	derived := c.derive()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(derived)
+		}
+	}
+	return derived
+}
+
+// withOptions returns a client applying opts, or c itself when there are
+// none.
+func (c *Client) withOptions(opts []RequestOption) *Client {
+	if len(opts) == 0 {
+		return c
+	}
+	return c.With(opts...)
+}
+
+// Clone returns a derived client with the same settings as c. It shares c's
+// authentication token, rate-limit state, and connection pool; it is
+// equivalent to c.With() and exists for readability.
+func (c *Client) Clone() *Client {
+	return c.With()
+}
@@ -0,0 +1,255 @@
+package ekodb
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestWithTimeoutOverridesClientTimeout(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server) // 5s client-wide timeout
+
+	if err := client.With(WithTimeout(50 * time.Millisecond)).Health(); err == nil {
+		t.Fatal("expected per-request timeout to fire")
+	}
+	if err := client.Health(); err != nil {
+		t.Fatalf("base client should keep its own timeout: %v", err)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("base client timeout mutated to %v", client.httpClient.Timeout)
+	}
+
+	derived := client.With(WithTimeout(time.Minute))
+	if derived.httpClient.Transport != client.httpClient.Transport {
+		t.Error("derived client must share the parent's transport")
+	}
+}
+
+func TestWithTimeoutAppliesToStreams(t *testing.T) {
+	server := createTestServer(t, nil)
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	derived := client.With(WithTimeout(time.Minute))
+	if derived.streamClient.Timeout != time.Minute {
+		t.Errorf("stream timeout = %v, want 1m", derived.streamClient.Timeout)
+	}
+	if derived.streamClient.Transport != client.streamClient.Transport {
+		t.Error("derived stream client must share the parent's transport")
+	}
+	if client.streamClient.Timeout != 0 {
+		t.Errorf("base stream timeout mutated to %v", client.streamClient.Timeout)
+	}
+}
+
+func TestRequestOptionsAppliedOnce(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/batch/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": []string{"u1"}, "failed": []interface{}{}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	var applied int32
+	count := func(*Client) { atomic.AddInt32(&applied, 1) }
+	records := []Record{{"name": "a"}}
+
+	if _, err := client.BatchInsert("users", records, BatchInsertOptions{RequestOptions: []RequestOption{count}}); err != nil {
+		t.Fatalf("BatchInsert failed: %v", err)
+	}
+	if got := atomic.SwapInt32(&applied, 0); got != 1 {
+		t.Errorf("BatchInsert applied its options %d times, want 1", got)
+	}
+	opts := BatchInsertOptions{RequestOptions: []RequestOption{count}}
+	if _, err := client.BatchInsertChunked("users", records, ChunkOptions{Size: 1}, opts); err != nil {
+		t.Fatalf("BatchInsertChunked failed: %v", err)
+	}
+	if got := atomic.SwapInt32(&applied, 0); got != 1 {
+		t.Errorf("BatchInsertChunked applied its options %d times, want 1", got)
+	}
+}
+
+func TestPerCallRequestOptions(t *testing.T) {
+	var headers []string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			headers = append(headers, r.Header.Get("X-Subsystem"))
+			_ = json.NewEncoder(w).Encode([]Record{{"id": "u1"}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	if err := client.Health(WithTimeout(50 * time.Millisecond)); err == nil {
+		t.Fatal("expected the per-call timeout to fire")
+	}
+	if err := client.Health(); err != nil {
+		t.Fatalf("the override must not outlive the call: %v", err)
+	}
+
+	opts := FindOptions{RequestOptions: []RequestOption{WithHeader("X-Subsystem", "reports")}}
+	if _, err := client.Find("users", NewQueryBuilder().Build(), opts); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if _, err := client.Find("users", NewQueryBuilder().Build()); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(headers) != 2 || headers[0] != "reports" || headers[1] != "" {
+		t.Errorf("headers = %q", headers)
+	}
+}
+
+func TestWithRetryOverrides(t *testing.T) {
+	var calls int32
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server) // retries disabled
+
+	_ = client.With(WithMaxRetries(2)).Health()
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 1 attempt + 2 retries, got %d calls", got)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	_ = client.With(WithMaxRetries(2), WithNoRetry()).Health()
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("WithNoRetry should disable retries, got %d calls", got)
+	}
+	if client.shouldRetry {
+		t.Error("base client retry setting mutated")
+	}
+}
+
+func TestWithFormatLoggerAndHeader(t *testing.T) {
+	var contentType, subsystem string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			contentType = r.Header.Get("Content-Type")
+			subsystem = r.Header.Get("X-Subsystem")
+			if contentType == "application/msgpack" {
+				b, _ := msgpack.Marshal([]Record{})
+				_, _ = w.Write(b)
+				return
+			}
+			_ = json.NewEncoder(w).Encode([]Record{})
+		},
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server) // JSON
+	var logs bytes.Buffer
+	derived := client.With(
+		WithFormat(MessagePack),
+		WithLogger(log.New(&logs, "", 0)),
+		WithHeader("X-Subsystem", "analytics"),
+	)
+
+	if _, err := derived.Find("users", nil); err != nil {
+		t.Fatalf("Find via derived client failed: %v", err)
+	}
+	if contentType != "application/msgpack" || subsystem != "analytics" {
+		t.Errorf("derived request: Content-Type=%q X-Subsystem=%q", contentType, subsystem)
+	}
+
+	if _, err := client.Find("users", nil); err != nil {
+		t.Fatalf("Find via base client failed: %v", err)
+	}
+	if contentType != "application/json" || subsystem != "" {
+		t.Errorf("base client affected by derived options: Content-Type=%q X-Subsystem=%q", contentType, subsystem)
+	}
+
+	_ = derived.With(WithMaxRetries(1)).Health()
+	if !strings.Contains(logs.String(), "Rate limited, retrying") {
+		t.Errorf("expected retry log on the derived logger, got %q", logs.String())
+	}
+}
+
+func TestCloneSharesAuthState(t *testing.T) {
+	server := createTestServer(t, nil)
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	clone := client.Clone()
+	if clone == client || clone.root() != client {
+		t.Fatal("Clone should return a derived client rooted at the original")
+	}
+	clone.ClearTokenCache()
+	if token := client.getToken(); token != "" {
+		t.Errorf("clearing the clone's token should clear the original's, got %q", token)
+	}
+	if clone.httpClient != client.httpClient {
+		t.Error("Clone should share the HTTP client")
+	}
+}
+
+func TestShardHint(t *testing.T) {
+	var hint string
+	var created Schema
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/find/orders": func(w http.ResponseWriter, r *http.Request) {
+			hint = r.Header.Get(ShardHintHeader)
+			_ = json.NewEncoder(w).Encode([]Record{})
+		},
+		"POST /api/collections/orders": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	schema := NewSchemaBuilder().
+		AddField("region", NewFieldTypeSchemaBuilder("String").Build()).
+		ShardKey("region").
+		Build()
+	if err := client.CreateCollection("orders", schema); err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	if created.ShardKey != "region" {
+		t.Errorf("shard_key not sent, got %+v", created)
+	}
+
+	if _, err := client.With(ShardHint(42)).Find("orders", nil); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if hint != "42" {
+		t.Errorf("%s = %q, want 42", ShardHintHeader, hint)
+	}
+	if _, err := client.Find("orders", nil); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if hint != "" {
+		t.Errorf("base client sent a shard hint: %q", hint)
+	}
+}
@@ -0,0 +1,69 @@
+package ekodb
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryDecision is the verdict of a RetryClassifier.
+type RetryDecision int
+
+const (
+	// RetryDefault applies the built-in policy: retry network errors, 429,
+	// and 503.
+	RetryDefault RetryDecision = iota
+	// RetryRequest retries the request.
+	RetryRequest
+	// NoRetry fails the request immediately.
+	NoRetry
+)
+
+// RetryClassifier decides whether a failed request is retried. It is called
+// with the response for any non-2xx status (resp.Body can be read; err is
+// nil) or with the transport error when no response was received (resp is
+// nil). 401 responses are not passed to it: they trigger a token refresh.
+//
+// Retries still require ClientConfig.ShouldRetry and stop after MaxRetries.
+// A retried response waits for its Retry-After header when present, otherwise
+// for the client's exponential backoff.
+//
+// Example — retry 502s from a proxy, never retry conflicts:
+//
+//	RetryClassifier: func(resp *http.Response, err error) ekodb.RetryDecision {
+//	    if resp != nil && resp.StatusCode == http.StatusBadGateway {
+//	        return ekodb.RetryRequest
+//	    }
+//	    if resp != nil && resp.StatusCode == http.StatusConflict {
+//	        return ekodb.NoRetry
+//	    }
+//	    return ekodb.RetryDefault
+//	}
+type RetryClassifier func(resp *http.Response, err error) RetryDecision
+
+// WithRetryClassifier sets the RetryClassifier for the derived client,
+// replacing ClientConfig.RetryClassifier. Nil restores the built-in policy.
+func WithRetryClassifier(classifier RetryClassifier) RequestOption {
+	return func(c *Client) {
+		c.retryClassifier = classifier
+	}
+}
+
+// classifyRetry consults the configured RetryClassifier, if any.
+func (c *Client) classifyRetry(resp *http.Response, err error) RetryDecision {
+	if c.retryClassifier == nil {
+		return RetryDefault
+	}
+	return c.retryClassifier(resp, err)
+}
+
+// classifiedRetryDelay is the wait before a retry chosen by a RetryClassifier:
+// the response's Retry-After seconds when given, else the jittered backoff.
+func classifiedRetryDelay(resp *http.Response, attempt int) time.Duration {
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return retryBackoff(attempt)
+}
@@ -0,0 +1,119 @@
+package ekodb
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryClassifierRetriesCustomStatus(t *testing.T) {
+	var calls int32
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusBadGateway)
+				_, _ = w.Write([]byte("proxy hiccup"))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		},
+	})
+	defer server.Close()
+
+	var sawBody string
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:     server.URL,
+		APIKey:      "test-api-key",
+		Format:      JSON,
+		ShouldRetry: true,
+		RetryClassifier: func(resp *http.Response, err error) RetryDecision {
+			if resp != nil && resp.StatusCode == http.StatusBadGateway {
+				b, _ := io.ReadAll(resp.Body)
+				sawBody = string(b)
+				return RetryRequest
+			}
+			return RetryDefault
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+
+	if err := client.Health(); err != nil {
+		t.Fatalf("Health should succeed after retrying 502s: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+	if sawBody != "proxy hiccup" {
+		t.Errorf("classifier should be able to read the body, got %q", sawBody)
+	}
+
+	// Without the classifier a 502 is not retried.
+	atomic.StoreInt32(&calls, 0)
+	err = client.With(WithRetryClassifier(nil)).Health()
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected HTTPError 502, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected 1 call with the default policy, got %d", got)
+	}
+}
+
+func TestRetryClassifierNoRetryOverridesDefault(t *testing.T) {
+	var calls int32
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server).With(
+		WithMaxRetries(3),
+		WithRetryClassifier(func(resp *http.Response, err error) RetryDecision { return NoRetry }),
+	)
+
+	err := client.Health()
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Errorf("expected RateLimitError, got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("NoRetry should stop after 1 call, got %d", got)
+	}
+}
+
+func TestRetryClassifierSeesNetworkErrors(t *testing.T) {
+	server := createTestServer(t, nil)
+	client := createTestClient(t, server)
+	server.Close()
+
+	var networkErrs int32
+	start := time.Now()
+	err := client.With(
+		WithMaxRetries(3),
+		WithRetryClassifier(func(resp *http.Response, err error) RetryDecision {
+			if resp == nil && err != nil {
+				atomic.AddInt32(&networkErrs, 1)
+			}
+			return NoRetry
+		}),
+	).Health()
+	if err == nil {
+		t.Fatal("expected a network error")
+	}
+	if got := atomic.LoadInt32(&networkErrs); got != 1 {
+		t.Errorf("classifier should see the network error once, got %d", got)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("NoRetry should fail without backing off")
+	}
+}
@@ -0,0 +1,258 @@
+package ekodb
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SampleFieldGenerator produces the value of one field for the i-th generated
+// record, drawing randomness from rng.
+type SampleFieldGenerator func(rng *rand.Rand, i int) interface{}
+
+// SampleDataSpec controls GenerateSampleData and GenerateSampleRecords.
+type SampleDataSpec struct {
+	// Schema describes the fields to fabricate. GenerateSampleData fetches the
+	// collection's schema when nil.
+	Schema *Schema
+	// Fields overrides the generator for specific fields, or adds fields the
+	// schema does not define.
+	Fields map[string]SampleFieldGenerator
+	// VectorDimensions sets the dimension of individual Vector fields; others
+	// use DefaultVectorDimension (default: 384).
+	VectorDimensions       map[string]int
+	DefaultVectorDimension int
+	// Seed makes the output reproducible; 0 picks a random seed.
+	Seed uint64
+	// BatchSize is the number of records per BatchInsert (default: 500).
+	BatchSize int
+}
+
+// GenerateSampleData fabricates n records conforming to the collection's
+// schema and bulk inserts them, for demos and load tests against freshly
+// created collections. It returns the inserted records with their IDs.
+//
+// Values respect field types, enums, and Min/Max ranges. String fields are
+// filled based on their name — "email", "name", "phone", "url", "city", and
+// "country" get realistic values, others get words — and Unique fields get
+// a per-record suffix so they do not collide. Regex constraints are not
+// interpreted; supply a generator in spec.Fields for such fields.
+//
+// Example:
+//
+//	records, err := client.GenerateSampleData("users", 1000, SampleDataSpec{
+//	    Seed: 42,
+//	    VectorDimensions: map[string]int{"embedding": 1536},
+//	})
+func (c *Client) GenerateSampleData(collection string, n int, spec SampleDataSpec, opts ...RequestOption) ([]Record, error) {
+	c = c.withOptions(opts)
+	if spec.Schema == nil {
+		schema, err := c.GetSchema(collection)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load schema for %s: %w", collection, err)
+		}
+		spec.Schema = schema
+	}
+	records := GenerateSampleRecords(n, spec)
+
+	batchSize := spec.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	for start := 0; start < len(records); start += batchSize {
+		end := min(start+batchSize, len(records))
+		inserted, err := c.BatchInsert(collection, records[start:end])
+		if err != nil {
+			return records[:start], err
+		}
+		for i, rec := range inserted {
+			if id, ok := rec["id"]; ok && start+i < end {
+				records[start+i]["id"] = id
+			}
+		}
+	}
+	return records, nil
+}
+
+// GenerateSampleRecords fabricates n records from spec without inserting
+// them (see GenerateSampleData). spec.Schema may be nil when spec.Fields
+// describes every field.
+func GenerateSampleRecords(n int, spec SampleDataSpec) []Record {
+	seed := spec.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+
+	// Generate fields in a fixed order so a seed always yields the same values
+	// (DateTimes aside, which are relative to the current time).
+	var names []string
+	if spec.Schema != nil {
+		for name := range spec.Schema.Fields {
+			if name != "id" {
+				names = append(names, name)
+			}
+		}
+	}
+	for name := range spec.Fields {
+		if spec.Schema == nil || !hasSchemaField(spec.Schema, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	records := make([]Record, n)
+	for i := range records {
+		rec := make(Record, len(names))
+		for _, name := range names {
+			if gen, ok := spec.Fields[name]; ok {
+				rec[name] = gen(rng, i)
+				continue
+			}
+			rec[name] = sampleFieldValue(rng, i, name, spec.Schema.Fields[name], spec)
+		}
+		records[i] = rec
+	}
+	return records
+}
+
+func hasSchemaField(schema *Schema, name string) bool {
+	_, ok := schema.Fields[name]
+	return ok
+}
+
+var (
+	sampleFirstNames = []string{"Ada", "Alan", "Grace", "Linus", "Margaret", "Dennis", "Barbara", "Ken", "Radia", "Edsger"}
+	sampleLastNames  = []string{"Lovelace", "Turing", "Hopper", "Torvalds", "Hamilton", "Ritchie", "Liskov", "Thompson", "Perlman", "Dijkstra"}
+	sampleCities     = []string{"Lisbon", "Osaka", "Toronto", "Nairobi", "Berlin", "Austin", "Santiago", "Seoul"}
+	sampleCountries  = []string{"PT", "JP", "CA", "KE", "DE", "US", "CL", "KR"}
+	sampleWords      = []string{"alpha", "bravo", "delta", "echo", "harbor", "meadow", "orbit", "pixel", "quartz", "river", "summit", "vector"}
+)
+
+func sampleFieldValue(rng *rand.Rand, i int, name string, field FieldTypeSchema, spec SampleDataSpec) interface{} {
+	if len(field.Enums) > 0 {
+		return field.Enums[rng.IntN(len(field.Enums))]
+	}
+	lower := strings.ToLower(name)
+
+	switch field.FieldType {
+	case "Integer":
+		lo, hi := sampleRange(field, 0, 1000)
+		return int64(lo) + rng.Int64N(int64(hi-lo)+1)
+	case "Float", "Number":
+		lo, hi := sampleRange(field, 0, 1000)
+		return math.Round((lo+rng.Float64()*(hi-lo))*100) / 100
+	case "Decimal":
+		lo, hi := sampleRange(field, 0, 1000)
+		return FieldDecimal(fmt.Sprintf("%.2f", lo+rng.Float64()*(hi-lo)))
+	case "Boolean":
+		return rng.IntN(2) == 1
+	case "DateTime":
+		offset := time.Duration(rng.Int64N(int64(365 * 24 * time.Hour)))
+		return FieldDateTime(time.Now().UTC().Add(-offset).Truncate(time.Second))
+	case "Duration":
+		return FieldDuration(rng.Int64N(int64(time.Hour / time.Millisecond)))
+	case "UUID":
+		return FieldUUID(sampleUUID(rng))
+	case "Vector":
+		dim := spec.VectorDimensions[name]
+		if dim <= 0 {
+			dim = spec.DefaultVectorDimension
+		}
+		if dim <= 0 {
+			dim = 384
+		}
+		return FieldVector(sampleUnitVector(rng, dim))
+	case "Array":
+		return []interface{}{sampleWord(rng), sampleWord(rng)}
+	case "Set":
+		return FieldSet([]string{sampleWords[i%len(sampleWords)], sampleWord(rng) + "-" + sampleWord(rng)})
+	case "Object":
+		return map[string]interface{}{"key": sampleWord(rng)}
+	case "Binary", "Bytes":
+		b := make([]byte, 16)
+		for j := range b {
+			b[j] = byte(rng.UintN(256))
+		}
+		if field.FieldType == "Binary" {
+			return FieldBinary(b)
+		}
+		return FieldBytes(b)
+	}
+
+	// String and anything unrecognized: pick by field name. Emails and URLs
+	// embed the record index, so they are unique already.
+	switch {
+	case strings.Contains(lower, "email"):
+		return fmt.Sprintf("%s.%s%d@example.com",
+			strings.ToLower(sampleFirstNames[rng.IntN(len(sampleFirstNames))]),
+			strings.ToLower(sampleLastNames[rng.IntN(len(sampleLastNames))]), i)
+	case strings.Contains(lower, "url"), strings.Contains(lower, "website"):
+		return fmt.Sprintf("https://example.com/%s/%d", sampleWord(rng), i)
+	}
+	var s string
+	switch {
+	case strings.Contains(lower, "name"):
+		s = sampleFirstNames[rng.IntN(len(sampleFirstNames))] + " " + sampleLastNames[rng.IntN(len(sampleLastNames))]
+	case strings.Contains(lower, "phone"):
+		s = fmt.Sprintf("+1-555-%03d-%04d", rng.IntN(1000), rng.IntN(10000))
+	case strings.Contains(lower, "city"):
+		s = sampleCities[rng.IntN(len(sampleCities))]
+	case strings.Contains(lower, "country"):
+		s = sampleCountries[rng.IntN(len(sampleCountries))]
+	default:
+		s = sampleWord(rng) + " " + sampleWord(rng)
+	}
+	if field.Unique {
+		s = fmt.Sprintf("%s %d", s, i)
+	}
+	return s
+}
+
+// sampleRange returns the field's Min/Max as floats, or the defaults.
+func sampleRange(field FieldTypeSchema, lo, hi float64) (float64, float64) {
+	if v, ok := lintNumber(field.Min); ok {
+		lo = v
+	}
+	if v, ok := lintNumber(field.Max); ok {
+		hi = v
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi
+}
+
+func sampleWord(rng *rand.Rand) string {
+	return sampleWords[rng.IntN(len(sampleWords))]
+}
+
+func sampleUUID(rng *rand.Rand) string {
+	var b [16]byte
+	for i := range b {
+		b[i] = byte(rng.UintN(256))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func sampleUnitVector(rng *rand.Rand, dim int) []float64 {
+	v := make([]float64, dim)
+	var norm float64
+	for i := range v {
+		v[i] = rng.NormFloat64()
+		norm += v[i] * v[i]
+	}
+	norm = math.Sqrt(norm)
+	if norm == 0 {
+		norm = 1
+	}
+	for i := range v {
+		v[i] /= norm
+	}
+	return v
+}
@@ -0,0 +1,116 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func sampleTestSchema() *Schema {
+	return &Schema{Fields: map[string]FieldTypeSchema{
+		"email":     {FieldType: "String", Unique: true},
+		"full_name": {FieldType: "String"},
+		"age":       {FieldType: "Integer", Min: 18, Max: 65},
+		"tier":      {FieldType: "String", Enums: []interface{}{"free", "pro"}},
+		"active":    {FieldType: "Boolean"},
+		"joined":    {FieldType: "DateTime"},
+		"embedding": {FieldType: "Vector"},
+		"id":        {FieldType: "String"},
+	}}
+}
+
+func TestGenerateSampleRecordsConformsToSchema(t *testing.T) {
+	spec := SampleDataSpec{
+		Schema:           sampleTestSchema(),
+		Seed:             7,
+		VectorDimensions: map[string]int{"embedding": 12},
+		Fields: map[string]SampleFieldGenerator{
+			"sku": func(rng *rand.Rand, i int) interface{} { return fmt.Sprintf("SKU-%04d", i) },
+		},
+	}
+	records := GenerateSampleRecords(50, spec)
+	if len(records) != 50 {
+		t.Fatalf("got %d records, want 50", len(records))
+	}
+
+	emails := map[string]bool{}
+	for i, rec := range records {
+		if _, ok := rec["id"]; ok {
+			t.Fatal("id should be left to the server")
+		}
+		email, _ := rec["email"].(string)
+		if !strings.Contains(email, "@") || emails[email] {
+			t.Errorf("record %d: bad or duplicate email %q", i, email)
+		}
+		emails[email] = true
+
+		age := rec["age"].(int64)
+		if age < 18 || age > 65 {
+			t.Errorf("record %d: age %d outside [18, 65]", i, age)
+		}
+		if tier := rec["tier"]; tier != "free" && tier != "pro" {
+			t.Errorf("record %d: tier %v not in enum", i, tier)
+		}
+		if _, ok := rec["active"].(bool); !ok {
+			t.Errorf("record %d: active is %T", i, rec["active"])
+		}
+		if rec["joined"].(map[string]interface{})["type"] != "DateTime" {
+			t.Errorf("record %d: joined is not a DateTime", i)
+		}
+		vec := rec["embedding"].(map[string]interface{})["value"].([]float64)
+		if len(vec) != 12 {
+			t.Errorf("record %d: embedding dimension %d, want 12", i, len(vec))
+		}
+		if rec["sku"] != fmt.Sprintf("SKU-%04d", i) {
+			t.Errorf("record %d: custom generator not used: %v", i, rec["sku"])
+		}
+	}
+
+	// The same seed reproduces the same values.
+	again := GenerateSampleRecords(50, spec)
+	for i := range records {
+		if records[i]["email"] != again[i]["email"] || !reflect.DeepEqual(records[i]["embedding"], again[i]["embedding"]) {
+			t.Fatalf("record %d differs between runs with the same seed", i)
+		}
+	}
+}
+
+func TestGenerateSampleDataInsertsInBatches(t *testing.T) {
+	var batches []int
+	next := 0
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/collections/users": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(CollectionMetadata{Collection: *sampleTestSchema()})
+		},
+		"POST /api/batch/insert/users": func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Inserts []map[string]interface{} `json:"inserts"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			batches = append(batches, len(body.Inserts))
+			ids := make([]string, len(body.Inserts))
+			for i := range ids {
+				ids[i] = fmt.Sprintf("u%d", next)
+				next++
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": ids, "failed": []interface{}{}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	records, err := client.GenerateSampleData("users", 25, SampleDataSpec{BatchSize: 10, DefaultVectorDimension: 4})
+	if err != nil {
+		t.Fatalf("GenerateSampleData failed: %v", err)
+	}
+	if !reflect.DeepEqual(batches, []int{10, 10, 5}) {
+		t.Errorf("batch sizes = %v, want [10 10 5]", batches)
+	}
+	if len(records) != 25 || records[0]["id"] != "u0" || records[24]["id"] != "u24" {
+		t.Errorf("records not annotated with inserted IDs: first=%v last=%v", records[0]["id"], records[24]["id"])
+	}
+}
@@ -7,7 +7,8 @@ import (
 )
 
 // CreateSchedule creates a new schedule.
-func (c *Client) CreateSchedule(data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) CreateSchedule(data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", "/api/schedules", data)
 	if err != nil {
 		return nil, err
@@ -20,7 +21,8 @@ func (c *Client) CreateSchedule(data map[string]interface{}) (map[string]interfa
 }
 
 // ListSchedules lists all schedules.
-func (c *Client) ListSchedules() (map[string]interface{}, error) {
+func (c *Client) ListSchedules(opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", "/api/schedules", nil)
 	if err != nil {
 		return nil, err
@@ -33,7 +35,8 @@ func (c *Client) ListSchedules() (map[string]interface{}, error) {
 }
 
 // GetSchedule retrieves a schedule by ID.
-func (c *Client) GetSchedule(id string) (map[string]interface{}, error) {
+func (c *Client) GetSchedule(id string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("GET", fmt.Sprintf("/api/schedules/%s", url.PathEscape(id)), nil)
 	if err != nil {
 		return nil, err
@@ -46,7 +49,8 @@ func (c *Client) GetSchedule(id string) (map[string]interface{}, error) {
 }
 
 // UpdateSchedule updates a schedule by ID.
-func (c *Client) UpdateSchedule(id string, data map[string]interface{}) (map[string]interface{}, error) {
+func (c *Client) UpdateSchedule(id string, data map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("PUT", fmt.Sprintf("/api/schedules/%s", url.PathEscape(id)), data)
 	if err != nil {
 		return nil, err
@@ -59,13 +63,15 @@ func (c *Client) UpdateSchedule(id string, data map[string]interface{}) (map[str
 }
 
 // DeleteSchedule deletes a schedule by ID.
-func (c *Client) DeleteSchedule(id string) error {
+func (c *Client) DeleteSchedule(id string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	_, err := c.makeRequest("DELETE", fmt.Sprintf("/api/schedules/%s", url.PathEscape(id)), nil)
 	return err
 }
 
 // PauseSchedule pauses a schedule by ID.
-func (c *Client) PauseSchedule(id string) (map[string]interface{}, error) {
+func (c *Client) PauseSchedule(id string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/schedules/%s/pause", url.PathEscape(id)), nil)
 	if err != nil {
 		return nil, err
@@ -78,7 +84,8 @@ func (c *Client) PauseSchedule(id string) (map[string]interface{}, error) {
 }
 
 // ResumeSchedule resumes a schedule by ID.
-func (c *Client) ResumeSchedule(id string) (map[string]interface{}, error) {
+func (c *Client) ResumeSchedule(id string, opts ...RequestOption) (map[string]interface{}, error) {
+	c = c.withOptions(opts)
 	respBody, err := c.makeRequest("POST", fmt.Sprintf("/api/schedules/%s/resume", url.PathEscape(id)), nil)
 	if err != nil {
 		return nil, err
@@ -40,6 +40,7 @@ type IndexConfig struct {
 	Metric         *DistanceMetric       `json:"metric,omitempty"`
 	M              *int                  `json:"m,omitempty"`
 	EfConstruction *int                  `json:"ef_construction,omitempty"`
+	Dimensions     *int                  `json:"dimensions,omitempty"` // Vector indexes: the embedding dimension
 }
 
 // FieldTypeSchema represents field type schema with constraints
@@ -62,6 +63,9 @@ type Schema struct {
 	CreatedAt    *string                    `json:"created_at,omitempty"`
 	LastModified *string                    `json:"last_modified,omitempty"`
 	BypassRipple *bool                      `json:"bypass_ripple,omitempty"`
+	// ShardKey names the field that partitions the collection on servers that
+	// shard collections. Requests can target a single shard with ShardHint.
+	ShardKey string `json:"shard_key,omitempty"`
 }
 
 // CollectionMetadata represents collection metadata with analytics
@@ -142,6 +146,16 @@ func (fb *FieldTypeSchemaBuilder) VectorIndex(algorithm VectorIndexAlgorithm, me
 	return fb
 }
 
+// Dimensions sets the embedding dimension of the field's vector index,
+// adding a vector index with the server's defaults if there is none yet
+func (fb *FieldTypeSchemaBuilder) Dimensions(n int) *FieldTypeSchemaBuilder {
+	if fb.schema.Index == nil || fb.schema.Index.Type != "vector" {
+		fb.schema.Index = &IndexConfig{Type: "vector"}
+	}
+	fb.schema.Index.Dimensions = &n
+	return fb
+}
+
 // BTreeIndex adds a B-tree index
 func (fb *FieldTypeSchemaBuilder) BTreeIndex() *FieldTypeSchemaBuilder {
 	fb.schema.Index = &IndexConfig{
@@ -199,20 +213,29 @@ func (sb *SchemaBuilder) Version(version int) *SchemaBuilder {
 	return sb
 }
 
+// ShardKey declares the field the collection is sharded on. Servers without
+// sharding ignore it.
+func (sb *SchemaBuilder) ShardKey(field string) *SchemaBuilder {
+	sb.schema.ShardKey = field
+	return sb
+}
+
 // Build builds the final Schema
 func (sb *SchemaBuilder) Build() Schema {
 	return sb.schema
 }
 
 // CreateCollection creates a collection with schema
-func (c *Client) CreateCollection(collection string, schema Schema) error {
+func (c *Client) CreateCollection(collection string, schema Schema, opts ...RequestOption) error {
+	c = c.withOptions(opts)
 	endpoint := fmt.Sprintf("/api/collections/%s", url.PathEscape(collection))
 	_, err := c.makeRequest("POST", endpoint, schema)
 	return err
 }
 
 // GetCollection gets collection metadata and schema
-func (c *Client) GetCollection(collection string) (*CollectionMetadata, error) {
+func (c *Client) GetCollection(collection string, opts ...RequestOption) (*CollectionMetadata, error) {
+	c = c.withOptions(opts)
 	endpoint := fmt.Sprintf("/api/collections/%s", url.PathEscape(collection))
 
 	data, err := c.makeRequest("GET", endpoint, nil)
@@ -229,7 +252,8 @@ func (c *Client) GetCollection(collection string) (*CollectionMetadata, error) {
 }
 
 // GetSchema gets collection schema
-func (c *Client) GetSchema(collection string) (*Schema, error) {
+func (c *Client) GetSchema(collection string, opts ...RequestOption) (*Schema, error) {
+	c = c.withOptions(opts)
 	metadata, err := c.GetCollection(collection)
 	if err != nil {
 		return nil, err
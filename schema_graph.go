@@ -0,0 +1,242 @@
+package ekodb
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// SchemaGraphFormat selects the output of ExportSchemaGraph.
+type SchemaGraphFormat string
+
+const (
+	// SchemaGraphDOT emits a Graphviz digraph (render with `dot -Tsvg`).
+	SchemaGraphDOT SchemaGraphFormat = "dot"
+	// SchemaGraphMermaid emits a Mermaid erDiagram, which GitHub and most
+	// wikis render inline.
+	SchemaGraphMermaid SchemaGraphFormat = "mermaid"
+)
+
+// SchemaRelation is an edge of the schema graph: a field of one collection
+// that refers to records of another.
+type SchemaRelation struct {
+	From  string // Collection holding the reference
+	Field string
+	To    string // Referenced collection
+}
+
+// ExportSchemaGraph writes a diagram of every collection on the server —
+// fields with their types, constraints, and indexes, and the relationships
+// between collections — in the given format. It is generated from the live
+// schemas, so it doubles as documentation for an existing database:
+//
+//	f, _ := os.Create("schema.mmd")
+//	defer f.Close()
+//	err := client.ExportSchemaGraph(f, SchemaGraphMermaid)
+//
+// Schemas are fetched concurrently (see ClientConfig.MaxConcurrency).
+// Relationships are inferred from field names (see SchemaRelations).
+func (c *Client) ExportSchemaGraph(w io.Writer, format SchemaGraphFormat, opts ...RequestOption) error {
+	c = c.withOptions(opts)
+	collections, err := c.ListCollections()
+	if err != nil {
+		return err
+	}
+	found := make([]*Schema, len(collections))
+	err = c.fanOut(len(collections), func(ctx context.Context, i int) error {
+		schema, err := c.WithContext(ctx).GetSchema(collections[i])
+		if err != nil {
+			return fmt.Errorf("failed to load schema for %s: %w", collections[i], err)
+		}
+		found[i] = schema
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	schemas := make(map[string]*Schema, len(collections))
+	for i, name := range collections {
+		schemas[name] = found[i]
+	}
+	return WriteSchemaGraph(w, schemas, format)
+}
+
+// WriteSchemaGraph writes the diagram ExportSchemaGraph produces for an
+// explicit set of schemas, keyed by collection name.
+func WriteSchemaGraph(w io.Writer, schemas map[string]*Schema, format SchemaGraphFormat) error {
+	bw := bufio.NewWriter(w)
+	switch format {
+	case SchemaGraphDOT:
+		writeSchemaDOT(bw, schemas)
+	case SchemaGraphMermaid:
+		writeSchemaMermaid(bw, schemas)
+	default:
+		return fmt.Errorf("unknown schema graph format %q", format)
+	}
+	return bw.Flush()
+}
+
+// SchemaRelations infers the relationships between collections, sorted by
+// collection and field. Schemas do not declare references, so a field is
+// taken to refer to another collection when it is named after it with an
+// "_id" or "Id" suffix: "user_id" and "userId" refer to whichever of "user",
+// "users", or "useres" exists.
+func SchemaRelations(schemas map[string]*Schema) []SchemaRelation {
+	var relations []SchemaRelation
+	for _, name := range sortedKeys(schemas) {
+		schema := schemas[name]
+		if schema == nil {
+			continue
+		}
+		for _, field := range sortedKeys(schema.Fields) {
+			if target, ok := referencedCollection(field, schemas); ok {
+				relations = append(relations, SchemaRelation{From: name, Field: field, To: target})
+			}
+		}
+	}
+	return relations
+}
+
+// referencedCollection resolves the collection a field name points at.
+func referencedCollection(field string, schemas map[string]*Schema) (string, bool) {
+	var base string
+	switch {
+	case strings.HasSuffix(field, "_id") && len(field) > 3:
+		base = strings.TrimSuffix(field, "_id")
+	case strings.HasSuffix(field, "Id") && len(field) > 2:
+		base = strings.TrimSuffix(field, "Id")
+	default:
+		return "", false
+	}
+	for _, candidate := range []string{base, base + "s", base + "es"} {
+		if _, ok := schemas[candidate]; ok {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// describeField summarizes a field's constraints and index, e.g.
+// "required, unique, index: text".
+func describeField(f FieldTypeSchema) string {
+	var parts []string
+	if f.Required {
+		parts = append(parts, "required")
+	}
+	if f.Unique {
+		parts = append(parts, "unique")
+	}
+	if len(f.Enums) > 0 {
+		parts = append(parts, fmt.Sprintf("enum(%d)", len(f.Enums)))
+	}
+	if f.Index != nil {
+		parts = append(parts, "index: "+f.Index.Type)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func writeSchemaDOT(w *bufio.Writer, schemas map[string]*Schema) {
+	w.WriteString("digraph ekodb {\n")
+	w.WriteString("  rankdir=LR;\n")
+	w.WriteString("  node [shape=record, fontname=\"Helvetica\"];\n")
+	for _, name := range sortedKeys(schemas) {
+		var rows []string
+		if schema := schemas[name]; schema != nil {
+			for _, field := range sortedKeys(schema.Fields) {
+				f := schema.Fields[field]
+				row := field + ": " + f.FieldType
+				if desc := describeField(f); desc != "" {
+					row += " (" + desc + ")"
+				}
+				rows = append(rows, escapeDOTRecord(row)+"\\l")
+			}
+		}
+		fmt.Fprintf(w, "  %q [label=\"{%s|%s}\"];\n", name, escapeDOTRecord(name), strings.Join(rows, ""))
+	}
+	for _, rel := range SchemaRelations(schemas) {
+		fmt.Fprintf(w, "  %q -> %q [label=%q];\n", rel.From, rel.To, rel.Field)
+	}
+	w.WriteString("}\n")
+}
+
+// escapeDOTRecord escapes the characters that are special inside a quoted
+// record label.
+func escapeDOTRecord(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '{', '}', '|', '<', '>', '"', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func writeSchemaMermaid(w *bufio.Writer, schemas map[string]*Schema) {
+	relations := SchemaRelations(schemas)
+	foreignKeys := make(map[string]bool, len(relations))
+	for _, rel := range relations {
+		foreignKeys[rel.From+"."+rel.Field] = true
+	}
+
+	w.WriteString("erDiagram\n")
+	for _, name := range sortedKeys(schemas) {
+		schema := schemas[name]
+		if schema == nil || len(schema.Fields) == 0 {
+			fmt.Fprintf(w, "    %s\n", mermaidIdent(name))
+			continue
+		}
+		fmt.Fprintf(w, "    %s {\n", mermaidIdent(name))
+		for _, field := range sortedKeys(schema.Fields) {
+			f := schema.Fields[field]
+			line := "        " + mermaidIdent(f.FieldType) + " " + mermaidIdent(field)
+			var keys []string
+			if foreignKeys[name+"."+field] {
+				keys = append(keys, "FK")
+			}
+			if f.Unique {
+				keys = append(keys, "UK")
+			}
+			if len(keys) > 0 {
+				line += " " + strings.Join(keys, ",")
+			}
+			if desc := describeField(f); desc != "" {
+				line += " \"" + strings.ReplaceAll(desc, "\"", "'") + "\""
+			}
+			w.WriteString(line + "\n")
+		}
+		w.WriteString("    }\n")
+	}
+	for _, rel := range relations {
+		fmt.Fprintf(w, "    %s }o--|| %s : %q\n", mermaidIdent(rel.From), mermaidIdent(rel.To), rel.Field)
+	}
+}
+
+// mermaidIdent replaces characters Mermaid does not accept in entity and
+// attribute names.
+func mermaidIdent(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// sortedKeys returns m's keys in order, for deterministic output.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,103 @@
+package ekodb
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func schemaGraphTestSchemas() map[string]*Schema {
+	return map[string]*Schema{
+		"users": {Fields: map[string]FieldTypeSchema{
+			"email": {FieldType: "String", Unique: true, Required: true},
+			"bio":   {FieldType: "String", Index: &IndexConfig{Type: "text"}},
+		}},
+		"orders": {Fields: map[string]FieldTypeSchema{
+			"user_id": {FieldType: "String", Required: true},
+			"total":   {FieldType: "Decimal"},
+		}},
+		"audit": {Fields: map[string]FieldTypeSchema{
+			"orderId": {FieldType: "String"},
+			"ghostId": {FieldType: "String"},
+		}},
+	}
+}
+
+func TestSchemaRelations(t *testing.T) {
+	got := SchemaRelations(schemaGraphTestSchemas())
+	want := []SchemaRelation{
+		{From: "audit", Field: "orderId", To: "orders"},
+		{From: "orders", Field: "user_id", To: "users"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("relations = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("relation %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWriteSchemaGraphFormats(t *testing.T) {
+	var dot bytes.Buffer
+	if err := WriteSchemaGraph(&dot, schemaGraphTestSchemas(), SchemaGraphDOT); err != nil {
+		t.Fatalf("WriteSchemaGraph dot failed: %v", err)
+	}
+	for _, want := range []string{
+		"digraph ekodb {",
+		`"users" [label="{users|bio: String (index: text)\lemail: String (required, unique)\l}"];`,
+		`"orders" -> "users" [label="user_id"];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT output missing %q:\n%s", want, dot.String())
+		}
+	}
+
+	var mermaid bytes.Buffer
+	if err := WriteSchemaGraph(&mermaid, schemaGraphTestSchemas(), SchemaGraphMermaid); err != nil {
+		t.Fatalf("WriteSchemaGraph mermaid failed: %v", err)
+	}
+	for _, want := range []string{
+		"erDiagram\n",
+		`        String email UK "required, unique"`,
+		`        String user_id FK "required"`,
+		`    orders }o--|| users : "user_id"`,
+	} {
+		if !strings.Contains(mermaid.String(), want) {
+			t.Errorf("Mermaid output missing %q:\n%s", want, mermaid.String())
+		}
+	}
+
+	if err := WriteSchemaGraph(&bytes.Buffer{}, nil, "svg"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestExportSchemaGraph(t *testing.T) {
+	schemas := schemaGraphTestSchemas()
+	collectionHandler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(CollectionMetadata{Collection: *schemas[name]})
+		}
+	}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/collections": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string][]string{"collections": {"users", "orders"}})
+		},
+		"GET /api/collections/users":  collectionHandler("users"),
+		"GET /api/collections/orders": collectionHandler("orders"),
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	var out bytes.Buffer
+	if err := client.ExportSchemaGraph(&out, SchemaGraphMermaid); err != nil {
+		t.Fatalf("ExportSchemaGraph failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `orders }o--|| users : "user_id"`) {
+		t.Errorf("expected the orders -> users relation:\n%s", out.String())
+	}
+}
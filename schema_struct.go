@@ -0,0 +1,212 @@
+package ekodb
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// codecSchemaOpts are the schema constraints a field's tag declares.
+type codecSchemaOpts struct {
+	required bool
+	unique   bool
+	index    string // "btree", "hash", "text", or "vector"; "" for none
+	dims     int    // Vector index dimension; 0 if unset
+}
+
+// parse applies a tag option, reporting whether it is a schema option.
+func (o *codecSchemaOpts) parse(opt string) (bool, error) {
+	key, value, hasValue := strings.Cut(opt, "=")
+	switch key {
+	case "required":
+		o.required = true
+	case "unique":
+		o.unique = true
+	case "index":
+		o.index = "btree"
+		if hasValue {
+			switch value {
+			case "btree", "hash", "text", "vector":
+				o.index = value
+			default:
+				return true, fmt.Errorf("unknown index type %q (must be one of: btree, hash, text, vector)", value)
+			}
+		}
+	case "dims":
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return true, fmt.Errorf("invalid dims %q", value)
+		}
+		o.dims = n
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// SchemaOptions contains optional parameters for SchemaFromStruct
+type SchemaOptions struct {
+	BypassRipple *bool
+	ShardKey     string
+	// TextIndexLanguage is the language of text indexes declared with
+	// index=text (default: "english").
+	TextIndexLanguage string
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
+}
+
+// SchemaFromStruct derives a collection Schema from a struct type, so
+// CreateCollection can be driven from the types used with MarshalRecord and
+// UnmarshalRecord. Fields are named as the struct codec names them; each
+// field's type comes from its tag's type option or, failing that, its Go
+// type (string, integers, floats, bool, time.Time, time.Duration, Decimal,
+// Vector, []byte, slices, maps, and nested structs), or the type a
+// registered field codec wraps it in. The id field and interface-typed
+// fields are left out.
+//
+// Constraints come from the ekodb tag: required, unique, index (a B-tree
+// index), index=hash|text|vector, and dims=N for a vector index's dimension:
+//
+//	type Doc struct {
+//	    Slug      string    `ekodb:"slug,required,unique,index=hash"`
+//	    Body      string    `ekodb:"body,index=text"`
+//	    Embedding Vector    `ekodb:"embedding,dims=1536"`
+//	    CreatedAt time.Time `ekodb:"created_at,index"`
+//	}
+//	schema, err := ekodb.SchemaFromStruct(Doc{})
+//	err = client.CreateCollection("docs", schema)
+func SchemaFromStruct(v interface{}, opts ...SchemaOptions) (Schema, error) {
+	var opt SchemaOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.TextIndexLanguage == "" {
+		opt.TextIndexLanguage = "english"
+	}
+
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return Schema{}, fmt.Errorf("SchemaFromStruct: expected a struct, got %T", v)
+	}
+	fields, err := codecFields(t)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	sb := NewSchemaBuilder()
+	if opt.BypassRipple != nil {
+		sb.BypassRipple(*opt.BypassRipple)
+	}
+	if opt.ShardKey != "" {
+		sb.ShardKey(opt.ShardKey)
+	}
+	for _, f := range fields {
+		if f.name == "id" {
+			continue
+		}
+		fieldType := f.wrap
+		if fieldType == "" {
+			fieldType = schemaFieldType(t.FieldByIndex(f.index).Type)
+		}
+		if fieldType == "" {
+			continue
+		}
+		fb := NewFieldTypeSchemaBuilder(fieldType)
+		if f.schema.required {
+			fb.Required()
+		}
+		if f.schema.unique {
+			fb.Unique()
+		}
+		index := f.schema.index
+		if index == "" && f.schema.dims > 0 {
+			index = "vector"
+		}
+		switch index {
+		case "btree":
+			fb.BTreeIndex()
+		case "hash":
+			fb.HashIndex()
+		case "text":
+			fb.TextIndex(opt.TextIndexLanguage)
+		case "vector":
+			if f.schema.dims > 0 {
+				fb.Dimensions(f.schema.dims)
+			} else {
+				fb.schema.Index = &IndexConfig{Type: "vector"}
+			}
+		}
+		sb.AddField(f.name, fb.Build())
+	}
+	return sb.Build(), nil
+}
+
+// CreateCollectionFromStruct creates a collection with the schema
+// SchemaFromStruct derives from v.
+func (c *Client) CreateCollectionFromStruct(collection string, v interface{}, opts ...SchemaOptions) error {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	schema, err := SchemaFromStruct(v, opts...)
+	if err != nil {
+		return err
+	}
+	return c.CreateCollection(collection, schema)
+}
+
+// schemaFieldType maps a Go type to the ekoDB field type its values are
+// stored as, or "" if it has no fixed type.
+func schemaFieldType(t reflect.Type) string {
+	if codec, ok := lookupFieldCodec(t); ok {
+		if m, ok := asObject(codecZeroValue(codec, t)); ok {
+			if typ, ok := m["type"].(string); ok {
+				return typ
+			}
+		}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return "DateTime"
+	case durationType:
+		return "Duration"
+	case decimalType:
+		return "Decimal"
+	case reflect.TypeOf(Vector(nil)):
+		return "Vector"
+	case bytesType:
+		return "Binary"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "String"
+	case reflect.Bool:
+		return "Boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "Integer"
+	case reflect.Float32, reflect.Float64:
+		return "Float"
+	case reflect.Slice, reflect.Array:
+		return "Array"
+	case reflect.Map, reflect.Struct:
+		return "Object"
+	}
+	return ""
+}
+
+// codecZeroValue encodes t's zero value with codec, or returns nil if the
+// encoder rejects it.
+func codecZeroValue(codec fieldCodec, t reflect.Type) interface{} {
+	v, err := codec.encode(reflect.Zero(t))
+	if err != nil {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,97 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type schemaDoc struct {
+	ID        string            `ekodb:"id,omitempty"`
+	Slug      string            `ekodb:"slug,required,unique,index=hash"`
+	Body      string            `ekodb:"body,index=text"`
+	Views     int               `json:"views"`
+	Score     *float64          `ekodb:"score"`
+	Price     Decimal           `ekodb:"price"`
+	Total     string            `ekodb:"total,decimal,required"`
+	Embedding Vector            `ekodb:"embedding,dims=3"`
+	CreatedAt time.Time         `ekodb:"created_at,index"`
+	Tags      []string          `ekodb:"tags,set"`
+	Meta      map[string]string `ekodb:"meta"`
+	Extra     interface{}       `ekodb:"extra"`
+	Skip      string            `ekodb:"-"`
+}
+
+func TestSchemaFromStruct(t *testing.T) {
+	schema, err := SchemaFromStruct(&schemaDoc{}, SchemaOptions{ShardKey: "slug"})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct failed: %v", err)
+	}
+	types := map[string]string{
+		"slug": "String", "body": "String", "views": "Integer", "score": "Float",
+		"price": "Decimal", "total": "Decimal", "embedding": "Vector",
+		"created_at": "DateTime", "tags": "Set", "meta": "Object",
+	}
+	if len(schema.Fields) != len(types) {
+		t.Errorf("fields = %v", schema.Fields)
+	}
+	for name, want := range types {
+		if got := schema.Fields[name].FieldType; got != want {
+			t.Errorf("%s: type %q, want %q", name, got, want)
+		}
+	}
+	slug := schema.Fields["slug"]
+	if !slug.Required || !slug.Unique || slug.Index == nil || slug.Index.Type != "hash" {
+		t.Errorf("slug = %+v", slug)
+	}
+	if body := schema.Fields["body"]; body.Index == nil || body.Index.Type != "text" || *body.Index.Language != "english" {
+		t.Errorf("body = %+v", body)
+	}
+	if emb := schema.Fields["embedding"]; emb.Index == nil || emb.Index.Type != "vector" || *emb.Index.Dimensions != 3 {
+		t.Errorf("embedding = %+v", emb)
+	}
+	if at := schema.Fields["created_at"]; at.Index == nil || at.Index.Type != "btree" {
+		t.Errorf("created_at = %+v", at)
+	}
+	if !schema.Fields["total"].Required || schema.ShardKey != "slug" {
+		t.Errorf("total = %+v, shard key = %q", schema.Fields["total"], schema.ShardKey)
+	}
+
+	// Schema options do not affect the codec.
+	if _, err := MarshalRecord(schemaDoc{Slug: "a"}); err != nil {
+		t.Errorf("MarshalRecord failed: %v", err)
+	}
+}
+
+func TestSchemaFromStructErrors(t *testing.T) {
+	if _, err := SchemaFromStruct("nope"); err == nil {
+		t.Error("expected an error for a non-struct")
+	}
+	type badIndex struct {
+		Name string `ekodb:"name,index=fulltext"`
+	}
+	if _, err := SchemaFromStruct(badIndex{}); err == nil || !strings.Contains(err.Error(), "unknown index type") {
+		t.Errorf("expected an index type error, got %v", err)
+	}
+}
+
+func TestCreateCollectionFromStruct(t *testing.T) {
+	var got Schema
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/collections/docs": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusOK)
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	if err := client.CreateCollectionFromStruct("docs", schemaDoc{}); err != nil {
+		t.Fatalf("CreateCollectionFromStruct failed: %v", err)
+	}
+	if got.Fields["slug"].FieldType != "String" || !got.Fields["slug"].Unique {
+		t.Errorf("sent schema = %+v", got)
+	}
+}
@@ -2,6 +2,7 @@
 package ekodb
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -38,6 +39,13 @@ type SearchQuery struct {
 	BypassCache  *bool `json:"bypass_cache,omitempty"`
 	Limit        *int  `json:"limit,omitempty"`
 
+	// Pagination. Offset skips that many ranked results. SnapshotToken, copied
+	// from a previous SearchResponse, pins the search to the index state that
+	// response was computed from, so pages stay consistent while concurrent
+	// writes update the index. See NextPage.
+	Offset        *int   `json:"offset,omitempty"`
+	SnapshotToken string `json:"snapshot_token,omitempty"`
+
 	// Field projection
 	SelectFields  []string `json:"select_fields,omitempty"`
 	ExcludeFields []string `json:"exclude_fields,omitempty"`
@@ -62,6 +70,44 @@ type SearchResponse struct {
 	Results []SearchResult `json:"results"`
 	Total   int            `json:"total"`
 	TookMs  *int           `json:"took_ms,omitempty"`
+	// SnapshotToken identifies the index snapshot these results came from.
+	// It is empty when the server does not support snapshot pagination.
+	SnapshotToken string `json:"snapshot_token,omitempty"`
+}
+
+// NextPage returns the query for the page after resp: the offset advanced past
+// resp's results and the snapshot pinned to resp's, so records written
+// between calls neither shift nor duplicate results. It reports false once
+// resp was the last page.
+//
+// Example:
+//
+//	q := ekodb.NewSearchQueryBuilder("golang").Limit(50).Build()
+//	for {
+//	    resp, err := client.Search("articles", q)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(resp.Results)
+//	    var more bool
+//	    if q, more = q.NextPage(resp); !more {
+//	        break
+//	    }
+//	}
+func (q SearchQuery) NextPage(resp *SearchResponse) (SearchQuery, bool) {
+	offset := 0
+	if q.Offset != nil {
+		offset = *q.Offset
+	}
+	offset += len(resp.Results)
+	if len(resp.Results) == 0 || offset >= resp.Total {
+		return q, false
+	}
+	q.Offset = &offset
+	if resp.SnapshotToken != "" {
+		q.SnapshotToken = resp.SnapshotToken
+	}
+	return q, true
 }
 
 // SearchQueryBuilder provides a fluent API for building search queries
@@ -192,6 +238,18 @@ func (sb *SearchQueryBuilder) Limit(limit int) *SearchQueryBuilder {
 	return sb
 }
 
+// Offset skips the first offset ranked results
+func (sb *SearchQueryBuilder) Offset(offset int) *SearchQueryBuilder {
+	sb.query.Offset = &offset
+	return sb
+}
+
+// Snapshot pins the search to the snapshot a previous response reported
+func (sb *SearchQueryBuilder) Snapshot(token string) *SearchQueryBuilder {
+	sb.query.SnapshotToken = token
+	return sb
+}
+
 // SelectFields selects specific fields to return
 func (sb *SearchQueryBuilder) SelectFields(fields []string) *SearchQueryBuilder {
 	sb.query.SelectFields = fields
@@ -220,7 +278,11 @@ func (sb *SearchQueryBuilder) Build() SearchQuery {
 }
 
 // Search performs a search query on a collection
-func (c *Client) Search(collection string, searchQuery SearchQuery) (*SearchResponse, error) {
+func (c *Client) Search(collection string, searchQuery SearchQuery, opts ...RequestOption) (*SearchResponse, error) {
+	c = c.withOptions(opts)
+	if err := c.checkSearchVectorDims(collection, searchQuery); err != nil {
+		return nil, err
+	}
 	endpoint := fmt.Sprintf("/api/search/%s", url.PathEscape(collection))
 
 	data, err := c.makeRequest("POST", endpoint, searchQuery)
@@ -229,13 +291,44 @@ func (c *Client) Search(collection string, searchQuery SearchQuery) (*SearchResp
 	}
 
 	var response SearchResponse
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := c.unmarshalJSON(data, &response); err != nil {
 		return nil, err
 	}
+	if c.rewritesRecords() {
+		for _, result := range response.Results {
+			c.finishRecord(result.Record)
+		}
+	}
 
 	return &response, nil
 }
 
+// SearchCollections runs searchQuery against each collection concurrently,
+// with at most ClientConfig.MaxConcurrency searches in flight, and returns the
+// responses keyed by collection. The first failure cancels the searches still
+// running and is returned.
+func (c *Client) SearchCollections(collections []string, searchQuery SearchQuery, opts ...RequestOption) (map[string]*SearchResponse, error) {
+	c = c.withOptions(opts)
+	responses := make([]*SearchResponse, len(collections))
+	err := c.fanOut(len(collections), func(ctx context.Context, i int) error {
+		resp, err := c.WithContext(ctx).Search(collections[i], searchQuery)
+		if err != nil {
+			return fmt.Errorf("search %s: %w", collections[i], err)
+		}
+		responses[i] = resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]*SearchResponse, len(collections))
+	for i, collection := range collections {
+		results[collection] = responses[i]
+	}
+	return results, nil
+}
+
 // ============================================================================
 // Distinct Values
 // ============================================================================
@@ -282,7 +375,8 @@ type DistinctValuesResponse struct {
 //	        },
 //	    },
 //	})
-func (c *Client) DistinctValues(collection, field string, query DistinctValuesQuery) (*DistinctValuesResponse, error) {
+func (c *Client) DistinctValues(collection, field string, query DistinctValuesQuery, opts ...RequestOption) (*DistinctValuesResponse, error) {
+	c = c.withOptions(opts)
 	endpoint := fmt.Sprintf("/api/distinct/%s/%s", url.PathEscape(collection), url.PathEscape(field))
 
 	data, err := c.makeRequest("POST", endpoint, query)
@@ -0,0 +1,191 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StorageStats summarizes a server's storage usage.
+type StorageStats struct {
+	Collections int   `json:"collections"`
+	Records     int64 `json:"records"`
+	DiskBytes   int64 `json:"disk_bytes"`
+	MemoryBytes int64 `json:"memory_bytes"`
+}
+
+// HealthStatus is the detailed health report returned by HealthDetailed.
+// Fields other than Status are zero when the server does not report them
+// (older servers return only a status).
+type HealthStatus struct {
+	Status     string            `json:"status"`
+	Version    string            `json:"version,omitempty"`
+	UptimeSecs int64             `json:"uptime_secs,omitempty"`
+	Checks     map[string]string `json:"checks,omitempty"` // Per-subsystem status, e.g. "storage": "ok"
+}
+
+// Healthy reports whether the server's overall status is "ok".
+func (h *HealthStatus) Healthy() bool {
+	return h.Status == "ok"
+}
+
+// ServerInfo describes the server a client is connected to.
+type ServerInfo struct {
+	Version    string        `json:"version"`
+	UptimeSecs int64         `json:"uptime_secs,omitempty"`
+	Storage    *StorageStats `json:"storage,omitempty"`
+	// Features lists optional capabilities the server supports, e.g.
+	// "ws_batch" or "vector_search".
+	Features []string `json:"features,omitempty"`
+}
+
+// HasFeature reports whether the server advertises the named feature.
+func (s *ServerInfo) HasFeature(name string) bool {
+	for _, f := range s.Features {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AtLeastVersion reports whether the server version is at least min, comparing
+// dotted numeric components ("0.41" < "0.41.2" < "1.0"). A missing or
+// unparsable server version reports false.
+func (s *ServerInfo) AtLeastVersion(min string) bool {
+	have, ok := parseVersion(s.Version)
+	if !ok {
+		return false
+	}
+	want, ok := parseVersion(min)
+	if !ok {
+		return false
+	}
+	for i := 0; i < len(have) || i < len(want); i++ {
+		var h, w int
+		if i < len(have) {
+			h = have[i]
+		}
+		if i < len(want) {
+			w = want[i]
+		}
+		if h != w {
+			return h > w
+		}
+	}
+	return true
+}
+
+// parseVersion splits "v1.2.3-beta" into [1 2 3], ignoring a leading "v" and
+// any pre-release or build suffix.
+func parseVersion(v string) ([]int, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+ "); i >= 0 {
+		v = v[:i]
+	}
+	if v == "" {
+		return nil, false
+	}
+	parts := strings.Split(v, ".")
+	out := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
+
+// HealthDetailed returns the server's health report, including its version,
+// uptime, and per-subsystem checks when the server provides them. Unlike
+// Health, an unhealthy status is reported in the result rather than as an
+// error.
+func (c *Client) HealthDetailed(opts ...RequestOption) (*HealthStatus, error) {
+	c = c.withOptions(opts)
+	respBody, err := c.makeRequest("GET", "/api/health", nil)
+	if err != nil {
+		return nil, err
+	}
+	var status HealthStatus
+	if err := json.Unmarshal(respBody, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Ping measures the round-trip time of a single request to the server's
+// health endpoint rather than a data endpoint, so it stays off collection
+// rate limits on servers that exempt health checks. It is meant for
+// connection warm-up and latency dashboards: the request runs under ctx, is
+// never retried, and only a successful round trip is reported.
+func (c *Client) Ping(ctx context.Context, opts ...RequestOption) (time.Duration, error) {
+	c = c.withOptions(opts)
+	pinger := c.WithContext(ctx).With(WithNoRetry())
+	start := time.Now()
+	if _, err := pinger.makeRequest("GET", "/api/health", nil); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// ServerInfo returns the server's version, uptime, storage statistics, and
+// feature flags, so applications can gate newer APIs at runtime:
+//
+//	info, err := client.ServerInfo()
+//	if err == nil && info.HasFeature("ws_batch") {
+//	    // use WebSocket batch operations
+//	}
+//
+// Servers without the info endpoint are handled by falling back to the health
+// report, which yields the version and uptime when available and no features.
+func (c *Client) ServerInfo(opts ...RequestOption) (*ServerInfo, error) {
+	c = c.withOptions(opts)
+	respBody, err := c.makeRequest("GET", "/api/info", nil)
+	if err != nil {
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) || !httpErr.IsNotFound() {
+			return nil, err
+		}
+		health, err := c.HealthDetailed()
+		if err != nil {
+			return nil, err
+		}
+		return &ServerInfo{Version: health.Version, UptimeSecs: health.UptimeSecs}, nil
+	}
+	var info ServerInfo
+	if err := json.Unmarshal(respBody, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// serverFeature reports whether the server advertises the named feature. The
+// features are fetched once per root client, through c so the caller's
+// context applies, and without holding a lock, so a slow fetch stalls only
+// its own caller; concurrent first calls may each fetch. If the fetch fails
+// none are assumed for this call, and the next call tries again.
+func (c *Client) serverFeature(name string) bool {
+	root := c.root()
+	root.featuresMu.Lock()
+	features := root.features
+	root.featuresMu.Unlock()
+	if features == nil {
+		info, err := c.ServerInfo()
+		if err != nil {
+			c.logf("Could not query server features, assuming none: %v", err)
+			return false
+		}
+		root.featuresMu.Lock()
+		if root.features == nil {
+			root.features = info
+		}
+		features = root.features
+		root.featuresMu.Unlock()
+	}
+	return features.HasFeature(name)
+}
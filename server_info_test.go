@@ -0,0 +1,195 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthDetailed(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"status":      "degraded",
+				"version":     "0.41.0",
+				"uptime_secs": 3600,
+				"checks":      map[string]string{"storage": "ok", "replication": "lagging"},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	health, err := client.HealthDetailed()
+	if err != nil {
+		t.Fatalf("HealthDetailed failed: %v", err)
+	}
+	if health.Healthy() {
+		t.Error("degraded status should not be healthy")
+	}
+	if health.Version != "0.41.0" || health.UptimeSecs != 3600 || health.Checks["replication"] != "lagging" {
+		t.Errorf("unexpected health: %+v", health)
+	}
+}
+
+func TestServerInfo(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"version":     "v0.42.1",
+				"uptime_secs": 12,
+				"storage":     map[string]interface{}{"collections": 3, "records": 1200, "disk_bytes": 4096},
+				"features":    []string{"ws_batch", "vector_search"},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	info, err := client.ServerInfo()
+	if err != nil {
+		t.Fatalf("ServerInfo failed: %v", err)
+	}
+	if !info.HasFeature("ws_batch") || info.HasFeature("geo") {
+		t.Errorf("unexpected features: %v", info.Features)
+	}
+	if info.Storage == nil || info.Storage.Records != 1200 || info.Storage.Collections != 3 {
+		t.Errorf("unexpected storage: %+v", info.Storage)
+	}
+	for min, want := range map[string]bool{"0.42": true, "0.42.1": true, "0.42.2": false, "1.0.0": false, "0.9": true} {
+		if got := info.AtLeastVersion(min); got != want {
+			t.Errorf("AtLeastVersion(%q) = %v, want %v", min, got, want)
+		}
+	}
+}
+
+func TestServerInfoFallsBackToHealth(t *testing.T) {
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		},
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "version": "0.30.0"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	info, err := client.ServerInfo()
+	if err != nil {
+		t.Fatalf("ServerInfo failed: %v", err)
+	}
+	if info.Version != "0.30.0" || len(info.Features) != 0 {
+		t.Errorf("unexpected fallback info: %+v", info)
+	}
+	if (&ServerInfo{}).AtLeastVersion("0.1") {
+		t.Error("unknown version should not satisfy AtLeastVersion")
+	}
+}
+
+func TestPing(t *testing.T) {
+	var calls int32
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/health": func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) > 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	rtt, err := client.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if rtt < 20*time.Millisecond || rtt > 2*time.Second {
+		t.Errorf("unexpected RTT %v", rtt)
+	}
+
+	// Failures are returned without retrying, even when the client retries.
+	if _, err := client.With(WithMaxRetries(3)).Ping(context.Background()); err == nil {
+		t.Error("expected Ping to fail on 503")
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Ping should not retry, got %d calls", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Ping(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestServerFeatureRetriesAfterFailedFetch(t *testing.T) {
+	var calls int32
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "unavailable"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"bulk"}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if client.WithContext(ctx).serverFeature("bulk") {
+		t.Error("feature reported although the caller's context was done")
+	}
+	if client.serverFeature("bulk") {
+		t.Error("feature reported although the fetch failed")
+	}
+	if !client.serverFeature("bulk") || !client.serverFeature("bulk") {
+		t.Error("feature not reported after a successful fetch")
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("fetched %d times, want 2", got)
+	}
+}
+
+func TestServerFeatureSlowFetchDoesNotBlockOthers(t *testing.T) {
+	release := make(chan struct{})
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": []string{"bulk"}})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	slow := make(chan bool)
+	go func() { slow <- client.serverFeature("bulk") }()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if client.WithContext(ctx).serverFeature("bulk") {
+		t.Error("feature reported although the fetch timed out")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("caller with its own deadline waited %v on another caller's fetch", elapsed)
+	}
+	close(release)
+	if !<-slow {
+		t.Error("slow fetch did not report the feature")
+	}
+}
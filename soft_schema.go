@@ -0,0 +1,237 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SoftSchemaConfig configures soft schema mode (see Client.EnableSoftSchema).
+type SoftSchemaConfig struct {
+	// Collections lists the collections soft mode applies to. "*" matches
+	// every collection; anything not listed keeps strict behavior.
+	Collections []string
+}
+
+// softSchemaState tracks which fields have been registered per collection so
+// each new field costs a single schema update. mu guards only the maps; the
+// schema requests run without it.
+type softSchemaState struct {
+	mu          sync.Mutex
+	all         bool
+	collections map[string]bool
+	// known maps collection -> field name -> a channel closed once the field
+	// is registered; a writer needing a field still being registered by
+	// another waits on it.
+	known map[string]map[string]chan struct{}
+}
+
+// registeredField is the channel of fields already in the schema.
+var registeredField = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// EnableSoftSchema turns on soft schema mode for the allow-listed
+// collections: before Insert, Update, Upsert, BatchInsert, or BatchUpdate
+// writes a field the collection's schema doesn't define yet, the client infers
+// the field's type from the value, registers it (creating the collection if
+// needed), and logs the inferred type. This lets prototypes write
+// heterogeneous records without predefining every field, while collections
+// outside the allow-list stay strict.
+//
+// Registration failures are logged and the write proceeds unchanged.
+func (c *Client) EnableSoftSchema(config SoftSchemaConfig) {
+	state := &softSchemaState{
+		collections: make(map[string]bool),
+		known:       make(map[string]map[string]chan struct{}),
+	}
+	for _, name := range config.Collections {
+		if name == "*" {
+			state.all = true
+		}
+		state.collections[name] = true
+	}
+	c.softSchema = state
+}
+
+// UpdateCollectionSchema replaces a collection's schema.
+func (c *Client) UpdateCollectionSchema(collection string, schema Schema, opts ...RequestOption) error {
+	c = c.withOptions(opts)
+	_, err := c.makeRequest("PUT", "/api/collections/"+url.PathEscape(collection), schema)
+	return err
+}
+
+// softSchemaObserve registers any fields in records that collection does not
+// define yet. It is a no-op unless soft mode covers collection.
+func (c *Client) softSchemaObserve(collection string, records ...Record) {
+	state := c.softSchema
+	if state == nil {
+		return
+	}
+	state.mu.Lock()
+	covered := state.all || state.collections[collection]
+	_, loaded := state.known[collection]
+	state.mu.Unlock()
+	if !covered {
+		return
+	}
+
+	exists := true
+	var schema *Schema
+	if !loaded {
+		current, err := c.GetSchema(collection)
+		if err != nil {
+			if httpErr, ok := err.(*HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+				c.logf("Soft schema: failed to load schema for %s: %v", collection, err)
+				return
+			}
+			exists = false
+			empty := NewSchemaBuilder().Build()
+			current = &empty
+		}
+		schema = current
+		state.mu.Lock()
+		if _, ok := state.known[collection]; !ok {
+			known := make(map[string]chan struct{}, len(schema.Fields))
+			for name := range schema.Fields {
+				known[name] = registeredField
+			}
+			state.known[collection] = known
+		}
+		state.mu.Unlock()
+	}
+
+	// Claim the fields nobody has registered yet, and note those another
+	// writer is registering.
+	added := map[string]FieldTypeSchema{}
+	var waits []chan struct{}
+	done := make(chan struct{})
+	state.mu.Lock()
+	known := state.known[collection]
+	for _, record := range records {
+		for name, value := range record {
+			if name == "id" || name == "ttl" {
+				continue
+			}
+			if ch, ok := known[name]; ok {
+				if ch != registeredField && ch != done {
+					waits = append(waits, ch)
+				}
+				continue
+			}
+			typ := inferFieldType(value)
+			if typ == "" {
+				continue
+			}
+			added[name] = FieldTypeSchema{FieldType: typ}
+			known[name] = done
+		}
+	}
+	state.mu.Unlock()
+	defer func() {
+		for _, ch := range waits {
+			<-ch
+		}
+	}()
+	if len(added) == 0 {
+		return
+	}
+	names := make([]string, 0, len(added))
+	for name := range added {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	err := c.registerSoftSchemaFields(collection, schema, exists, added)
+	state.mu.Lock()
+	for _, name := range names {
+		if err != nil {
+			delete(known, name)
+		} else {
+			known[name] = registeredField
+		}
+	}
+	state.mu.Unlock()
+	close(done)
+	if err != nil {
+		c.logf("Soft schema: failed to register fields %v on %s: %v", names, collection, err)
+		return
+	}
+	for _, name := range names {
+		c.logf("Soft schema: registered %s.%s as %s", collection, name, added[name].FieldType)
+	}
+}
+
+// registerSoftSchemaFields adds fields to collection's schema, creating the
+// collection if it does not exist. schema is the collection's schema if it
+// was just loaded, or nil to fetch it.
+func (c *Client) registerSoftSchemaFields(collection string, schema *Schema, exists bool, fields map[string]FieldTypeSchema) error {
+	if schema == nil {
+		current, err := c.GetSchema(collection)
+		if err != nil {
+			return err
+		}
+		schema = current
+	}
+	updated := *schema
+	updated.Fields = make(map[string]FieldTypeSchema, len(schema.Fields)+len(fields))
+	for name, field := range schema.Fields {
+		updated.Fields[name] = field
+	}
+	for name, field := range fields {
+		updated.Fields[name] = field
+	}
+	if exists {
+		return c.UpdateCollectionSchema(collection, updated)
+	}
+	return c.CreateCollection(collection, updated)
+}
+
+// inferFieldType maps a Go value to the ekoDB field type it will be stored
+// as, or "" if it has none, e.g. for nil. Wrapped values
+// ({"type": ..., "value": ...}) report their own type.
+func inferFieldType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "Integer"
+		}
+		return "Float"
+	case string:
+		return "String"
+	case bool:
+		return "Boolean"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return "Integer"
+	case float32, float64:
+		return "Float"
+	case time.Time:
+		return "DateTime"
+	case time.Duration:
+		return "Duration"
+	case []byte:
+		return "Bytes"
+	case []float32, []float64, Vector:
+		return "Vector"
+	case Decimal:
+		return "Decimal"
+	case map[string]interface{}:
+		if t, ok := v["type"].(string); ok {
+			if _, hasValue := v["value"]; hasValue {
+				return t
+			}
+		}
+		return "Object"
+	case Record:
+		return inferFieldType(map[string]interface{}(v))
+	}
+	return schemaFieldType(reflect.TypeOf(value))
+}
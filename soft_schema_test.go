@@ -0,0 +1,188 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSoftSchemaRegistersNewFieldsOnce(t *testing.T) {
+	gets, puts := 0, 0
+	var registered Schema
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/collections/notes": func(w http.ResponseWriter, r *http.Request) {
+			gets++
+			fields := map[string]FieldTypeSchema{"title": {FieldType: "String"}}
+			for name, f := range registered.Fields {
+				fields[name] = f
+			}
+			_ = json.NewEncoder(w).Encode(CollectionMetadata{Collection: Schema{Fields: fields}})
+		},
+		"PUT /api/collections/notes": func(w http.ResponseWriter, r *http.Request) {
+			puts++
+			_ = json.NewDecoder(r.Body).Decode(&registered)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+		"POST /api/insert/notes": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(Record{"id": "n1"})
+		},
+		"POST /api/insert/strict": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(Record{"id": "s1"})
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	client.EnableSoftSchema(SoftSchemaConfig{Collections: []string{"notes"}})
+
+	if _, err := client.Insert("notes", Record{
+		"title":   "hi",
+		"pinned":  true,
+		"views":   3,
+		"total":   FieldDecimal("9.99"),
+		"created": time.Now(),
+	}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if puts != 1 {
+		t.Fatalf("expected one schema update, got %d", puts)
+	}
+	want := map[string]string{"title": "String", "pinned": "Boolean", "views": "Integer", "total": "Decimal", "created": "DateTime"}
+	for name, typ := range want {
+		if registered.Fields[name].FieldType != typ {
+			t.Errorf("field %s registered as %q, want %q", name, registered.Fields[name].FieldType, typ)
+		}
+	}
+
+	// Known fields don't trigger another update or schema fetch.
+	if _, err := client.Insert("notes", Record{"title": "again", "views": 4}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if puts != 1 || gets != 1 {
+		t.Errorf("expected no further schema traffic, got gets=%d puts=%d", gets, puts)
+	}
+
+	// Collections outside the allow-list are left alone.
+	if _, err := client.Insert("strict", Record{"anything": 1}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if gets != 1 {
+		t.Errorf("strict collection must not be inspected, got %d schema fetches", gets)
+	}
+}
+
+func TestSoftSchemaCreatesMissingCollection(t *testing.T) {
+	var created Schema
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/collections/fresh": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		},
+		"POST /api/collections/fresh": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+		"POST /api/batch/insert/fresh": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"successful": []string{"a", "b"}, "failed": []interface{}{}})
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	client.EnableSoftSchema(SoftSchemaConfig{Collections: []string{"*"}})
+	_, err := client.BatchInsert("fresh", []Record{
+		{"name": "a", "tags": []string{"x"}},
+		{"name": "b", "meta": map[string]interface{}{"k": 1}},
+	})
+	if err != nil {
+		t.Fatalf("BatchInsert failed: %v", err)
+	}
+	want := map[string]string{"name": "String", "tags": "Array", "meta": "Object"}
+	for name, typ := range want {
+		if created.Fields[name].FieldType != typ {
+			t.Errorf("field %s created as %q, want %q", name, created.Fields[name].FieldType, typ)
+		}
+	}
+}
+
+func TestInferFieldType(t *testing.T) {
+	cases := map[string]interface{}{
+		"String":  "s",
+		"Integer": int64(1),
+		"Float":   1.5,
+		"Vector":  []float64{0.1},
+		"Bytes":   []byte("x"),
+		"UUID":    FieldUUID("550e8400-e29b-41d4-a716-446655440000"),
+		"Object":  map[string]interface{}{"value": 1, "currency": "USD"},
+	}
+	for want, value := range cases {
+		if got := inferFieldType(value); got != want {
+			t.Errorf("inferFieldType(%v) = %s, want %s", value, got, want)
+		}
+	}
+	more := []struct {
+		value interface{}
+		want  string
+	}{
+		{json.Number("42"), "Integer"},
+		{json.Number("4.2"), "Float"},
+		{Vector{0.1, 0.2}, "Vector"},
+		{MustParseDecimal("1.50"), "Decimal"},
+		{[]interface{}{1, "a"}, "Array"},
+		{nil, ""},
+		{make(chan int), ""},
+	}
+	for _, tt := range more {
+		if got := inferFieldType(tt.value); got != tt.want {
+			t.Errorf("inferFieldType(%T) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSoftSchemaDoesNotBlockOtherCollections(t *testing.T) {
+	release := make(chan struct{})
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/collections/slow": func(w http.ResponseWriter, r *http.Request) {
+			<-release
+			_ = json.NewEncoder(w).Encode(CollectionMetadata{Collection: Schema{Fields: map[string]FieldTypeSchema{}}})
+		},
+		"GET /api/collections/fast": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(CollectionMetadata{Collection: Schema{Fields: map[string]FieldTypeSchema{}}})
+		},
+		"PUT /api/collections/*": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.EnableSoftSchema(SoftSchemaConfig{Collections: []string{"*"}})
+
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+		client.softSchemaObserve("slow", Record{"a": 1})
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	fastDone := make(chan struct{})
+	go func() {
+		defer close(fastDone)
+		client.softSchemaObserve("fast", Record{"b": 1, "empty": nil})
+	}()
+	select {
+	case <-fastDone:
+	case <-time.After(2 * time.Second):
+		t.Fatal("soft schema for one collection waited on another's schema request")
+	}
+	close(release)
+	<-slowDone
+
+	client.softSchema.mu.Lock()
+	defer client.softSchema.mu.Unlock()
+	if _, ok := client.softSchema.known["fast"]["empty"]; ok {
+		t.Error("a nil value must not register a field")
+	}
+	if _, ok := client.softSchema.known["slow"]["a"]; !ok {
+		t.Error("field a was not registered")
+	}
+}
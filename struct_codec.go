@@ -0,0 +1,680 @@
+package ekodb
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// Struct Codec
+// ============================================================================
+// MarshalRecord and UnmarshalRecord map Go structs to ekoDB records. Fields
+// are named by the `ekodb` tag, falling back to the `json` tag and then the
+// Go field name; a type option in the tag wraps the value in the matching
+// ekoDB type on the way in and is unwrapped on the way out:
+//
+//   type Order struct {
+//       ID        string    `ekodb:"id,omitempty"`
+//       Total     Decimal   `ekodb:"total"`
+//       CreatedAt time.Time `ekodb:"created_at,datetime"`
+//       Tags      []string  `ekodb:"tags,set"`
+//       Internal  string    `ekodb:"-"`
+//   }
+//
+// The schema options required, unique, index, and dims are read only by
+// SchemaFromStruct.
+
+// codecWrapTypes maps tag options to the ekoDB type they wrap values in.
+var codecWrapTypes = map[string]string{
+	"array":    "Array",
+	"binary":   "Binary",
+	"boolean":  "Boolean",
+	"bytes":    "Bytes",
+	"datetime": "DateTime",
+	"decimal":  "Decimal",
+	"duration": "Duration",
+	"float":    "Float",
+	"integer":  "Integer",
+	"number":   "Number",
+	"object":   "Object",
+	"set":      "Set",
+	"string":   "String",
+	"uuid":     "UUID",
+	"vector":   "Vector",
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	bytesType    = reflect.TypeOf([]byte(nil))
+	decimalType  = reflect.TypeOf(Decimal{})
+)
+
+// codecField is a struct field as seen by the codec.
+type codecField struct {
+	index     []int
+	name      string
+	wrap      string // ekoDB type to wrap the value in; "" sends it as is
+	omitEmpty bool
+	schema    codecSchemaOpts // Used only by SchemaFromStruct
+}
+
+// codecFieldCache caches a struct type's codec fields (or the error from
+// parsing its tags).
+var codecFieldCache sync.Map // reflect.Type -> codecFieldsResult
+
+type codecFieldsResult struct {
+	fields []codecField
+	err    error
+}
+
+func codecFields(t reflect.Type) ([]codecField, error) {
+	if cached, ok := codecFieldCache.Load(t); ok {
+		r := cached.(codecFieldsResult)
+		return r.fields, r.err
+	}
+	fields, err := parseCodecFields(t)
+	codecFieldCache.Store(t, codecFieldsResult{fields, err})
+	return fields, err
+}
+
+func parseCodecFields(t reflect.Type) ([]codecField, error) {
+	var fields []codecField
+	for _, sf := range reflect.VisibleFields(t) {
+		tag, hasTag := sf.Tag.Lookup("ekodb")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		// Untagged embedded structs are flattened: VisibleFields already lists
+		// their promoted fields.
+		if sf.Anonymous && !hasTag && sf.Type.Kind() != reflect.Interface {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				continue
+			}
+		}
+		if len(sf.Index) > 1 && !promotedFromUntaggedEmbed(t, sf.Index) {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if !hasTag {
+			jsonName, jsonOpts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+			if jsonName == "-" && jsonOpts == "" {
+				continue
+			}
+			name = jsonName
+			if strings.Contains(","+jsonOpts+",", ",omitempty,") {
+				opts = "omitempty"
+			}
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		field := codecField{index: sf.Index, name: name}
+		for _, opt := range strings.Split(opts, ",") {
+			switch {
+			case opt == "":
+			case opt == "omitempty":
+				field.omitEmpty = true
+			case codecWrapTypes[opt] != "":
+				field.wrap = codecWrapTypes[opt]
+			default:
+				known, err := field.schema.parse(opt)
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", t.Name(), sf.Name, err)
+				}
+				if !known {
+					return nil, fmt.Errorf("%s.%s: unknown ekodb tag option %q", t.Name(), sf.Name, opt)
+				}
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// promotedFromUntaggedEmbed reports whether every struct along index (but the
+// last) is an untagged embedded struct, i.e. the field was flattened.
+func promotedFromUntaggedEmbed(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		sf := t.Field(i)
+		if _, tagged := sf.Tag.Lookup("ekodb"); tagged || !sf.Anonymous {
+			return false
+		}
+		t = sf.Type
+	}
+	return true
+}
+
+// MarshalRecord converts a struct (or pointer to struct) into a Record for
+// Insert, Update, or the batch operations, wrapping fields whose tag names an
+// ekoDB type — decimal, datetime, uuid, duration, set, vector, binary, bytes,
+// number, array, object, string, integer, float, or boolean. Decimal fields
+// are always sent as Decimals. Add omitempty to leave out zero values.
+// Nested structs become objects.
+func MarshalRecord(v interface{}) (Record, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("MarshalRecord: nil %s", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("MarshalRecord: expected a struct, got %s", rv.Type())
+	}
+	m, err := marshalStruct(rv)
+	if err != nil {
+		return nil, err
+	}
+	return Record(m), nil
+}
+
+func marshalStruct(rv reflect.Value) (map[string]interface{}, error) {
+	fields, err := codecFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		fv, err := rv.FieldByIndexErr(f.index)
+		if err != nil {
+			continue // Field of a nil embedded pointer
+		}
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		value, err := marshalValue(fv)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.name, err)
+		}
+		if f.wrap != "" && value != nil && !hasFieldCodec(fv.Type()) {
+			if value, err = wrapCodecValue(f.wrap, fv); err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.name, err)
+			}
+		}
+		out[f.name] = value
+	}
+	return out, nil
+}
+
+// marshalValue converts nested structs (and slices and maps of them) into
+// maps and runs registered field codecs; other values are returned as is.
+func marshalValue(rv reflect.Value) (interface{}, error) {
+	if rv.IsValid() {
+		if codec, ok := lookupFieldCodec(rv.Type()); ok {
+			if (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil() {
+				return nil, nil
+			}
+			return encodeWithCodec(codec, rv)
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return marshalValue(rv.Elem())
+	case reflect.Struct:
+		switch rv.Type() {
+		case timeType:
+			return rv.Interface(), nil
+		case decimalType:
+			return FieldDecimalOf(rv.Interface().(Decimal)), nil
+		}
+		return marshalStruct(rv)
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil, nil
+		}
+		if !containsStructs(rv.Type().Elem()) {
+			return rv.Interface(), nil
+		}
+		out := make([]interface{}, rv.Len())
+		for i := range out {
+			v, err := marshalValue(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = v
+		}
+		return out, nil
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Type().Key().Kind() != reflect.String || !containsStructs(rv.Type().Elem()) {
+			return rv.Interface(), nil
+		}
+		out := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			v, err := marshalValue(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			out[iter.Key().String()] = v
+		}
+		return out, nil
+	}
+	return rv.Interface(), nil
+}
+
+// containsStructs reports whether values of t need converting by
+// marshalValue.
+func containsStructs(t reflect.Type) bool {
+	if hasFieldCodec(t) {
+		return true
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != timeType
+	case reflect.Interface:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return containsStructs(t.Elem())
+	}
+	return false
+}
+
+// wrapCodecValue wraps rv in the ekoDB type typ, converting Go
+// representations (time.Time, time.Duration, []byte, numbers for Decimal)
+// the way the FieldXxx builders do.
+func wrapCodecValue(typ string, rv reflect.Value) (interface{}, error) {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	switch typ {
+	case "DateTime":
+		switch v := rv.Interface().(type) {
+		case time.Time:
+			return FieldDateTime(v), nil
+		case string:
+			return FieldDateTimeString(v), nil
+		}
+	case "Decimal":
+		if s, ok := decimalString(rv); ok {
+			return FieldDecimal(s), nil
+		}
+	case "UUID":
+		if s, ok := stringish(rv); ok {
+			return FieldUUID(s), nil
+		}
+	case "Duration":
+		if rv.Type() == durationType {
+			return FieldDurationFromGo(time.Duration(rv.Int())), nil
+		}
+		if rv.CanInt() {
+			return FieldDuration(rv.Int()), nil
+		}
+	case "Vector":
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			values := make([]float64, rv.Len())
+			for i := range values {
+				elem := rv.Index(i)
+				switch {
+				case elem.CanFloat():
+					values[i] = elem.Float()
+				case elem.CanInt():
+					values[i] = float64(elem.Int())
+				default:
+					return nil, fmt.Errorf("cannot encode %s as Vector", rv.Type())
+				}
+			}
+			return FieldVector(values), nil
+		}
+	case "Binary", "Bytes":
+		if rv.Type() == bytesType {
+			return map[string]interface{}{"type": typ, "value": base64.StdEncoding.EncodeToString(rv.Bytes())}, nil
+		}
+		if rv.Kind() == reflect.String {
+			return map[string]interface{}{"type": typ, "value": rv.String()}, nil
+		}
+	default:
+		value, err := marshalValue(rv)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": typ, "value": value}, nil
+	}
+	return nil, fmt.Errorf("cannot encode %s as %s", rv.Type(), typ)
+}
+
+// decimalString formats strings, numbers, and fmt.Stringers as a decimal
+// string.
+func decimalString(rv reflect.Value) (string, bool) {
+	switch {
+	case rv.Kind() == reflect.String:
+		return rv.String(), true
+	case rv.CanInt():
+		return strconv.FormatInt(rv.Int(), 10), true
+	case rv.CanUint():
+		return strconv.FormatUint(rv.Uint(), 10), true
+	case rv.CanFloat():
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64), true
+	}
+	return stringish(rv)
+}
+
+// stringish returns the value of a string or fmt.Stringer.
+func stringish(rv reflect.Value) (string, bool) {
+	if rv.Kind() == reflect.String {
+		return rv.String(), true
+	}
+	if s, ok := rv.Interface().(fmt.Stringer); ok {
+		return s.String(), true
+	}
+	return "", false
+}
+
+// UnmarshalRecord populates the struct pointed to by v from rec, matching
+// fields by the same names MarshalRecord uses. Wrapped values
+// ({"type": ..., "value": ...}) are unwrapped whether or not the field's tag
+// names a type, DateTime strings are parsed into time.Time, Decimal strings
+// into Decimal and numeric fields, and Duration values into time.Duration
+// (plain numbers are milliseconds, as written by FieldDuration). Fields
+// missing from rec are left unchanged.
+func UnmarshalRecord(rec Record, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("UnmarshalRecord: expected a non-nil pointer to a struct, got %T", v)
+	}
+	return unmarshalStruct(rv.Elem(), rec)
+}
+
+func unmarshalStruct(dst reflect.Value, src map[string]interface{}) error {
+	fields, err := codecFields(dst.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		raw, ok := src[f.name]
+		if !ok {
+			continue
+		}
+		fv := fieldByIndexAlloc(dst, f.index)
+		if err := unmarshalValue(fv, raw); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// unmarshalMap replaces dst, a map with string keys, with obj's entries.
+func unmarshalMap(dst reflect.Value, obj map[string]interface{}) error {
+	out := reflect.MakeMapWithSize(dst.Type(), len(obj))
+	for k, v := range obj {
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		if err := unmarshalValue(elem, v); err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+		out.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+	}
+	dst.Set(out)
+	return nil
+}
+
+// fieldByIndexAlloc is reflect.Value.FieldByIndex, allocating nil embedded
+// struct pointers on the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// asObject returns src as a map when it is a JSON object.
+func asObject(src interface{}) (map[string]interface{}, bool) {
+	switch m := src.(type) {
+	case map[string]interface{}:
+		return m, true
+	case Record:
+		return m, true
+	}
+	return nil, false
+}
+
+// unmarshalValue converts a decoded record value into dst.
+func unmarshalValue(dst reflect.Value, raw interface{}) error {
+	src := GetValue(raw)
+	if m, ok := src.(Record); ok {
+		src = GetValue(map[string]interface{}(m))
+	}
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if codec, ok := lookupFieldCodec(dst.Type()); ok {
+		return decodeWithCodec(codec, dst, src)
+	}
+
+	switch dst.Type() {
+	case timeType:
+		t, err := parseCodecTime(src)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := parseCodecDuration(src)
+		if err != nil {
+			return err
+		}
+		dst.SetInt(int64(d))
+		return nil
+	case decimalType:
+		d, err := decimalFrom(src)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(d))
+		return nil
+	case bytesType:
+		b := GetBytesValue(src)
+		if b == nil {
+			return fmt.Errorf("cannot decode %T into []byte", src)
+		}
+		dst.SetBytes(b)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return unmarshalValue(dst.Elem(), src)
+	case reflect.Interface:
+		if reflect.TypeOf(src).AssignableTo(dst.Type()) {
+			dst.Set(reflect.ValueOf(src))
+			return nil
+		}
+	case reflect.String:
+		switch s := src.(type) {
+		case string:
+			dst.SetString(s)
+			return nil
+		case json.Number:
+			dst.SetString(s.String())
+			return nil
+		case float64:
+			dst.SetString(strconv.FormatFloat(s, 'f', -1, 64))
+			return nil
+		}
+	case reflect.Bool:
+		if b, ok := src.(bool); ok {
+			dst.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := codecInt(src); ok && !dst.OverflowInt(n) {
+			dst.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n, ok := codecInt(src); ok && n >= 0 && !dst.OverflowUint(uint64(n)) {
+			dst.SetUint(uint64(n))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, ok := codecFloat(src); ok {
+			dst.SetFloat(f)
+			return nil
+		}
+	case reflect.Slice:
+		items := reflect.ValueOf(src)
+		if items.Kind() == reflect.Slice || items.Kind() == reflect.Array {
+			out := reflect.MakeSlice(dst.Type(), items.Len(), items.Len())
+			for i := 0; i < items.Len(); i++ {
+				if err := unmarshalValue(out.Index(i), items.Index(i).Interface()); err != nil {
+					return fmt.Errorf("[%d]: %w", i, err)
+				}
+			}
+			dst.Set(out)
+			return nil
+		}
+	case reflect.Array:
+		items := reflect.ValueOf(src)
+		if items.Kind() == reflect.Slice || items.Kind() == reflect.Array {
+			for i := 0; i < dst.Len() && i < items.Len(); i++ {
+				if err := unmarshalValue(dst.Index(i), items.Index(i).Interface()); err != nil {
+					return fmt.Errorf("[%d]: %w", i, err)
+				}
+			}
+			return nil
+		}
+	case reflect.Map:
+		if obj, ok := asObject(src); ok && dst.Type().Key().Kind() == reflect.String {
+			return unmarshalMap(dst, obj)
+		}
+	case reflect.Struct:
+		if obj, ok := asObject(src); ok {
+			return unmarshalStruct(dst, obj)
+		}
+	}
+
+	// Anything else (e.g. types with their own UnmarshalJSON) goes through a
+	// JSON round trip.
+	b, err := json.Marshal(src)
+	if err == nil && json.Unmarshal(b, dst.Addr().Interface()) == nil {
+		return nil
+	}
+	return fmt.Errorf("cannot decode %T into %s", src, dst.Type())
+}
+
+func parseCodecTime(src interface{}) (time.Time, error) {
+	switch v := src.(type) {
+	case time.Time:
+		if currentDateTimeOptions().UTC {
+			v = v.UTC()
+		}
+		return v, nil
+	case string:
+		return ParseDateTime(v)
+	}
+	return time.Time{}, fmt.Errorf("cannot decode %T into time.Time", src)
+}
+
+func parseCodecDuration(src interface{}) (time.Duration, error) {
+	switch v := src.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		return time.ParseDuration(v)
+	case map[string]interface{}:
+		return GetDurationValue(v), nil
+	}
+	if ms, ok := codecInt(src); ok {
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+	return 0, fmt.Errorf("cannot decode %T into time.Duration", src)
+}
+
+// codecInt converts numbers, json.Numbers, and numeric strings to int64,
+// rejecting fractional values.
+func codecInt(src interface{}) (int64, bool) {
+	switch v := src.(type) {
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	case float64:
+		if v != float64(int64(v)) {
+			return 0, false
+		}
+		return int64(v), true
+	case float32:
+		if v != float32(int64(v)) {
+			return 0, false
+		}
+		return int64(v), true
+	}
+	rv := reflect.ValueOf(src)
+	switch {
+	case rv.CanInt():
+		return rv.Int(), true
+	case rv.CanUint() && rv.Uint() <= 1<<63-1:
+		return int64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+// codecFloat converts numbers, json.Numbers, and decimal strings to float64.
+func codecFloat(src interface{}) (float64, bool) {
+	switch v := src.(type) {
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	rv := reflect.ValueOf(src)
+	switch {
+	case rv.CanFloat():
+		return rv.Float(), true
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+// InsertStruct inserts v, converted with MarshalRecord, and returns the
+// inserted record.
+func (c *Client) InsertStruct(collection string, v interface{}, opts ...InsertOptions) (Record, error) {
+	record, err := MarshalRecord(v)
+	if err != nil {
+		return nil, err
+	}
+	return c.Insert(collection, record, opts...)
+}
@@ -0,0 +1,166 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type codecAddress struct {
+	City    string `ekodb:"city"`
+	Country string `json:"country,omitempty"`
+}
+
+type codecAudit struct {
+	CreatedBy string `ekodb:"created_by"`
+}
+
+type codecOrder struct {
+	codecAudit
+	ID        string         `ekodb:"id,omitempty"`
+	Total     float64        `ekodb:"total,decimal"`
+	CreatedAt time.Time      `ekodb:"created_at,datetime"`
+	Timeout   time.Duration  `ekodb:"timeout,duration"`
+	Tags      []string       `ekodb:"tags,set"`
+	Embedding []float32      `ekodb:"embedding,vector"`
+	Payload   []byte         `ekodb:"payload,bytes"`
+	Ship      codecAddress   `ekodb:"ship"`
+	Stops     []codecAddress `ekodb:"stops,omitempty"`
+	Note      *string        `ekodb:"note,omitempty"`
+	Count     int            `json:"count"`
+	Internal  string         `ekodb:"-"`
+}
+
+func TestMarshalRecordWrapsTaggedFields(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rec, err := MarshalRecord(&codecOrder{
+		codecAudit: codecAudit{CreatedBy: "ops"},
+		Total:      19.99,
+		CreatedAt:  created,
+		Timeout:    1500 * time.Millisecond,
+		Tags:       []string{"sale"},
+		Embedding:  []float32{0.5, 1},
+		Payload:    []byte("hi"),
+		Ship:       codecAddress{City: "Oslo"},
+		Count:      3,
+		Internal:   "secret",
+	})
+	if err != nil {
+		t.Fatalf("MarshalRecord failed: %v", err)
+	}
+
+	want := Record{
+		"created_by": "ops",
+		"total":      FieldDecimal("19.99"),
+		"created_at": FieldDateTime(created),
+		"timeout":    FieldDuration(1500),
+		"tags":       FieldSet([]string{"sale"}),
+		"embedding":  FieldVector([]float64{0.5, 1}),
+		"payload":    FieldBytes([]byte("hi")),
+		"ship":       map[string]interface{}{"city": "Oslo"},
+		"count":      3,
+	}
+	if !reflect.DeepEqual(rec, want) {
+		t.Errorf("MarshalRecord =\n%#v\nwant\n%#v", rec, want)
+	}
+}
+
+func TestUnmarshalRecordUnwrapsValues(t *testing.T) {
+	// A record as it comes back from the server over JSON.
+	var rec Record
+	raw := `{
+		"id": "o1",
+		"created_by": {"type": "String", "value": "ops"},
+		"total": {"type": "Decimal", "value": "19.99"},
+		"created_at": {"type": "DateTime", "value": "2026-03-01T12:00:00.25Z"},
+		"timeout": {"type": "Duration", "value": 1500},
+		"tags": {"type": "Set", "value": ["sale", "new"]},
+		"embedding": {"type": "Vector", "value": [0.5, 1]},
+		"payload": {"type": "Bytes", "value": "aGk="},
+		"ship": {"type": "Object", "value": {"city": "Oslo", "country": "NO"}},
+		"stops": [{"city": "Bergen"}],
+		"note": "fragile",
+		"count": {"type": "Integer", "value": 3}
+	}`
+	if err := json.Unmarshal([]byte(raw), &rec); err != nil {
+		t.Fatal(err)
+	}
+
+	var order codecOrder
+	if err := UnmarshalRecord(rec, &order); err != nil {
+		t.Fatalf("UnmarshalRecord failed: %v", err)
+	}
+	if order.ID != "o1" || order.CreatedBy != "ops" || order.Total != 19.99 || order.Count != 3 {
+		t.Errorf("scalars not decoded: %+v", order)
+	}
+	if !order.CreatedAt.Equal(time.Date(2026, 3, 1, 12, 0, 0, 250e6, time.UTC)) {
+		t.Errorf("CreatedAt = %v", order.CreatedAt)
+	}
+	if order.Timeout != 1500*time.Millisecond {
+		t.Errorf("Timeout = %v", order.Timeout)
+	}
+	if !reflect.DeepEqual(order.Tags, []string{"sale", "new"}) || !reflect.DeepEqual(order.Embedding, []float32{0.5, 1}) {
+		t.Errorf("slices not decoded: tags=%v embedding=%v", order.Tags, order.Embedding)
+	}
+	if string(order.Payload) != "hi" {
+		t.Errorf("Payload = %q", order.Payload)
+	}
+	if order.Ship != (codecAddress{City: "Oslo", Country: "NO"}) || len(order.Stops) != 1 || order.Stops[0].City != "Bergen" {
+		t.Errorf("nested structs not decoded: ship=%+v stops=%+v", order.Ship, order.Stops)
+	}
+	if order.Note == nil || *order.Note != "fragile" {
+		t.Errorf("Note = %v", order.Note)
+	}
+}
+
+func TestStructCodecErrors(t *testing.T) {
+	if _, err := MarshalRecord(map[string]interface{}{}); err == nil {
+		t.Error("expected an error for a non-struct")
+	}
+	type badTag struct {
+		A string `ekodb:"a,money"`
+	}
+	if _, err := MarshalRecord(badTag{}); err == nil {
+		t.Error("expected an error for an unknown tag option")
+	}
+	type badVector struct {
+		V []string `ekodb:"v,vector"`
+	}
+	if _, err := MarshalRecord(badVector{V: []string{"x"}}); err == nil {
+		t.Error("expected an error encoding strings as a Vector")
+	}
+
+	var order codecOrder
+	if err := UnmarshalRecord(Record{"count": "many"}, &order); err == nil {
+		t.Error("expected an error decoding a word into an int")
+	}
+	if err := UnmarshalRecord(Record{}, order); err == nil {
+		t.Error("expected an error for a non-pointer")
+	}
+}
+
+func TestInsertStruct(t *testing.T) {
+	var got map[string]interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/insert/orders": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "o1"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	rec, err := client.InsertStruct("orders", codecOrder{Total: 5})
+	if err != nil {
+		t.Fatalf("InsertStruct failed: %v", err)
+	}
+	if rec["id"] != "o1" {
+		t.Errorf("unexpected result: %v", rec)
+	}
+	total, _ := got["total"].(map[string]interface{})
+	if total["type"] != "Decimal" || total["value"] != "5" {
+		t.Errorf("total sent as %v", got["total"])
+	}
+}
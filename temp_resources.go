@@ -0,0 +1,126 @@
+package ekodb
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Temporary resources created by client helpers are named
+// <prefix><unix millis>_<random hex>, so CleanupOrphans can tell how old one
+// is from its name alone, even after the process that created it has died.
+const (
+	TempCollectionPrefix = "embed_temp_"
+	TempScriptPrefix     = "embed_script_"
+)
+
+// TempCollectionName returns a fresh name for a temporary collection.
+func TempCollectionName() string {
+	return tempResourceName(TempCollectionPrefix, time.Now())
+}
+
+// TempScriptLabel returns a fresh label for a temporary function.
+func TempScriptLabel() string {
+	return tempResourceName(TempScriptPrefix, time.Now())
+}
+
+func tempResourceName(prefix string, at time.Time) string {
+	return fmt.Sprintf("%s%d_%08x", prefix, at.UnixMilli(), rand.Uint32())
+}
+
+// tempResourceCreatedAt returns the creation time encoded in a temporary
+// resource's name.
+func tempResourceCreatedAt(name, prefix string) (time.Time, bool) {
+	rest, ok := strings.CutPrefix(name, prefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	millis, _, _ := strings.Cut(rest, "_")
+	ms, err := strconv.ParseInt(millis, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms), true
+}
+
+// OrphanCleanupResult lists the temporary resources CleanupOrphans removed.
+type OrphanCleanupResult struct {
+	Collections []string
+	Scripts     []string
+}
+
+// CleanupOrphans deletes temporary collections (TempCollectionPrefix) and
+// functions (TempScriptPrefix) created more than olderThan ago, such as those
+// left behind when a process dies in the middle of a helper call. The age
+// comes from the name; functions with names that carry no timestamp fall back
+// to their CreatedAt, and resources whose age cannot be determined are kept.
+//
+// A failed deletion does not stop the sweep; the errors are joined and
+// returned along with what was removed. Set ClientConfig.CleanupOrphansOnStart
+// to run this whenever a client is created.
+func (c *Client) CleanupOrphans(olderThan time.Duration, opts ...RequestOption) (*OrphanCleanupResult, error) {
+	c = c.withOptions(opts)
+	cutoff := time.Now().Add(-olderThan)
+	result := &OrphanCleanupResult{}
+	var errs []error
+
+	collections, err := c.ListCollections()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	for _, name := range collections {
+		created, ok := tempResourceCreatedAt(name, TempCollectionPrefix)
+		if !ok || !created.Before(cutoff) {
+			continue
+		}
+		if err := c.DeleteCollection(name); err != nil {
+			errs = append(errs, fmt.Errorf("collection %s: %w", name, err))
+			continue
+		}
+		result.Collections = append(result.Collections, name)
+	}
+
+	functions, err := c.ListFunctions(nil)
+	if err != nil {
+		return result, errors.Join(append(errs, fmt.Errorf("failed to list functions: %w", err))...)
+	}
+	for _, fn := range functions {
+		if !strings.HasPrefix(fn.Label, TempScriptPrefix) {
+			continue
+		}
+		created, ok := tempResourceCreatedAt(fn.Label, TempScriptPrefix)
+		if !ok && fn.CreatedAt != nil {
+			created, ok = *fn.CreatedAt, true
+		}
+		if !ok || !created.Before(cutoff) {
+			continue
+		}
+		id := fn.Label
+		if fn.ID != nil {
+			id = *fn.ID
+		}
+		if err := c.DeleteFunction(id); err != nil {
+			errs = append(errs, fmt.Errorf("function %s: %w", fn.Label, err))
+			continue
+		}
+		result.Scripts = append(result.Scripts, fn.Label)
+	}
+	return result, errors.Join(errs...)
+}
+
+// cleanupOrphansInBackground runs CleanupOrphans without delaying client
+// construction, logging what it removed.
+func (c *Client) cleanupOrphansInBackground(olderThan time.Duration) {
+	go func() {
+		result, err := c.CleanupOrphans(olderThan)
+		if err != nil {
+			c.logf("Orphan cleanup failed: %v", err)
+		}
+		if result != nil && len(result.Collections)+len(result.Scripts) > 0 {
+			c.logf("Orphan cleanup removed %d collection(s) and %d function(s)", len(result.Collections), len(result.Scripts))
+		}
+	}()
+}
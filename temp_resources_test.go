@@ -0,0 +1,111 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func orphanHandlers(old, fresh string, mu *sync.Mutex, deleted *[]string) map[string]http.HandlerFunc {
+	record := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*deleted = append(*deleted, strings.TrimPrefix(r.URL.Path, "/api/"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}
+	return map[string]http.HandlerFunc{
+		"GET /api/collections": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"collections": []string{"users", "embed_temp_" + old + "_0a0b0c0d", "embed_temp_" + fresh + "_01020304", "embed_temp_legacy"},
+			})
+		},
+		"GET /api/functions": func(w http.ResponseWriter, r *http.Request) {
+			id := "fn-1"
+			created := time.Now().Add(-48 * time.Hour)
+			_ = json.NewEncoder(w).Encode([]UserFunction{
+				{Label: "embed_script_" + old + "_deadbeef", ID: &id},
+				{Label: "embed_script_manual", CreatedAt: &created},
+				{Label: "report"},
+			})
+		},
+		"DELETE /api/collections/*": record,
+		"DELETE /api/functions/*":   record,
+	}
+}
+
+func TestCleanupOrphans(t *testing.T) {
+	old := strconv.FormatInt(time.Now().Add(-2*time.Hour).UnixMilli(), 10)
+	fresh := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	var mu sync.Mutex
+	var deleted []string
+	server := createTestServer(t, orphanHandlers(old, fresh, &mu, &deleted))
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	result, err := client.CleanupOrphans(time.Hour)
+	if err != nil {
+		t.Fatalf("CleanupOrphans failed: %v", err)
+	}
+	if len(result.Collections) != 1 || result.Collections[0] != "embed_temp_"+old+"_0a0b0c0d" {
+		t.Errorf("collections removed = %v", result.Collections)
+	}
+	if len(result.Scripts) != 2 {
+		t.Errorf("scripts removed = %v", result.Scripts)
+	}
+	want := "collections/embed_temp_" + old + "_0a0b0c0d,functions/fn-1,functions/embed_script_manual"
+	if got := strings.Join(deleted, ","); got != want {
+		t.Errorf("deleted %s, want %s", got, want)
+	}
+}
+
+func TestCleanupOrphansOnStart(t *testing.T) {
+	old := strconv.FormatInt(time.Now().Add(-2*time.Hour).UnixMilli(), 10)
+	var mu sync.Mutex
+	var deleted []string
+	server := createTestServer(t, orphanHandlers(old, old, &mu, &deleted))
+	defer server.Close()
+
+	_, err := NewClientWithConfig(ClientConfig{
+		BaseURL:               server.URL,
+		APIKey:                "test-api-key",
+		Format:                JSON,
+		Logger:                log.New(io.Discard, "", 0),
+		CleanupOrphansOnStart: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		n := len(deleted)
+		mu.Unlock()
+		if n == 4 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("background cleanup deleted %d resources, want 4", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTempResourceNames(t *testing.T) {
+	name := TempCollectionName()
+	created, ok := tempResourceCreatedAt(name, TempCollectionPrefix)
+	if !ok || time.Since(created) > time.Minute {
+		t.Errorf("tempResourceCreatedAt(%q) = %v, %v", name, created, ok)
+	}
+	if !strings.HasPrefix(TempScriptLabel(), TempScriptPrefix) {
+		t.Error("TempScriptLabel is missing its prefix")
+	}
+	if _, ok := tempResourceCreatedAt("embed_temp_legacy", TempCollectionPrefix); ok {
+		t.Error("expected no timestamp in a legacy name")
+	}
+}
@@ -0,0 +1,49 @@
+package ekodb
+
+import (
+	"fmt"
+	"time"
+)
+
+// tokenRejectedError is returned when the token endpoint refuses the API key.
+// Its message matches the other token-endpoint failures.
+type tokenRejectedError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *tokenRejectedError) Error() string {
+	return fmt.Sprintf("auth failed with status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// SetToken replaces the client's cached authentication token, e.g. with one
+// cached by another process. The expiry is read from the JWT's "exp" claim;
+// a token without one is used until the server rejects it, at which point a
+// new token is fetched with the API key. Derived clients share the token.
+func (c *Client) SetToken(token string) {
+	c = c.root()
+	expiry, _ := extractJWTExpiry(token)
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.token = token
+	c.tokenExpiry = expiry
+}
+
+// needsToken reports whether the cached token is missing or expires within
+// 60 seconds.
+func (c *Client) needsToken() bool {
+	c = c.root()
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	if c.token == "" {
+		return true
+	}
+	return c.tokenExpiry > 0 && time.Now().Unix()+60 >= c.tokenExpiry
+}
+
+// notifyAuthFailure calls the OnAuthFailure hook, if any.
+func (c *Client) notifyAuthFailure(err error) {
+	if c.onAuthFailure != nil {
+		c.onAuthFailure(err)
+	}
+}
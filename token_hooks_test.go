@@ -0,0 +1,147 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOnTokenRefreshReceivesExpiry(t *testing.T) {
+	exp := time.Now().Add(2 * time.Hour).Unix()
+	jwt := makeTestJWT(map[string]interface{}{"exp": exp})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": jwt})
+	}))
+	defer server.Close()
+
+	var gotToken string
+	var gotExpiry time.Time
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL: server.URL,
+		APIKey:  "test-key",
+		Format:  JSON,
+		OnTokenRefresh: func(token string, expiresAt time.Time) {
+			gotToken, gotExpiry = token, expiresAt
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+	if gotToken != jwt || gotExpiry.Unix() != exp {
+		t.Errorf("hook got (%q, %v), want (%q, %d)", gotToken, gotExpiry, jwt, exp)
+	}
+
+	// The hook runs outside the token lock, so it may use the client.
+	client.onTokenRefresh = func(string, time.Time) { _ = client.getToken() }
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = client.RefreshToken()
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnTokenRefresh calling back into the client deadlocked")
+	}
+}
+
+func TestSeededTokenSkipsInitialFetch(t *testing.T) {
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth/token" {
+			fetches.Add(1)
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "fresh-token"})
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	cached := makeTestJWT(map[string]interface{}{"exp": time.Now().Add(time.Hour).Unix()})
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL: server.URL,
+		APIKey:  "test-key",
+		Format:  JSON,
+		Token:   cached,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+	if n := fetches.Load(); n != 0 {
+		t.Errorf("expected no token fetch with a valid seeded token, got %d", n)
+	}
+	if got := client.getToken(); got != cached {
+		t.Errorf("expected the seeded token, got %q", got)
+	}
+
+	// An expired seed is replaced at construction.
+	expired := makeTestJWT(map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()})
+	client, err = NewClientWithConfig(ClientConfig{
+		BaseURL: server.URL,
+		APIKey:  "test-key",
+		Format:  JSON,
+		Token:   expired,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+	if got := client.getToken(); got != "fresh-token" {
+		t.Errorf("expected an expired seed to be replaced, got %q", got)
+	}
+
+	client.With().SetToken("shared-token")
+	if got := client.getToken(); got != "shared-token" {
+		t.Errorf("SetToken on a derived client should update the shared token, got %q", got)
+	}
+}
+
+func TestOnAuthFailure(t *testing.T) {
+	var rejectKey atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth/token" {
+			if rejectKey.Load() {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte("invalid api key"))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("token revoked"))
+	}))
+	defer server.Close()
+
+	var failures []error
+	config := ClientConfig{
+		BaseURL:       server.URL,
+		APIKey:        "test-key",
+		Format:        JSON,
+		OnAuthFailure: func(err error) { failures = append(failures, err) },
+	}
+	client, err := NewClientWithConfig(config)
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+
+	// Still unauthorized after a successful refresh.
+	if err := client.Health(); err == nil {
+		t.Fatal("expected an auth error")
+	}
+	if len(failures) != 1 || !strings.Contains(failures[0].Error(), "after token refresh") {
+		t.Fatalf("unexpected failures: %v", failures)
+	}
+
+	// API key rejected by the token endpoint.
+	rejectKey.Store(true)
+	if _, err := NewClientWithConfig(config); err == nil {
+		t.Fatal("expected NewClientWithConfig to fail")
+	}
+	if len(failures) != 2 || !strings.Contains(failures[1].Error(), "status: 401") {
+		t.Errorf("unexpected failures: %v", failures)
+	}
+}
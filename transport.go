@@ -0,0 +1,56 @@
+package ekodb
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTransport builds the HTTP transport for a client from its config. It
+// starts from a clone of http.DefaultTransport (proxy-from-environment, dial
+// and TLS handshake timeouts, HTTP/2 over TLS) and applies the pool and
+// protocol settings from config on top. A non-nil dialer replaces the default
+// dial function, which the stream client uses to bound only the connect phase.
+func newTransport(config ClientConfig, dialer *net.Dialer) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if dialer != nil {
+		t.DialContext = dialer.DialContext
+	}
+	if config.MaxIdleConns > 0 {
+		t.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	if config.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = config.MaxConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = config.IdleConnTimeout
+	}
+	if config.TLSConfig != nil {
+		t.TLSClientConfig = config.TLSConfig.Clone()
+	}
+	t.DisableKeepAlives = config.DisableKeepAlives
+	if config.DisableHTTP2 {
+		// A non-nil, empty TLSNextProto map is the documented way to turn off
+		// HTTP/2 on a Transport.
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return t
+}
+
+// websocketDialer returns the dialer used for this client's WebSocket
+// connections: gorilla's default dialer, or a copy of it carrying the
+// client's TLS configuration.
+func (c *Client) websocketDialer() *websocket.Dialer {
+	if c.tlsConfig == nil {
+		return websocket.DefaultDialer
+	}
+	d := *websocket.DefaultDialer
+	d.TLSClientConfig = c.tlsConfig.Clone()
+	return &d
+}
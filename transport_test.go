@@ -0,0 +1,173 @@
+package ekodb
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestNewTransportDefaults(t *testing.T) {
+	tr := newTransport(ClientConfig{}, nil)
+	def := http.DefaultTransport.(*http.Transport)
+	if tr == def {
+		t.Fatal("expected a dedicated transport, not http.DefaultTransport")
+	}
+	if tr.MaxIdleConns != def.MaxIdleConns {
+		t.Errorf("MaxIdleConns = %d, want default %d", tr.MaxIdleConns, def.MaxIdleConns)
+	}
+	if tr.IdleConnTimeout != def.IdleConnTimeout {
+		t.Errorf("IdleConnTimeout = %v, want default %v", tr.IdleConnTimeout, def.IdleConnTimeout)
+	}
+	if !tr.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be attempted by default")
+	}
+}
+
+func TestNewTransportTuning(t *testing.T) {
+	tr := newTransport(ClientConfig{
+		MaxIdleConns:        500,
+		MaxIdleConnsPerHost: 64,
+		MaxConnsPerHost:     128,
+		IdleConnTimeout:     15 * time.Second,
+		DisableKeepAlives:   true,
+		DisableHTTP2:        true,
+	}, nil)
+	if tr.MaxIdleConns != 500 {
+		t.Errorf("MaxIdleConns = %d, want 500", tr.MaxIdleConns)
+	}
+	if tr.MaxIdleConnsPerHost != 64 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 64", tr.MaxIdleConnsPerHost)
+	}
+	if tr.MaxConnsPerHost != 128 {
+		t.Errorf("MaxConnsPerHost = %d, want 128", tr.MaxConnsPerHost)
+	}
+	if tr.IdleConnTimeout != 15*time.Second {
+		t.Errorf("IdleConnTimeout = %v, want 15s", tr.IdleConnTimeout)
+	}
+	if !tr.DisableKeepAlives {
+		t.Error("expected DisableKeepAlives")
+	}
+	if tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil || len(tr.TLSNextProto) != 0 {
+		t.Error("expected HTTP/2 disabled via empty TLSNextProto")
+	}
+}
+
+func TestClientUsesTunedTransport(t *testing.T) {
+	server := createTestServer(t, nil)
+	defer server.Close()
+
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:         server.URL,
+		APIKey:          "test-api-key",
+		MaxConnsPerHost: 7,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+	for name, hc := range map[string]*http.Client{"http": client.httpClient, "stream": client.streamClient} {
+		tr, ok := hc.Transport.(*http.Transport)
+		if !ok {
+			t.Fatalf("%s client transport is %T, want *http.Transport", name, hc.Transport)
+		}
+		if tr.MaxConnsPerHost != 7 {
+			t.Errorf("%s client MaxConnsPerHost = %d, want 7", name, tr.MaxConnsPerHost)
+		}
+	}
+	if client.streamClient.Timeout != 0 {
+		t.Error("stream client must not have a request timeout")
+	}
+}
+
+// selfSignedClientCert generates a throwaway certificate for mutual TLS tests.
+func selfSignedClientCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ekodb-test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestClientMutualTLS(t *testing.T) {
+	var peerCN string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			peerCN = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+		if r.URL.Path == "/api/auth/token" {
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "test-jwt-token"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	// Without the client certificate the handshake is rejected.
+	if _, err := NewClientWithConfig(ClientConfig{
+		BaseURL:   server.URL,
+		APIKey:    "test-api-key",
+		TLSConfig: &tls.Config{RootCAs: roots},
+	}); err == nil {
+		t.Fatal("expected handshake failure without a client certificate")
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:      roots,
+		Certificates: []tls.Certificate{selfSignedClientCert(t)},
+	}
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:   server.URL,
+		APIKey:    "test-api-key",
+		Format:    JSON,
+		TLSConfig: tlsConfig,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+	if err := client.Health(); err != nil {
+		t.Fatalf("Health over mTLS failed: %v", err)
+	}
+	if peerCN != "ekodb-test-client" {
+		t.Errorf("server saw client certificate CN %q, want ekodb-test-client", peerCN)
+	}
+
+	// The caller's config is cloned, and the WebSocket dialer carries it too.
+	tlsConfig.ServerName = "mutated"
+	d := client.websocketDialer()
+	if d == websocket.DefaultDialer {
+		t.Fatal("expected a dedicated WebSocket dialer when TLSConfig is set")
+	}
+	if d.TLSClientConfig == nil || len(d.TLSClientConfig.Certificates) != 1 {
+		t.Error("WebSocket dialer is missing the client certificate")
+	}
+	if d.TLSClientConfig.ServerName == "mutated" {
+		t.Error("TLSConfig should be cloned, not aliased")
+	}
+}
@@ -0,0 +1,142 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// TrashedRecord is a deleted record waiting in the trash.
+type TrashedRecord struct {
+	Record    Record    `json:"record"`
+	DeletedAt time.Time `json:"deleted_at"`
+	// ExpiresAt is when the record is permanently deleted unless restored.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TrashedCollection summarizes the trash of one collection.
+type TrashedCollection struct {
+	Collection string    `json:"collection"`
+	Records    int       `json:"records"`
+	OldestAt   time.Time `json:"oldest_deleted_at"`
+}
+
+// RestoreConflict selects what a restore does when a live record already has
+// the trashed record's ID.
+type RestoreConflict string
+
+const (
+	// RestoreConflictFail fails the restore with a 409 conflict (the default)
+	RestoreConflictFail RestoreConflict = "fail"
+	// RestoreConflictSkip leaves the live record and the trashed one as they are
+	RestoreConflictSkip RestoreConflict = "skip"
+	// RestoreConflictOverwrite replaces the live record with the trashed one
+	RestoreConflictOverwrite RestoreConflict = "overwrite"
+	// RestoreConflictCopy restores the trashed record under a new ID
+	RestoreConflictCopy RestoreConflict = "copy"
+)
+
+// RestoreOptions contains optional parameters for RestoreRecord and
+// RestoreCollection.
+type RestoreOptions struct {
+	// OnConflict handles ID conflicts with live records; empty fails
+	OnConflict RestoreConflict
+	// TargetCollection restores into this collection instead of the one the
+	// records were deleted from
+	TargetCollection string
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
+}
+
+// restorePath adds RestoreOptions to a restore path.
+func restorePath(path string, opts []RestoreOptions) string {
+	if len(opts) == 0 {
+		return path
+	}
+	params := url.Values{}
+	if opts[0].OnConflict != "" {
+		params.Add("on_conflict", string(opts[0].OnConflict))
+	}
+	if opts[0].TargetCollection != "" {
+		params.Add("target_collection", opts[0].TargetCollection)
+	}
+	if len(params) > 0 {
+		path = fmt.Sprintf("%s?%s", path, params.Encode())
+	}
+	return path
+}
+
+// ListTrashedRecords returns the records in collection's trash that match
+// query, which takes the same forms as for Find (nil for all):
+//
+//	trashed, err := client.ListTrashedRecords("users", ekodb.NewQueryBuilder().Eq("team", "ops").Build())
+//	for _, t := range trashed {
+//	    if time.Until(t.ExpiresAt) < 24*time.Hour {
+//	        client.RestoreRecord("users", client.ExtractRecordID("users", t.Record))
+//	    }
+//	}
+func (c *Client) ListTrashedRecords(collection string, query interface{}, opts ...RequestOption) ([]TrashedRecord, error) {
+	c = c.withOptions(opts)
+	path := fmt.Sprintf("/api/trash/%s/find", url.PathEscape(collection))
+	body, err := c.queryToBodyMap(path, query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trash query: %w", err)
+	}
+	respBody, err := c.makeRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []TrashedRecord
+	if err := c.unmarshalJSON(respBody, &records); err != nil {
+		return nil, err
+	}
+	for _, t := range records {
+		c.finishRecord(t.Record)
+	}
+	return records, nil
+}
+
+// ListTrashedCollections returns the collections that have records in the
+// trash.
+func (c *Client) ListTrashedCollections(opts ...RequestOption) ([]TrashedCollection, error) {
+	c = c.withOptions(opts)
+	respBody, err := c.makeRequest("GET", "/api/trash", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Collections []TrashedCollection `json:"collections"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, err
+	}
+	return result.Collections, nil
+}
+
+// PurgeTrash permanently deletes the records in collection's trash that were
+// deleted more than olderThan ago, or all of them if olderThan is 0, and
+// returns how many were purged. Purged records cannot be restored.
+func (c *Client) PurgeTrash(collection string, olderThan time.Duration, opts ...RequestOption) (int, error) {
+	c = c.withOptions(opts)
+	path := fmt.Sprintf("/api/trash/%s", url.PathEscape(collection))
+	if olderThan > 0 {
+		params := url.Values{}
+		params.Add("older_than_secs", fmt.Sprintf("%d", int64(olderThan/time.Second)))
+		path = fmt.Sprintf("%s?%s", path, params.Encode())
+	}
+	respBody, err := c.makeRequest("DELETE", path, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		RecordsPurged int `json:"records_purged"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return 0, err
+	}
+	return result.RecordsPurged, nil
+}
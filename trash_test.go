@@ -0,0 +1,113 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestListTrashedRecords(t *testing.T) {
+	var sent map[string]interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/trash/users/find": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"record":     map[string]interface{}{"id": "u1", "team": "ops"},
+				"deleted_at": "2024-05-01T10:00:00Z",
+				"expires_at": "2024-05-31T10:00:00Z",
+			}})
+		},
+		"GET /api/trash": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"collections": []interface{}{map[string]interface{}{
+					"collection": "users", "records": 3, "oldest_deleted_at": "2024-05-01T10:00:00Z",
+				}},
+			})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	trashed, err := client.ListTrashedRecords("users", NewQueryBuilder().Eq("team", "ops").Build())
+	if err != nil {
+		t.Fatalf("ListTrashedRecords failed: %v", err)
+	}
+	if len(trashed) != 1 || trashed[0].Record["id"] != "u1" ||
+		!trashed[0].ExpiresAt.Equal(time.Date(2024, 5, 31, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected trash %+v", trashed)
+	}
+	if sent["filter"] == nil {
+		t.Error("filter not sent")
+	}
+
+	collections, err := client.ListTrashedCollections()
+	if err != nil {
+		t.Fatalf("ListTrashedCollections failed: %v", err)
+	}
+	if len(collections) != 1 || collections[0].Collection != "users" || collections[0].Records != 3 {
+		t.Errorf("unexpected collections %+v", collections)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	var query string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"DELETE /api/trash/users": func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"records_purged": 7})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	n, err := client.PurgeTrash("users", 7*24*time.Hour)
+	if err != nil || n != 7 || query != "older_than_secs=604800" {
+		t.Errorf("PurgeTrash = %d, %v (query %q)", n, err, query)
+	}
+	if _, err := client.PurgeTrash("users", 0); err != nil || query != "" {
+		t.Errorf("PurgeTrash(0) sent %q (err %v)", query, err)
+	}
+}
+
+func TestRestoreOptions(t *testing.T) {
+	var recordQuery, collectionQuery string
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/trash/users/u1": func(w http.ResponseWriter, r *http.Request) {
+			recordQuery = r.URL.RawQuery
+			if r.URL.Query().Get("on_conflict") == "" {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"error":"record u1 exists"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": "restored"})
+		},
+		"POST /api/trash/users": func(w http.ResponseWriter, r *http.Request) {
+			collectionQuery = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": "ok", "collection": "users", "records_restored": 4})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	err := client.RestoreRecord("users", "u1")
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || !httpErr.IsConflict() {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+	if err := client.RestoreRecord("users", "u1", RestoreOptions{OnConflict: RestoreConflictCopy}); err != nil {
+		t.Fatalf("RestoreRecord failed: %v", err)
+	}
+	if recordQuery != "on_conflict=copy" {
+		t.Errorf("RestoreRecord query = %q", recordQuery)
+	}
+
+	n, err := client.RestoreCollection("users", RestoreOptions{OnConflict: RestoreConflictSkip, TargetCollection: "users_recovered"})
+	if err != nil || n != 4 {
+		t.Fatalf("RestoreCollection = %d, %v", n, err)
+	}
+	if collectionQuery != "on_conflict=skip&target_collection=users_recovered" {
+		t.Errorf("RestoreCollection query = %q", collectionQuery)
+	}
+}
@@ -0,0 +1,30 @@
+package ekodb
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// truncateCollectionFeature is the ServerInfo feature flag for the truncate
+// endpoint.
+const truncateCollectionFeature = "truncate_collection"
+
+// TruncateCollection deletes every record in collection, keeping the
+// collection itself with its schema and indexes. Servers that advertise the
+// "truncate_collection" feature clear it in one request; others run a
+// single Delete stage with no filter as a temporary function, so records
+// are never fetched by the client either way.
+func (c *Client) TruncateCollection(collection string, opts ...RequestOption) error {
+	c = c.withOptions(opts)
+	if c.serverFeature(truncateCollectionFeature) {
+		path := fmt.Sprintf("/api/collections/%s/truncate", url.PathEscape(collection))
+		_, err := c.makeRequest("POST", path, nil)
+		return err
+	}
+	_, err := c.runTempFunction("truncate "+collection,
+		[]FunctionStageConfig{StageDelete(collection, nil, false)})
+	if err != nil {
+		return fmt.Errorf("truncate collection %s: %w", collection, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestTruncateCollection(t *testing.T) {
+	var saved struct {
+		Functions []map[string]interface{} `json:"functions"`
+	}
+	var truncated bool
+	features := []string{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"version": "1.0.0", "features": features})
+		},
+		"POST /api/collections/users/truncate": func(w http.ResponseWriter, r *http.Request) {
+			truncated = true
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+		},
+		"POST /api/functions": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&saved)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok", "id": "fn_1"})
+		},
+		"POST /api/functions/*": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"records": []interface{}{}, "stats": map[string]interface{}{}})
+		},
+		"DELETE /api/functions/*": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+		},
+	})
+	defer server.Close()
+
+	client := createTestClient(t, server)
+	if err := client.TruncateCollection("users"); err != nil {
+		t.Fatalf("TruncateCollection failed: %v", err)
+	}
+	if truncated {
+		t.Error("truncate endpoint used without the feature")
+	}
+	if len(saved.Functions) != 1 || saved.Functions[0]["type"] != "Delete" || saved.Functions[0]["collection"] != "users" {
+		t.Errorf("unexpected pipeline %+v", saved.Functions)
+	}
+
+	features = []string{"truncate_collection"}
+	client = createTestClient(t, server)
+	if err := client.TruncateCollection("users"); err != nil {
+		t.Fatalf("TruncateCollection failed: %v", err)
+	}
+	if !truncated {
+		t.Error("truncate endpoint not used")
+	}
+}
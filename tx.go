@@ -0,0 +1,280 @@
+package ekodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrTxDone is returned by a Tx's methods once the transaction has been
+	// committed or rolled back.
+	ErrTxDone = errors.New("ekodb: transaction has already been committed or rolled back")
+	// ErrTxExpired is wrapped by the errors of a Tx's methods when the server
+	// has discarded the transaction for being idle past its TTL. The server's
+	// *HTTPError is wrapped too.
+	ErrTxExpired = errors.New("ekodb: transaction expired")
+)
+
+// defaultTxKeepAliveInterval is the KeepAlive interval for a transaction
+// begun without a TTL.
+const defaultTxKeepAliveInterval = 10 * time.Second
+
+// TxOptions contains optional parameters for BeginTx
+type TxOptions struct {
+	// TTL is how long the server keeps the transaction while idle before
+	// discarding it; zero uses the server's default. It is sent in whole
+	// seconds, rounded up.
+	TTL time.Duration
+	// KeepAliveInterval is how often KeepAlive sends a heartbeat (default: a
+	// third of TTL, or 10s without a TTL).
+	KeepAliveInterval time.Duration
+	// RequestOptions apply to this call only, as through Client.With.
+	RequestOptions []RequestOption `json:"-"`
+}
+
+// Tx is a transaction whose methods send their requests with its ID, so the
+// writes are staged in it and the reads see them:
+//
+//	tx, err := client.BeginTx("SERIALIZABLE")
+//	if err != nil {
+//	    return err
+//	}
+//	defer tx.Rollback() // no-op after Commit
+//
+//	if _, err := tx.Insert("orders", order); err != nil {
+//	    return err
+//	}
+//	if _, err := tx.Update("stock", itemID, ekodb.Record{"reserved": true}); err != nil {
+//	    return err
+//	}
+//	return tx.Commit()
+//
+// Any TransactionId already set in the options passed to its methods is
+// replaced by the Tx's. A Tx is safe for concurrent use.
+type Tx struct {
+	client    *Client
+	id        string
+	keepAlive time.Duration
+
+	mu   sync.Mutex
+	done bool
+}
+
+// BeginTx starts a transaction with the given isolation level, as
+// BeginTransaction does, and returns a handle on it. A transaction that does
+// long work between requests should set TxOptions.TTL and run KeepAlive:
+//
+//	tx, err := client.BeginTx("READ_COMMITTED", ekodb.TxOptions{TTL: 30 * time.Second})
+//	...
+//	go tx.KeepAlive(ctx)
+func (c *Client) BeginTx(isolationLevel string, opts ...TxOptions) (*Tx, error) {
+	if len(opts) > 0 {
+		c = c.withOptions(opts[0].RequestOptions)
+	}
+	var o TxOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.TTL < 0 {
+		return nil, fmt.Errorf("BeginTx: negative TTL %v", o.TTL)
+	}
+	id, err := c.beginTransaction(isolationLevel, kvTTLSeconds(o.TTL))
+	if err != nil {
+		return nil, err
+	}
+
+	interval := o.KeepAliveInterval
+	if interval <= 0 {
+		interval = defaultTxKeepAliveInterval
+		if o.TTL > 0 {
+			interval = o.TTL / 3
+		}
+	}
+	return &Tx{client: c, id: id, keepAlive: interval}, nil
+}
+
+// ID returns the server-assigned transaction ID
+func (tx *Tx) ID() string {
+	return tx.id
+}
+
+// active returns ErrTxDone once the transaction has finished.
+func (tx *Tx) active() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.done {
+		return ErrTxDone
+	}
+	return nil
+}
+
+// Insert stages an insert in the transaction
+func (tx *Tx) Insert(collection string, record Record, opts ...InsertOptions) (Record, error) {
+	if err := tx.active(); err != nil {
+		return nil, err
+	}
+	var o InsertOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o.TransactionId = &tx.id
+	record, err := tx.client.Insert(collection, record, o)
+	return record, tx.txError(err, false)
+}
+
+// Update stages an update in the transaction
+func (tx *Tx) Update(collection, id string, record Record, opts ...UpdateOptions) (Record, error) {
+	if err := tx.active(); err != nil {
+		return nil, err
+	}
+	var o UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o.TransactionId = &tx.id
+	record, err := tx.client.Update(collection, id, record, o)
+	return record, tx.txError(err, false)
+}
+
+// Delete stages a delete in the transaction
+func (tx *Tx) Delete(collection, id string, opts ...DeleteOptions) error {
+	if err := tx.active(); err != nil {
+		return err
+	}
+	var o DeleteOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o.TransactionId = &tx.id
+	return tx.txError(tx.client.Delete(collection, id, o), false)
+}
+
+// Find runs a find that sees the transaction's staged writes
+func (tx *Tx) Find(collection string, query interface{}, opts ...FindOptions) ([]Record, error) {
+	if err := tx.active(); err != nil {
+		return nil, err
+	}
+	var o FindOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o.TransactionId = &tx.id
+	records, err := tx.client.Find(collection, query, o)
+	return records, tx.txError(err, false)
+}
+
+// FindByID gets a record as the transaction sees it
+func (tx *Tx) FindByID(collection, id string, opts ...FindByIDOptions) (Record, error) {
+	if err := tx.active(); err != nil {
+		return nil, err
+	}
+	var o FindByIDOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o.TransactionId = &tx.id
+	record, err := tx.client.FindByID(collection, id, o)
+	return record, tx.txError(err, false)
+}
+
+// Savepoint creates a named savepoint in the transaction
+func (tx *Tx) Savepoint(name string) error {
+	if err := tx.active(); err != nil {
+		return err
+	}
+	return tx.txError(tx.client.CreateSavepoint(tx.id, name), true)
+}
+
+// RollbackToSavepoint discards the writes staged after a savepoint
+func (tx *Tx) RollbackToSavepoint(name string) error {
+	if err := tx.active(); err != nil {
+		return err
+	}
+	return tx.txError(tx.client.RollbackToSavepoint(tx.id, name), false)
+}
+
+// Commit applies the transaction's staged writes; see CommitTransaction. The
+// Tx is finished afterwards even if the commit fails: after a conflict, retry
+// with a new transaction.
+func (tx *Tx) Commit() error {
+	if err := tx.finish(); err != nil {
+		return err
+	}
+	return tx.txError(tx.client.CommitTransaction(tx.id), true)
+}
+
+// Rollback discards the transaction's staged writes. It returns ErrTxDone
+// after Commit or an earlier Rollback, so it can be deferred unconditionally.
+func (tx *Tx) Rollback() error {
+	if err := tx.finish(); err != nil {
+		return err
+	}
+	return tx.txError(tx.client.RollbackTransaction(tx.id), true)
+}
+
+// finish marks the transaction finished, returning ErrTxDone if it already
+// was.
+func (tx *Tx) finish() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+	return nil
+}
+
+// KeepAlive sends a heartbeat for the transaction at its keep-alive interval
+// (see TxOptions) so the server does not discard it while idle. It blocks
+// until ctx is done or the transaction finishes, returning nil, or until a
+// heartbeat fails, returning the error (which wraps ErrTxExpired if the
+// transaction already expired). Run it in its own goroutine.
+func (tx *Tx) KeepAlive(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ticker := time.NewTicker(tx.keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if tx.active() != nil {
+			return nil
+		}
+		path := "/api/transactions/" + url.PathEscape(tx.id) + "/keepalive"
+		if _, err := tx.client.WithContext(ctx).makeRequest("POST", path, nil); err != nil {
+			if ctx.Err() != nil || tx.active() != nil {
+				return nil
+			}
+			return tx.txError(err, true)
+		}
+	}
+}
+
+// txError wraps ErrTxExpired into err when the server reports that the
+// transaction no longer exists: by 410 Gone, by a message naming an expired or
+// missing transaction, or, for requests on the transaction itself (txScoped),
+// by 404.
+func (tx *Tx) txError(err error, txScoped bool) error {
+	var httpErr *HTTPError
+	if err == nil || !errors.As(err, &httpErr) {
+		return err
+	}
+	msg := strings.ToLower(httpErr.Message)
+	expired := httpErr.StatusCode == http.StatusGone ||
+		(strings.Contains(msg, "transaction") && (strings.Contains(msg, "expired") || strings.Contains(msg, "not found"))) ||
+		(txScoped && httpErr.IsNotFound())
+	if !expired {
+		return err
+	}
+	return fmt.Errorf("transaction %s: %w: %w", tx.id, ErrTxExpired, err)
+}
@@ -0,0 +1,169 @@
+package ekodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTx(t *testing.T) {
+	var seen []string
+	record := func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Method+" "+r.URL.Path+" "+r.URL.Query().Get("transaction_id"))
+	}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/transactions": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"transaction_id": "tx1"})
+		},
+		"POST /api/insert/orders": func(w http.ResponseWriter, r *http.Request) {
+			record(w, r)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "o1"})
+		},
+		"PUT /api/update/orders/o1": func(w http.ResponseWriter, r *http.Request) {
+			record(w, r)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "o1", "status": "paid"})
+		},
+		"POST /api/find/orders": func(w http.ResponseWriter, r *http.Request) {
+			record(w, r)
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": "o1"}})
+		},
+		"GET /api/find/orders/o1": func(w http.ResponseWriter, r *http.Request) {
+			record(w, r)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "o1"})
+		},
+		"DELETE /api/delete/orders/o2": func(w http.ResponseWriter, r *http.Request) {
+			record(w, r)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "o2"})
+		},
+		"POST /api/transactions/tx1/commit": func(w http.ResponseWriter, r *http.Request) {
+			record(w, r)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "committed"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	tx, err := client.BeginTx("SERIALIZABLE")
+	if err != nil {
+		t.Fatalf("BeginTx failed: %v", err)
+	}
+	if tx.ID() != "tx1" {
+		t.Errorf("ID = %q", tx.ID())
+	}
+	other := "other"
+	if _, err := tx.Insert("orders", Record{"total": 5}, InsertOptions{TransactionId: &other}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if _, err := tx.Update("orders", "o1", Record{"status": "paid"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := tx.Find("orders", nil); err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if _, err := tx.FindByID("orders", "o1"); err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if err := tx.Delete("orders", "o2"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	want := []string{
+		"POST /api/insert/orders tx1",
+		"PUT /api/update/orders/o1 tx1",
+		"POST /api/find/orders tx1",
+		"GET /api/find/orders/o1 tx1",
+		"DELETE /api/delete/orders/o2 tx1",
+		"POST /api/transactions/tx1/commit ",
+	}
+	if len(seen) != len(want) {
+		t.Fatalf("requests %q", seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("request %d = %q, want %q", i, seen[i], want[i])
+		}
+	}
+
+	if err := tx.Rollback(); !errors.Is(err, ErrTxDone) {
+		t.Errorf("Rollback after Commit = %v, want ErrTxDone", err)
+	}
+	if _, err := tx.Insert("orders", Record{}); !errors.Is(err, ErrTxDone) {
+		t.Errorf("Insert after Commit = %v, want ErrTxDone", err)
+	}
+}
+
+func TestTxKeepAliveAndExpiry(t *testing.T) {
+	var beginBody map[string]interface{}
+	var heartbeats atomic.Int32
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/transactions": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&beginBody)
+			_ = json.NewEncoder(w).Encode(map[string]string{"transaction_id": "tx2"})
+		},
+		"POST /api/transactions/tx2/keepalive": func(w http.ResponseWriter, r *http.Request) {
+			if heartbeats.Add(1) < 3 {
+				_ = json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+		},
+		"POST /api/insert/orders": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "Transaction tx2 has expired"})
+		},
+		"GET /api/find/orders/o9": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "record not found"})
+		},
+		"POST /api/transactions/tx2/commit": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "gone"})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	tx, err := client.BeginTx("READ_COMMITTED", TxOptions{TTL: 1500 * time.Millisecond, KeepAliveInterval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("BeginTx failed: %v", err)
+	}
+	if beginBody["timeout_secs"] != float64(2) || beginBody["isolation_level"] != "ReadCommitted" {
+		t.Errorf("begin body = %v", beginBody)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = tx.KeepAlive(ctx)
+	if !errors.Is(err, ErrTxExpired) || heartbeats.Load() != 3 {
+		t.Errorf("KeepAlive = %v after %d heartbeats, want ErrTxExpired after 3", err, heartbeats.Load())
+	}
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || !httpErr.IsNotFound() {
+		t.Errorf("KeepAlive error does not wrap the HTTPError: %v", err)
+	}
+
+	if _, err := tx.Insert("orders", Record{}); !errors.Is(err, ErrTxExpired) {
+		t.Errorf("Insert = %v, want ErrTxExpired", err)
+	}
+	if _, err := tx.FindByID("orders", "o9"); err == nil || errors.Is(err, ErrTxExpired) {
+		t.Errorf("FindByID of a missing record = %v, want a plain not found", err)
+	}
+	if err := tx.Commit(); !errors.Is(err, ErrTxExpired) {
+		t.Errorf("Commit = %v, want ErrTxExpired", err)
+	}
+	if err := tx.KeepAlive(ctx); err != nil {
+		t.Errorf("KeepAlive after Commit = %v", err)
+	}
+
+	if _, err := client.BeginTx("READ_COMMITTED", TxOptions{TTL: -time.Second}); err == nil {
+		t.Error("expected an error for a negative TTL")
+	}
+}
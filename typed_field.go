@@ -0,0 +1,89 @@
+package ekodb
+
+// TypedField is a handle for a field whose values have type T, so filter
+// values are checked at compile time:
+//
+//	var (
+//	    Age    = ekodb.Field[int]("age")
+//	    Status = ekodb.Field[string]("status")
+//	)
+//
+//	qb := ekodb.NewQueryBuilder().Where(Age.Gt(18), Status.In("active", "trial"))
+//	// Age.Gt("18") does not compile
+//
+// Each method returns a filter expression, usable with Where, And, Or, and
+// Not.
+type TypedField[T any] struct {
+	name string
+}
+
+// Field returns a TypedField for the named field. Dot paths work as for the
+// QueryBuilder methods.
+func Field[T any](name string) TypedField[T] {
+	return TypedField[T]{name: name}
+}
+
+// Name returns the field name
+func (f TypedField[T]) Name() string {
+	return f.name
+}
+
+// Eq matches values equal to value
+func (f TypedField[T]) Eq(value T) map[string]interface{} {
+	return f.condition("Eq", value)
+}
+
+// Ne matches values not equal to value
+func (f TypedField[T]) Ne(value T) map[string]interface{} {
+	return f.condition("Ne", value)
+}
+
+// Gt matches values greater than value
+func (f TypedField[T]) Gt(value T) map[string]interface{} {
+	return f.condition("Gt", value)
+}
+
+// Gte matches values greater than or equal to value
+func (f TypedField[T]) Gte(value T) map[string]interface{} {
+	return f.condition("Gte", value)
+}
+
+// Lt matches values less than value
+func (f TypedField[T]) Lt(value T) map[string]interface{} {
+	return f.condition("Lt", value)
+}
+
+// Lte matches values less than or equal to value
+func (f TypedField[T]) Lte(value T) map[string]interface{} {
+	return f.condition("Lte", value)
+}
+
+// In matches any of values
+func (f TypedField[T]) In(values ...T) map[string]interface{} {
+	return f.condition("In", typedValues(values))
+}
+
+// Nin matches none of values
+func (f TypedField[T]) Nin(values ...T) map[string]interface{} {
+	return f.condition("NotIn", typedValues(values))
+}
+
+func (f TypedField[T]) condition(operator string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "Condition",
+		"content": map[string]interface{}{
+			"field":    f.name,
+			"operator": operator,
+			"value":    value,
+		},
+	}
+}
+
+// typedValues converts values to the []interface{} the In operators take.
+func typedValues[T any](values []T) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
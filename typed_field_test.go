@@ -0,0 +1,41 @@
+package ekodb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTypedField(t *testing.T) {
+	age := Field[int]("age")
+	status := Field[string]("status")
+	created := Field[time.Time]("created_at")
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := NewQueryBuilder().
+		Where(age.Gte(18), status.In("active", "trial")).
+		Where(created.Lt(since)).
+		Build()
+	want := NewQueryBuilder().
+		Gte("age", 18).
+		In("status", []interface{}{"active", "trial"}).
+		Lt("created_at", since).
+		Build()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Where(typed) = %v, want %v", got, want)
+	}
+
+	or := NewQueryBuilder().Or([]map[string]interface{}{age.Lt(13), age.Gt(65)}).Build()
+	exprs := or["filter"].(map[string]interface{})["content"].(map[string]interface{})["expressions"].([]map[string]interface{})
+	if len(exprs) != 2 || exprs[1]["content"].(map[string]interface{})["operator"] != "Gt" {
+		t.Errorf("unexpected Or expression %v", or)
+	}
+
+	nin := status.Nin("banned")["content"].(map[string]interface{})
+	if nin["operator"] != "NotIn" || !reflect.DeepEqual(nin["value"], []interface{}{"banned"}) {
+		t.Errorf("unexpected Nin condition %v", nin)
+	}
+	if age.Name() != "age" {
+		t.Errorf("Name() = %q", age.Name())
+	}
+}
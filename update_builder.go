@@ -0,0 +1,209 @@
+package ekodb
+
+import "fmt"
+
+// UpdateBuilder provides a fluent API for partial updates that change
+// individual fields instead of sending whole documents:
+//
+//	update := ekodb.NewUpdateBuilder().
+//	    Set("status", "shipped").
+//	    Inc("shipments", 1).
+//	    Push("history", "shipped").
+//	    Unset("draft")
+//	record, err := client.Patch("orders", id, update)
+//
+// Set and Unset are sent as a partial Update; the other operators are atomic
+// field actions (see UpdateWithAction) applied together in one
+// UpdateWithActionSequence request.
+type UpdateBuilder struct {
+	fields  Record
+	actions [][3]interface{}
+}
+
+// NewUpdateBuilder creates a new UpdateBuilder
+func NewUpdateBuilder() *UpdateBuilder {
+	return &UpdateBuilder{fields: Record{}}
+}
+
+// Set sets a field to value
+func (ub *UpdateBuilder) Set(field string, value interface{}) *UpdateBuilder {
+	ub.fields[field] = value
+	return ub
+}
+
+// Unset clears a field (sets it to null)
+func (ub *UpdateBuilder) Unset(field string) *UpdateBuilder {
+	ub.fields[field] = nil
+	return ub
+}
+
+// Inc atomically adds by to a numeric field (increment action)
+func (ub *UpdateBuilder) Inc(field string, by interface{}) *UpdateBuilder {
+	return ub.action("increment", field, by)
+}
+
+// Dec atomically subtracts by from a numeric field (decrement action)
+func (ub *UpdateBuilder) Dec(field string, by interface{}) *UpdateBuilder {
+	return ub.action("decrement", field, by)
+}
+
+// Mul atomically multiplies a numeric field by factor (multiply action)
+func (ub *UpdateBuilder) Mul(field string, factor interface{}) *UpdateBuilder {
+	return ub.action("multiply", field, factor)
+}
+
+// Push appends value to an array field (push action)
+func (ub *UpdateBuilder) Push(field string, value interface{}) *UpdateBuilder {
+	return ub.action("push", field, value)
+}
+
+// Pull removes value from an array field (remove action)
+func (ub *UpdateBuilder) Pull(field string, value interface{}) *UpdateBuilder {
+	return ub.action("remove", field, value)
+}
+
+// Pop removes the last element of an array field (pop action)
+func (ub *UpdateBuilder) Pop(field string) *UpdateBuilder {
+	return ub.action("pop", field, nil)
+}
+
+// Clear empties an array field (clear action)
+func (ub *UpdateBuilder) Clear(field string) *UpdateBuilder {
+	return ub.action("clear", field, nil)
+}
+
+func (ub *UpdateBuilder) action(action, field string, value interface{}) *UpdateBuilder {
+	ub.actions = append(ub.actions, [3]interface{}{action, field, value})
+	return ub
+}
+
+// Fields returns a copy of the fields changed by Set and Unset
+func (ub *UpdateBuilder) Fields() Record {
+	out := make(Record, len(ub.fields))
+	for k, v := range ub.fields {
+		out[k] = v
+	}
+	return out
+}
+
+// Actions returns the atomic field actions, in the form
+// UpdateWithActionSequence takes
+func (ub *UpdateBuilder) Actions() [][3]interface{} {
+	return append([][3]interface{}(nil), ub.actions...)
+}
+
+// Patch applies update to the record id and returns the updated record.
+//
+// When update has both Set/Unset fields and atomic actions it takes two
+// requests: the fields are written first, then the actions are applied, so
+// the actions see the new field values. The two are not atomic together; a
+// failure in the second leaves the first applied.
+func (c *Client) Patch(collection, id string, update *UpdateBuilder, opts ...RequestOption) (Record, error) {
+	c = c.withOptions(opts)
+	if update == nil || len(update.fields)+len(update.actions) == 0 {
+		return nil, fmt.Errorf("patch %s/%s: empty update", collection, id)
+	}
+	var record Record
+	if len(update.fields) > 0 {
+		var err error
+		record, err = c.Update(collection, id, update.Fields())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(update.actions) > 0 {
+		var err error
+		record, err = c.UpdateWithActionSequence(collection, id, update.Actions())
+		if err != nil {
+			if len(update.fields) > 0 {
+				return nil, fmt.Errorf("patch %s/%s: fields were updated but actions failed: %w", collection, id, err)
+			}
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+// apply computes the fields update would change in rec, evaluating the
+// atomic actions client-side. It is used where actions cannot be sent as
+// such, such as inside a transaction.
+func (ub *UpdateBuilder) apply(rec Record) (Record, error) {
+	changes := ub.Fields()
+	current := func(field string) interface{} {
+		if v, ok := changes[field]; ok {
+			return v
+		}
+		return GetValue(rec[field])
+	}
+	for _, a := range ub.actions {
+		action, field, value := a[0].(string), a[1].(string), a[2]
+		switch action {
+		case "increment", "decrement", "multiply":
+			n, err := applyArithmetic(action, current(field), value)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", action, field, err)
+			}
+			changes[field] = n
+		case "push", "remove", "pop", "clear":
+			var arr []interface{}
+			if cur := current(field); cur != nil {
+				var ok bool
+				if arr, ok = cur.([]interface{}); !ok {
+					return nil, fmt.Errorf("%s %s: field is not an array", action, field)
+				}
+			}
+			out := make([]interface{}, 0, len(arr)+1)
+			switch action {
+			case "push":
+				out = append(append(out, arr...), value)
+			case "remove":
+				for _, e := range arr {
+					if !diffValueEqual(e, value) {
+						out = append(out, e)
+					}
+				}
+			case "pop":
+				if len(arr) > 0 {
+					out = append(out, arr[:len(arr)-1]...)
+				}
+			}
+			changes[field] = out
+		default:
+			return nil, fmt.Errorf("unsupported action %q", action)
+		}
+	}
+	return changes, nil
+}
+
+// applyArithmetic evaluates a numeric action, in integers when both operands
+// are integers. A missing field counts as zero.
+func applyArithmetic(action string, cur, operand interface{}) (interface{}, error) {
+	if cur == nil {
+		cur = int64(0)
+	}
+	ai, aInt := codecInt(cur)
+	bi, bInt := codecInt(operand)
+	if aInt && bInt && diffIsNumber(cur) && diffIsNumber(operand) {
+		switch action {
+		case "increment":
+			return ai + bi, nil
+		case "decrement":
+			return ai - bi, nil
+		default:
+			return ai * bi, nil
+		}
+	}
+	a, aOK := codecFloat(cur)
+	b, bOK := codecFloat(operand)
+	if !aOK || !bOK || !diffIsNumber(cur) || !diffIsNumber(operand) {
+		return nil, fmt.Errorf("non-numeric operand")
+	}
+	switch action {
+	case "increment":
+		return a + b, nil
+	case "decrement":
+		return a - b, nil
+	default:
+		return a * b, nil
+	}
+}
@@ -0,0 +1,79 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateBuilder(t *testing.T) {
+	update := NewUpdateBuilder().
+		Set("status", "shipped").
+		Unset("draft").
+		Inc("shipments", 1).
+		Push("history", "shipped").
+		Pull("tags", "pending").
+		Clear("errors")
+
+	if fields := update.Fields(); fields["status"] != "shipped" || fields["draft"] != nil || len(fields) != 2 {
+		t.Errorf("Fields = %v", fields)
+	}
+	want := [][3]interface{}{
+		{"increment", "shipments", 1},
+		{"push", "history", "shipped"},
+		{"remove", "tags", "pending"},
+		{"clear", "errors", nil},
+	}
+	if got := update.Actions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Actions = %v", got)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	var order []string
+	var updateBody Record
+	var actions [][3]interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"PUT /api/update/orders/o1": func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "update")
+			_ = json.NewDecoder(r.Body).Decode(&updateBody)
+			_ = json.NewEncoder(w).Encode(Record{"id": "o1", "status": "shipped"})
+		},
+		"PUT /api/update/sequence/orders/o1": func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "sequence")
+			_ = json.NewDecoder(r.Body).Decode(&actions)
+			_ = json.NewEncoder(w).Encode(Record{"id": "o1", "status": "shipped", "shipments": 2})
+		},
+	})
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	rec, err := client.Patch("orders", "o1", NewUpdateBuilder().Set("status", "shipped").Unset("draft").Inc("shipments", 1))
+	if err != nil {
+		t.Fatalf("Patch failed: %v", err)
+	}
+	if !reflect.DeepEqual(order, []string{"update", "sequence"}) {
+		t.Errorf("request order = %v", order)
+	}
+	if v, present := updateBody["draft"]; !present || v != nil || updateBody["status"] != "shipped" {
+		t.Errorf("update body = %v", updateBody)
+	}
+	if len(actions) != 1 || actions[0][0] != "increment" {
+		t.Errorf("actions = %v", actions)
+	}
+	if rec.Int("shipments") != 2 {
+		t.Errorf("Patch returned %v", rec)
+	}
+
+	order = nil
+	if _, err := client.Patch("orders", "o1", NewUpdateBuilder().Push("history", "x")); err != nil {
+		t.Fatalf("Patch failed: %v", err)
+	}
+	if !reflect.DeepEqual(order, []string{"sequence"}) {
+		t.Errorf("actions-only patch sent %v", order)
+	}
+	if _, err := client.Patch("orders", "o1", NewUpdateBuilder()); err == nil {
+		t.Error("expected an error for an empty update")
+	}
+}
@@ -0,0 +1,98 @@
+package ekodb
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestUseNumber(t *testing.T) {
+	const body = `{"id": "u1", "big": {"type": "Integer", "value": 9007199254740993}, "ratio": 0.25}`
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/find/users/u1": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(body))
+		},
+		"POST /api/find/users": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("[" + body + "]"))
+		},
+		"POST /api/search/users": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"results": [{"record": ` + body + `, "score": 1}], "total": 1}`))
+		},
+	})
+	defer server.Close()
+
+	// Without UseNumber the value is rounded through float64.
+	rec, err := createTestClient(t, server).FindByID("users", "u1")
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if n, _ := GetIntValue(rec["big"]); n == 9007199254740993 {
+		t.Error("expected float64 rounding without UseNumber")
+	}
+
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:   server.URL,
+		APIKey:    "test-api-key",
+		Timeout:   5 * time.Second,
+		Format:    JSON,
+		UseNumber: true,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+	rec, err = client.FindByID("users", "u1")
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if n, ok := GetIntValue(rec["big"]); !ok || n != 9007199254740993 {
+		t.Errorf("GetIntValue = %d, %v", n, ok)
+	}
+	if got := rec.Float("ratio"); got != 0.25 {
+		t.Errorf("Float(ratio) = %v", got)
+	}
+
+	var streamed json.Number
+	err = client.FindEach("users", nil, func(r Record) error {
+		streamed, _ = GetValue(r["big"]).(json.Number)
+		return nil
+	})
+	if err != nil || streamed != "9007199254740993" {
+		t.Errorf("FindEach = %q, %v", streamed, err)
+	}
+	results, err := client.Search("users", SearchQuery{Query: "u"})
+	if err != nil || Record(results.Results[0].Record).Int("big") != 9007199254740993 {
+		t.Errorf("Search = %+v, %v", results, err)
+	}
+
+	// Records read this way encode their numbers as numbers again.
+	var buf bytes.Buffer
+	if err := msgpack.NewEncoder(&buf).Encode(rec); err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err := msgpack.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := GetIntValue(decoded["big"]); !ok || n != 9007199254740993 {
+		t.Errorf("msgpack round trip = %v", decoded["big"])
+	}
+}
+
+func TestGetValueHelpersAcceptJSONNumber(t *testing.T) {
+	if !GetBoolValue(json.Number("1")) {
+		t.Error("GetBoolValue(json.Number)")
+	}
+	if got := GetDurationValue(map[string]interface{}{"secs": json.Number("2"), "nanos": json.Number("5")}); got != 2*time.Second+5 {
+		t.Errorf("GetDurationValue = %v", got)
+	}
+	if got := GetVectorValue([]interface{}{json.Number("0.5"), json.Number("2")}); len(got) != 2 || got[0] != 0.5 {
+		t.Errorf("GetVectorValue = %v", got)
+	}
+	if got := GetBytesValue([]interface{}{json.Number("104"), json.Number("105")}); string(got) != "hi" {
+		t.Errorf("GetBytesValue = %q", got)
+	}
+}
@@ -39,11 +39,11 @@ func FieldDecimal(value string) map[string]interface{} {
 	}
 }
 
-// FieldDateTime creates a DateTime field value
+// FieldDateTime creates a DateTime field value, formatted by FormatDateTime
 func FieldDateTime(value time.Time) map[string]interface{} {
 	return map[string]interface{}{
 		"type":  "DateTime",
-		"value": value.Format(time.RFC3339),
+		"value": FormatDateTime(value),
 	}
 }
 
@@ -55,6 +55,12 @@ func FieldDateTimeString(value string) map[string]interface{} {
 	}
 }
 
+// FieldGeoPoint creates an Object field value holding a geographic point, in
+// the shape QueryBuilder.NearPoint and WithinBox filter on
+func FieldGeoPoint(lat, lon float64) map[string]interface{} {
+	return FieldObject(map[string]interface{}{"lat": lat, "lon": lon})
+}
+
 // FieldDuration creates a Duration field value
 func FieldDuration(milliseconds int64) map[string]interface{} {
 	return map[string]interface{}{
@@ -337,7 +343,7 @@ func GetFloatValue(field interface{}) float64 {
 
 // GetBoolValue extracts a bool value from an ekoDB field.
 //
-// Accepts: bool, string ("true"/"false"/"1"/"0"/"yes"/"no"), int/int64/float64 (non-zero = true).
+// Accepts: bool, string ("true"/"false"/"1"/"0"/"yes"/"no"), int/int64/float64/json.Number (non-zero = true).
 func GetBoolValue(field interface{}) bool {
 	val := GetValue(field)
 	switch v := val.(type) {
@@ -356,6 +362,9 @@ func GetBoolValue(field interface{}) bool {
 		return v != 0
 	case float64:
 		return v != 0
+	case json.Number:
+		f, err := v.Float64()
+		return err == nil && f != 0
 	}
 	return false
 }
@@ -379,15 +388,19 @@ func GetValues(record map[string]interface{}, fields []string) map[string]interf
 }
 
 // GetDateTimeValue extracts a time.Time value from an ekoDB DateTime field.
-// Supports time.Time values directly and RFC3339-formatted strings.
+// Supports time.Time values directly and strings accepted by ParseDateTime
+// (RFC3339 plus any DateTimeOptions.Layouts).
 // Returns nil if the field is not a datetime or if string parsing fails.
 func GetDateTimeValue(field interface{}) *time.Time {
 	val := GetValue(field)
 	if t, ok := val.(time.Time); ok {
+		if currentDateTimeOptions().UTC {
+			t = t.UTC()
+		}
 		return &t
 	}
 	if str, ok := val.(string); ok {
-		if t, err := time.Parse(time.RFC3339, str); err == nil {
+		if t, err := ParseDateTime(str); err == nil {
 			return &t
 		}
 	}
@@ -407,6 +420,7 @@ func GetUUIDValue(field interface{}) string {
 // Accepts underlying values of type float64, int, int64, or a string
 // containing a decimal representation. If conversion fails, it returns 0.0.
 // This function extends GetFloatValue by adding support for string parsing.
+// Use GetDecimal to read the value without losing precision.
 func GetDecimalValue(field interface{}) float64 {
 	// First try the standard float conversion
 	if result := GetFloatValue(field); result != 0.0 {
@@ -455,6 +469,15 @@ func GetDurationValue(field interface{}) time.Duration {
 		// Truncates fractional nanoseconds
 		return time.Duration(int64(f))
 	}
+	if n, ok := val.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return time.Duration(i)
+		}
+		if f, err := n.Float64(); err == nil {
+			return time.Duration(int64(f))
+		}
+		return 0
+	}
 	// Check for object with secs and nanos
 	if m, ok := val.(map[string]interface{}); ok {
 		secsVal, hasSecs := m["secs"]
@@ -464,8 +487,8 @@ func GetDurationValue(field interface{}) time.Duration {
 			// Note: Returns 0, indistinguishable from valid zero duration
 			return 0
 		}
-		secs, okSecs := secsVal.(float64)
-		nanos, okNanos := nanosVal.(float64)
+		secs, okSecs := jsonFloat(secsVal)
+		nanos, okNanos := jsonFloat(nanosVal)
 		if !okSecs || !okNanos {
 			// Incorrect field types; treat as invalid duration
 			return 0
@@ -476,6 +499,19 @@ func GetDurationValue(field interface{}) time.Duration {
 	return 0
 }
 
+// jsonFloat returns a number decoded from JSON, as a float64 or (with
+// ClientConfig.UseNumber) a json.Number.
+func jsonFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
 // GetBytesValue extracts a []byte from an ekoDB Bytes field.
 // The underlying field value may be:
 //   - []byte: returned as-is
@@ -496,7 +532,7 @@ func GetBytesValue(field interface{}) []byte {
 	if arr, ok := val.([]interface{}); ok {
 		result := make([]byte, len(arr))
 		for i, v := range arr {
-			num, ok := v.(float64)
+			num, ok := jsonFloat(v)
 			if !ok {
 				// Invalid element type; fail the conversion to avoid silent zero bytes
 				return nil
@@ -568,6 +604,12 @@ func GetVectorValue(field interface{}) []float64 {
 				result[i] = float64(num)
 			} else if num, ok := v.(int64); ok {
 				result[i] = float64(num)
+			} else if num, ok := v.(json.Number); ok {
+				f, err := num.Float64()
+				if err != nil {
+					return nil
+				}
+				result[i] = f
 			} else {
 				// Invalid element type; fail conversion to preserve vector dimensions
 				return nil
@@ -0,0 +1,235 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+var (
+	_ msgpack.CustomEncoder = Vector(nil)
+	_ msgpack.CustomDecoder = (*Vector)(nil)
+)
+
+// Vector is an embedding whose dimension is its length. In a Record it
+// encodes as a wrapped Vector field, so no FieldVector call is needed, and
+// it decodes from either a wrapped field or a plain array:
+//
+//	embedding := ekodb.Vector(values)
+//	client.Insert("docs", ekodb.Record{"embedding": embedding})
+//
+// With ClientConfig.ValidateVectors, dimensions are checked against the
+// collection's vector index before the request is sent.
+type Vector []float64
+
+// Dim returns the vector's dimension.
+func (v Vector) Dim() int { return len(v) }
+
+// Norm returns the vector's Euclidean length.
+func (v Vector) Norm() float64 {
+	var sum float64
+	for _, f := range v {
+		sum += f * f
+	}
+	return math.Sqrt(sum)
+}
+
+// Normalize returns a unit-length copy of v, or a copy of v unchanged if it
+// is all zeros.
+func (v Vector) Normalize() Vector {
+	out := make(Vector, len(v))
+	norm := v.Norm()
+	for i, f := range v {
+		if norm == 0 {
+			out[i] = f
+		} else {
+			out[i] = f / norm
+		}
+	}
+	return out
+}
+
+// Dot returns the dot product of v and o, which must have the same dimension.
+func (v Vector) Dot(o Vector) (float64, error) {
+	if len(v) != len(o) {
+		return 0, &VectorDimensionError{Expected: len(v), Actual: len(o)}
+	}
+	var sum float64
+	for i := range v {
+		sum += v[i] * o[i]
+	}
+	return sum, nil
+}
+
+// Cosine returns the cosine similarity of v and o, in [-1, 1]. A zero vector
+// has similarity 0 with everything.
+func (v Vector) Cosine(o Vector) (float64, error) {
+	dot, err := v.Dot(o)
+	if err != nil {
+		return 0, err
+	}
+	norms := v.Norm() * o.Norm()
+	if norms == 0 {
+		return 0, nil
+	}
+	return dot / norms, nil
+}
+
+// Field returns v as a Vector field value, as FieldVector does.
+func (v Vector) Field() map[string]interface{} {
+	return FieldVector(v)
+}
+
+// MarshalJSON encodes v as a wrapped Vector field.
+func (v Vector) MarshalJSON() ([]byte, error) {
+	if v == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(FieldVector(v))
+}
+
+// UnmarshalJSON accepts a wrapped Vector field or a plain array.
+func (v *Vector) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return v.set(raw)
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+func (v Vector) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if v == nil {
+		return enc.EncodeNil()
+	}
+	return encodeMsgpackValue(enc, FieldVector(v))
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+func (v *Vector) DecodeMsgpack(dec *msgpack.Decoder) error {
+	raw, err := dec.DecodeInterface()
+	if err != nil {
+		return err
+	}
+	return v.set(raw)
+}
+
+func (v *Vector) set(raw interface{}) error {
+	if raw == nil {
+		*v = nil
+		return nil
+	}
+	values, ok := vectorElements(GetValue(raw))
+	if !ok {
+		if m, isMap := asObject(raw); isMap && m["encoding"] == PackedVectorEncoding {
+			packed, _ := m["value"].(string)
+			unpacked, err := UnpackVector(packed)
+			if err != nil {
+				return err
+			}
+			*v = unpacked
+			return nil
+		}
+		return fmt.Errorf("cannot decode %T into Vector", raw)
+	}
+	*v = append(Vector(nil), values...)
+	return nil
+}
+
+// VectorDimensionError reports a vector whose dimension does not match what
+// it is compared with or the collection's vector index.
+type VectorDimensionError struct {
+	Collection string // Empty when comparing two vectors
+	Field      string
+	Expected   int
+	Actual     int
+}
+
+func (e *VectorDimensionError) Error() string {
+	if e.Collection == "" {
+		return fmt.Sprintf("vector dimension mismatch: %d != %d", e.Expected, e.Actual)
+	}
+	return fmt.Sprintf("vector field %s.%s has dimension %d, index expects %d", e.Collection, e.Field, e.Actual, e.Expected)
+}
+
+// vectorDims returns the configured dimension of each vector-indexed field
+// of collection, from its cached schema.
+func (c *Client) vectorDims(collection string) map[string]int {
+	dims := make(map[string]int)
+	schema := c.validationSchema(collection)
+	if schema == nil {
+		return dims
+	}
+	for name, field := range schema.Fields {
+		if field.Index != nil && field.Index.Type == "vector" && field.Index.Dimensions != nil {
+			dims[name] = *field.Index.Dimensions
+		}
+	}
+	return dims
+}
+
+// checkVectorDims returns a *VectorDimensionError for the first vector field
+// in records whose dimension does not match the collection's vector index.
+// It is a no-op unless ClientConfig.ValidateVectors is set.
+func (c *Client) checkVectorDims(collection string, records ...Record) error {
+	if !c.validateVectors {
+		return nil
+	}
+	dims := c.vectorDims(collection)
+	if len(dims) == 0 {
+		return nil
+	}
+	for _, rec := range records {
+		for field, want := range dims {
+			raw, ok := rec[field]
+			if !ok || raw == nil {
+				continue
+			}
+			if got, ok := vectorDimension(raw); ok && got != want {
+				return &VectorDimensionError{Collection: collection, Field: field, Expected: want, Actual: got}
+			}
+		}
+	}
+	return nil
+}
+
+// checkSearchVectorDims validates a search's query vector against the index
+// on its VectorField, or on the collection's only vector index when no field
+// is named.
+func (c *Client) checkSearchVectorDims(collection string, q SearchQuery) error {
+	if !c.validateVectors || len(q.Vector) == 0 {
+		return nil
+	}
+	dims := c.vectorDims(collection)
+	field := ""
+	if q.VectorField != nil {
+		field = *q.VectorField
+	} else if len(dims) == 1 {
+		for name := range dims {
+			field = name
+		}
+	}
+	if want, ok := dims[field]; ok && want != len(q.Vector) {
+		return &VectorDimensionError{Collection: collection, Field: field, Expected: want, Actual: len(q.Vector)}
+	}
+	return nil
+}
+
+// vectorDimension returns the dimension of a vector field value in any of
+// the forms the client sends.
+func vectorDimension(raw interface{}) (int, bool) {
+	switch v := raw.(type) {
+	case Vector:
+		return len(v), true
+	case Float32Vector:
+		return len(v), true
+	}
+	m, ok := asObject(raw)
+	if !ok || m["type"] != "Vector" {
+		return 0, false
+	}
+	values, ok := vectorElements(m["value"])
+	return len(values), ok
+}
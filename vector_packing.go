@@ -0,0 +1,281 @@
+package ekodb
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// PackedVectorEncoding names the compact Vector wire format: the elements as
+// little-endian float32s, base64-encoded. A 1536-dimension embedding packs to
+// about 8 KB instead of the ~30 KB its JSON float64 array takes.
+const PackedVectorEncoding = "f32-base64"
+
+// VectorEncodingHeader announces that the client sends and accepts packed
+// vectors. It is only sent once the server has advertised support.
+const VectorEncodingHeader = "X-EkoDB-Vector-Encoding"
+
+// packedVectorsFeature is the ServerInfo feature flag for packed vectors.
+const packedVectorsFeature = "packed_vectors"
+
+// PackVector encodes values in the PackedVectorEncoding format. Elements are
+// narrowed to float32.
+func PackVector(values []float64) string {
+	buf := make([]byte, 4*len(values))
+	for i, f := range values {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(float32(f)))
+	}
+	return base64.StdEncoding.EncodeToString(buf)
+}
+
+// UnpackVector decodes a vector packed by PackVector or by the server.
+func UnpackVector(packed string) ([]float64, error) {
+	buf, err := base64.StdEncoding.DecodeString(packed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid packed vector: %w", err)
+	}
+	if len(buf)%4 != 0 {
+		return nil, fmt.Errorf("invalid packed vector: %d bytes is not a whole number of float32s", len(buf))
+	}
+	out := make([]float64, len(buf)/4)
+	for i := range out {
+		out[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:])))
+	}
+	return out, nil
+}
+
+// negotiateVectorPacking enables packed vectors if the server advertises
+// them. Failure to ask leaves the client on plain arrays.
+func (c *Client) negotiateVectorPacking() {
+	info, err := c.ServerInfo()
+	if err != nil {
+		c.logf("Vector compression disabled: could not query server features: %v", err)
+		return
+	}
+	if !info.HasFeature(packedVectorsFeature) {
+		c.logf("Vector compression disabled: server does not support %q", packedVectorsFeature)
+		return
+	}
+	c.packVectors = true
+}
+
+// vectorElements returns the elements of a Vector field value, if it is an
+// array of numbers.
+func vectorElements(value interface{}) ([]float64, bool) {
+	switch v := value.(type) {
+	case []float64:
+		return v, true
+	case Vector:
+		return v, true
+	case []float32:
+		return float32sToFloat64s(v), true
+	case Float32Vector:
+		return float32sToFloat64s(v), true
+	case []interface{}:
+		out := make([]float64, len(v))
+		for i, e := range v {
+			switch n := e.(type) {
+			case float64:
+				out[i] = n
+			case float32:
+				out[i] = float64(n)
+			case int:
+				out[i] = float64(n)
+			case int64:
+				out[i] = float64(n)
+			case json.Number:
+				f, err := n.Float64()
+				if err != nil {
+					return nil, false
+				}
+				out[i] = f
+			default:
+				return nil, false
+			}
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+func float32sToFloat64s(v []float32) []float64 {
+	out := make([]float64, len(v))
+	for i, f := range v {
+		out[i] = float64(f)
+	}
+	return out
+}
+
+// packVectorValue returns v with every Vector field object replaced by its packed
+// form. Maps and slices along the way are copied rather than modified, so the
+// caller's records are left as they were; the second result reports whether
+// anything was packed.
+func packVectorValue(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case Vector:
+		return packVectorMap(FieldVector(v))
+	case Record:
+		m, changed := packVectorMap(v)
+		return Record(m), changed
+	case map[string]interface{}:
+		return packVectorMap(v)
+	case []Record:
+		var out []Record
+		for i, rec := range v {
+			packed, changed := packVectorMap(rec)
+			if !changed {
+				continue
+			}
+			if out == nil {
+				out = append([]Record(nil), v...)
+			}
+			out[i] = packed
+		}
+		if out == nil {
+			return v, false
+		}
+		return out, true
+	case []interface{}:
+		var out []interface{}
+		for i, e := range v {
+			packed, changed := packVectorValue(e)
+			if !changed {
+				continue
+			}
+			if out == nil {
+				out = append([]interface{}(nil), v...)
+			}
+			out[i] = packed
+		}
+		if out == nil {
+			return v, false
+		}
+		return out, true
+	}
+	return v, false
+}
+
+func packVectorMap(m map[string]interface{}) (map[string]interface{}, bool) {
+	if m["type"] == "Vector" && m["encoding"] == nil {
+		if values, ok := vectorElements(m["value"]); ok {
+			return map[string]interface{}{
+				"type":     "Vector",
+				"encoding": PackedVectorEncoding,
+				"value":    PackVector(values),
+			}, true
+		}
+	}
+	var out map[string]interface{}
+	for key, value := range m {
+		packed, changed := packVectorValue(value)
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]interface{}, len(m))
+			for k, v := range m {
+				out[k] = v
+			}
+		}
+		out[key] = packed
+	}
+	if out == nil {
+		return m, false
+	}
+	return out, true
+}
+
+// packedSearchQuery sends a SearchQuery's vector packed. The outer Vector
+// field shadows the embedded one in the JSON encoding.
+type packedSearchQuery struct {
+	SearchQuery
+	Vector         string `json:"vector"`
+	VectorEncoding string `json:"vector_encoding"`
+}
+
+// packRequestVectors rewrites a request body so its vectors travel packed:
+// Vector fields in records, batches, and function parameters, and the query
+// vector of a search.
+func packRequestVectors(data interface{}) interface{} {
+	switch d := data.(type) {
+	case SearchQuery:
+		if len(d.Vector) == 0 {
+			return d
+		}
+		return packedSearchQuery{SearchQuery: d, Vector: PackVector(d.Vector), VectorEncoding: PackedVectorEncoding}
+	case *SearchQuery:
+		if d == nil {
+			return d
+		}
+		return packRequestVectors(*d)
+	case batchInsertQuery:
+		items := make([]batchInsertItem, len(d.Inserts))
+		for i, item := range d.Inserts {
+			packed, _ := packVectorMap(item.Data)
+			items[i] = item
+			items[i].Data = packed
+		}
+		d.Inserts = items
+		return d
+	case batchUpdateQuery:
+		items := make([]batchUpdateItem, len(d.Updates))
+		for i, item := range d.Updates {
+			packed, _ := packVectorMap(item.Data)
+			items[i] = item
+			items[i].Data = packed
+		}
+		d.Updates = items
+		return d
+	}
+	packed, _ := packVectorValue(data)
+	return packed
+}
+
+// unpackVectorsInPlace replaces packed Vector field objects in a returned
+// record with plain ones, {"type": "Vector", "value": [...]}, so GetValue
+// and GetVectorValue see the usual array. Malformed packed values are left
+// as they are.
+func unpackVectorsInPlace(v interface{}) {
+	switch v := v.(type) {
+	case Record:
+		unpackVectorsInPlace(map[string]interface{}(v))
+	case map[string]interface{}:
+		for key, value := range v {
+			if unpacked, ok := unpackVectorObject(value); ok {
+				v[key] = unpacked
+				continue
+			}
+			unpackVectorsInPlace(value)
+		}
+	case []interface{}:
+		for i, e := range v {
+			if unpacked, ok := unpackVectorObject(e); ok {
+				v[i] = unpacked
+				continue
+			}
+			unpackVectorsInPlace(e)
+		}
+	}
+}
+
+func unpackVectorObject(v interface{}) (map[string]interface{}, bool) {
+	m, ok := asObject(v)
+	if !ok || m["type"] != "Vector" || m["encoding"] != PackedVectorEncoding {
+		return nil, false
+	}
+	packed, ok := m["value"].(string)
+	if !ok {
+		return nil, false
+	}
+	values, err := UnpackVector(packed)
+	if err != nil {
+		return nil, false
+	}
+	elems := make([]interface{}, len(values))
+	for i, f := range values {
+		elems[i] = f
+	}
+	return map[string]interface{}{"type": "Vector", "value": elems}, true
+}
@@ -0,0 +1,143 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newPackingClient(t *testing.T, server *httptest.Server) *Client {
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:         server.URL,
+		APIKey:          "test-api-key",
+		Timeout:         5 * time.Second,
+		Format:          JSON,
+		CompressVectors: true,
+		Logger:          log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test client: %v", err)
+	}
+	return client
+}
+
+func TestPackVectorRoundTrip(t *testing.T) {
+	in := []float64{0, 1.5, -2.25, 1e-3}
+	out, err := UnpackVector(PackVector(in))
+	if err != nil {
+		t.Fatalf("UnpackVector failed: %v", err)
+	}
+	for i := range in {
+		if float32(out[i]) != float32(in[i]) {
+			t.Errorf("element %d = %v, want %v", i, out[i], in[i])
+		}
+	}
+	if _, err := UnpackVector("AAA="); err == nil {
+		t.Error("expected an error for a truncated vector")
+	}
+}
+
+func TestCompressVectors(t *testing.T) {
+	var inserted, searched map[string]interface{}
+	var header string
+	packed := PackVector([]float64{0.5, 0.25})
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(ServerInfo{Version: "1.0", Features: []string{"packed_vectors"}})
+		},
+		"POST /api/insert/docs": func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Get(VectorEncodingHeader)
+			_ = json.NewDecoder(r.Body).Decode(&inserted)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":        "d1",
+				"embedding": map[string]interface{}{"type": "Vector", "encoding": PackedVectorEncoding, "value": packed},
+			})
+		},
+		"POST /api/search/docs": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&searched)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": []interface{}{}, "total": 0})
+		},
+	})
+	defer server.Close()
+	client := newPackingClient(t, server)
+
+	embedding := FieldVector([]float64{0.5, 0.25})
+	rec, err := client.Insert("docs", Record{"embedding": embedding})
+	if err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if header != PackedVectorEncoding {
+		t.Errorf("%s = %q", VectorEncodingHeader, header)
+	}
+	sent, _ := inserted["embedding"].(map[string]interface{})
+	if sent["encoding"] != PackedVectorEncoding || sent["value"] != packed {
+		t.Errorf("vector not packed on the wire: %v", inserted["embedding"])
+	}
+	if _, ok := embedding["value"].([]float64); !ok {
+		t.Errorf("caller's record was modified: %v", embedding)
+	}
+	if got := GetVectorValue(rec["embedding"]); len(got) != 2 || got[0] != 0.5 || got[1] != 0.25 {
+		t.Errorf("returned vector not unpacked: %v", rec["embedding"])
+	}
+
+	k := 3
+	if _, err := client.Search("docs", SearchQuery{Vector: []float64{0.5, 0.25}, VectorK: &k}); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if searched["vector"] != packed || searched["vector_encoding"] != PackedVectorEncoding || searched["vector_k"] != float64(3) {
+		t.Errorf("search vector not packed: %v", searched)
+	}
+}
+
+func TestCompressVectorsUnsupported(t *testing.T) {
+	var inserted map[string]interface{}
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/info": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(ServerInfo{Version: "1.0"})
+		},
+		"POST /api/insert/docs": func(w http.ResponseWriter, r *http.Request) {
+			if h := r.Header.Get(VectorEncodingHeader); h != "" {
+				t.Errorf("unexpected %s: %q", VectorEncodingHeader, h)
+			}
+			_ = json.NewDecoder(r.Body).Decode(&inserted)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "d1"})
+		},
+	})
+	defer server.Close()
+	client := newPackingClient(t, server)
+
+	if _, err := client.Insert("docs", Record{"embedding": FieldVector([]float64{1, 2})}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	sent, _ := inserted["embedding"].(map[string]interface{})
+	if _, ok := sent["value"].([]interface{}); !ok || sent["encoding"] != nil {
+		t.Errorf("expected a plain vector, got %v", inserted["embedding"])
+	}
+}
+
+func TestPackRequestVectorsNested(t *testing.T) {
+	params := map[string]interface{}{
+		"docs": []interface{}{
+			map[string]interface{}{"v": FieldVectorFloat32([]float32{1, 2})},
+			"untouched",
+		},
+	}
+	out := packRequestVectors(params).(map[string]interface{})
+	doc := out["docs"].([]interface{})[0].(map[string]interface{})
+	if v := doc["v"].(map[string]interface{}); v["encoding"] != PackedVectorEncoding {
+		t.Errorf("nested vector not packed: %v", v)
+	}
+	orig := params["docs"].([]interface{})[0].(map[string]interface{})["v"].(map[string]interface{})
+	if orig["encoding"] != nil {
+		t.Errorf("caller's params were modified: %v", orig)
+	}
+
+	batch := packRequestVectors(batchInsertQuery{Inserts: []batchInsertItem{{Data: Record{"v": FieldVector([]float64{1})}}}}).(batchInsertQuery)
+	if v := batch.Inserts[0].Data["v"].(map[string]interface{}); v["encoding"] != PackedVectorEncoding {
+		t.Errorf("batch vector not packed: %v", v)
+	}
+}
@@ -0,0 +1,116 @@
+package ekodb
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestVectorMath(t *testing.T) {
+	v := Vector{3, 4}
+	if v.Dim() != 2 || v.Norm() != 5 {
+		t.Errorf("Dim/Norm = %d, %v", v.Dim(), v.Norm())
+	}
+	if n := v.Normalize(); math.Abs(n.Norm()-1) > 1e-12 || v[0] != 3 {
+		t.Errorf("Normalize = %v (original %v)", n, v)
+	}
+	if cos, err := v.Cosine(Vector{6, 8}); err != nil || math.Abs(cos-1) > 1e-12 {
+		t.Errorf("Cosine = %v, %v", cos, err)
+	}
+	if cos, _ := v.Cosine(Vector{0, 0}); cos != 0 {
+		t.Errorf("Cosine with zero vector = %v", cos)
+	}
+	var dimErr *VectorDimensionError
+	if _, err := v.Dot(Vector{1}); !errors.As(err, &dimErr) || dimErr.Expected != 2 || dimErr.Actual != 1 {
+		t.Errorf("expected a dimension error, got %v", err)
+	}
+}
+
+func TestVectorEncoding(t *testing.T) {
+	b, err := json.Marshal(Record{"embedding": Vector{0.5, 1}})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(b) != `{"embedding":{"type":"Vector","value":[0.5,1]}}` {
+		t.Errorf("encoded = %s", b)
+	}
+
+	var v Vector
+	if err := json.Unmarshal([]byte(`{"type":"Vector","value":[1,2,3]}`), &v); err != nil || v.Dim() != 3 {
+		t.Errorf("wrapped decode = %v, %v", v, err)
+	}
+	if err := json.Unmarshal([]byte(`[4,5]`), &v); err != nil || v.Dim() != 2 || v[1] != 5 {
+		t.Errorf("plain decode = %v, %v", v, err)
+	}
+
+	packed, err := msgpack.Marshal(Vector{1, 2})
+	if err != nil {
+		t.Fatalf("msgpack.Marshal failed: %v", err)
+	}
+	if err := msgpack.Unmarshal(packed, &v); err != nil || v.Dim() != 2 || v[0] != 1 {
+		t.Errorf("msgpack round trip = %v, %v", v, err)
+	}
+}
+
+func TestValidateVectors(t *testing.T) {
+	schemaFetches, inserts := 0, 0
+	server := createTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/collections/docs": func(w http.ResponseWriter, r *http.Request) {
+			schemaFetches++
+			schema := NewSchemaBuilder().
+				AddField("embedding", NewFieldTypeSchemaBuilder("Vector").Dimensions(3).Build()).
+				Build()
+			_ = json.NewEncoder(w).Encode(CollectionMetadata{Collection: schema})
+		},
+		"POST /api/insert/docs": func(w http.ResponseWriter, r *http.Request) {
+			inserts++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "d1"})
+		},
+		"POST /api/search/docs": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": []interface{}{}, "total": 0})
+		},
+	})
+	defer server.Close()
+	client, err := NewClientWithConfig(ClientConfig{
+		BaseURL:         server.URL,
+		APIKey:          "test-api-key",
+		Timeout:         5 * time.Second,
+		Format:          JSON,
+		ValidateVectors: true,
+	})
+	if err != nil {
+		t.Fatalf("NewClientWithConfig failed: %v", err)
+	}
+
+	if _, err := client.Insert("docs", Record{"embedding": Vector{1, 2, 3}}); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	_, err = client.Insert("docs", Record{"embedding": FieldVector([]float64{1, 2})})
+	var dimErr *VectorDimensionError
+	if !errors.As(err, &dimErr) || dimErr.Field != "embedding" || dimErr.Expected != 3 || dimErr.Actual != 2 {
+		t.Errorf("expected a dimension error, got %v", err)
+	}
+	if _, err := client.BatchInsert("docs", []Record{{"embedding": Vector{1, 2, 3}}, {"embedding": Vector{1}}}); !errors.As(err, &dimErr) {
+		t.Errorf("expected a batch dimension error, got %v", err)
+	}
+	if _, err := client.Search("docs", SearchQuery{Vector: Vector{1, 2}}); !errors.As(err, &dimErr) {
+		t.Errorf("expected a search dimension error, got %v", err)
+	}
+	if _, err := client.Search("docs", SearchQuery{Vector: Vector{1, 2, 3}}); err != nil {
+		t.Errorf("Search failed: %v", err)
+	}
+	if inserts != 1 || schemaFetches != 1 {
+		t.Errorf("inserts = %d, schema fetches = %d", inserts, schemaFetches)
+	}
+
+	client.InvalidateValidationSchema("docs")
+	_, _ = client.Insert("docs", Record{"embedding": Vector{1, 2, 3}})
+	if schemaFetches != 2 {
+		t.Errorf("schema fetches after invalidation = %d", schemaFetches)
+	}
+}
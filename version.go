@@ -0,0 +1,7 @@
+package ekodb
+
+// Version is the version of this client library.
+const Version = "0.23.0"
+
+// UserAgent is the User-Agent header sent with every request.
+const UserAgent = "ekodb-client-go/" + Version
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand/v2"
+	"net/http"
 	"net/url"
 	"strings"
 	"sync"
@@ -67,6 +68,14 @@ type WebSocketClient struct {
 	wsURL string
 	conn  *websocket.Conn
 
+	// dialer is used for every (re)connect. Nil falls back to
+	// websocket.DefaultDialer (e.g. a manually constructed client).
+	dialer *websocket.Dialer
+
+	// headers are extra handshake headers (User-Agent and
+	// ClientConfig.DefaultHeaders) sent on every (re)connect.
+	headers http.Header
+
 	// tokenProvider returns a fresh auth token on every (re)connect. It is
 	// read on each dial so a since-expired JWT can be refreshed transparently.
 	tokenProvider func() string
@@ -77,7 +86,10 @@ type WebSocketClient struct {
 	subscriptions   map[string]chan MutationNotification
 	// subParams records the parameters used for each active subscription so
 	// the subscribe request can be replayed after an automatic reconnect.
-	subParams      map[string]SubscribeOptions
+	subParams map[string]SubscribeOptions
+	// kvWatches holds the channel for each active KVWatch, keyed by the
+	// watched key or prefix; they are replayed on reconnect like subscriptions.
+	kvWatches      map[string]chan KVChangeEvent
 	chatStreams    map[string]chan ChatStreamEvent
 	dispatcherDone chan struct{}
 	ctx            context.Context
@@ -113,10 +125,13 @@ func (c *Client) WebSocket(wsURL string) (*WebSocketClient, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	ws := &WebSocketClient{
 		wsURL:           wsURL,
+		dialer:          c.websocketDialer(),
+		headers:         c.websocketHeaders(),
 		tokenProvider:   c.getToken,
 		pendingRequests: make(map[string]chan wsResponse),
 		subscriptions:   make(map[string]chan MutationNotification),
 		subParams:       make(map[string]SubscribeOptions),
+		kvWatches:       make(map[string]chan KVChangeEvent),
 		chatStreams:     make(map[string]chan ChatStreamEvent),
 		ctx:             ctx,
 		cancel:          cancel,
@@ -212,8 +227,11 @@ func (ws *WebSocketClient) connect() error {
 	q.Set("token", token)
 	u.RawQuery = q.Encode()
 
-	header := make(map[string][]string)
-	header["Authorization"] = []string{"Bearer " + token}
+	header := ws.headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Authorization", "Bearer "+token)
 
 	// DialContext(ws.ctx) so Close()/cancel() can abort an in-flight dial
 	// (DefaultDialer.HandshakeTimeout still bounds a hung handshake). This keeps
@@ -234,7 +252,11 @@ func (ws *WebSocketClient) connect() error {
 		dialCtx, ws.cancel = context.WithCancel(dialCtx)
 		ws.ctx = dialCtx
 	}
-	conn, _, err := websocket.DefaultDialer.DialContext(dialCtx, u.String(), header)
+	dialer := ws.dialer
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+	conn, _, err := dialer.DialContext(dialCtx, u.String(), header)
 	if err != nil {
 		return fmt.Errorf("websocket connection failed: %w", err)
 	}
@@ -320,7 +342,7 @@ func (ws *WebSocketClient) reconnect() {
 
 		ws.mu.Lock()
 		closing = ws.closing
-		hasSubs := len(ws.subscriptions) > 0
+		hasSubs := ws.hasSubscriptionsLocked()
 		ws.mu.Unlock()
 		// Bail if closed, or if every subscription was removed while we were
 		// backing off (e.g. an in-flight Subscribe failed and deleted its sub
@@ -348,7 +370,7 @@ func (ws *WebSocketClient) reconnect() {
 		// leak a zombie connection + readLoop.
 		ws.mu.Lock()
 		shuttingDown := ws.closing
-		stillHasSubs := len(ws.subscriptions) > 0
+		stillHasSubs := ws.hasSubscriptionsLocked()
 		ws.mu.Unlock()
 		if shuttingDown || !stillHasSubs {
 			ws.writeMu.Lock()
@@ -404,6 +426,21 @@ func (ws *WebSocketClient) resubscribeAll() {
 	}
 	ws.mu.Unlock()
 
+	ws.mu.Lock()
+	patterns := make([]string, 0, len(ws.kvWatches))
+	for pattern := range ws.kvWatches {
+		patterns = append(patterns, pattern)
+	}
+	ws.mu.Unlock()
+
+	for _, pattern := range patterns {
+		_ = ws.writeJSON(map[string]interface{}{
+			"type":      "KVSubscribe",
+			"messageId": ws.genMessageID(),
+			"payload":   map[string]interface{}{"key": pattern},
+		})
+	}
+
 	for collection, opts := range params {
 		payload := map[string]interface{}{
 			"collection": collection,
@@ -426,6 +463,12 @@ func (ws *WebSocketClient) resubscribeAll() {
 	}
 }
 
+// hasSubscriptionsLocked reports whether anything needs replaying after a
+// reconnect. Callers must hold ws.mu.
+func (ws *WebSocketClient) hasSubscriptionsLocked() bool {
+	return len(ws.subscriptions) > 0 || len(ws.kvWatches) > 0
+}
+
 func (ws *WebSocketClient) genMessageID() string {
 	counter := ws.messageCounter.Add(1)
 	return fmt.Sprintf("%d-%d", time.Now().UnixNano(), counter)
@@ -567,10 +610,11 @@ func (ws *WebSocketClient) readLoop(conn *websocket.Conn) {
 			// transient drop. With nothing to replay (a one-shot request or a
 			// finished chat stream), an unexpected drop is terminal — tear down
 			// instead of spinning a background reconnect loop.
-			reconnect := !closing && len(ws.subscriptions) > 0
+			reconnect := !closing && ws.hasSubscriptionsLocked()
 			// Collect subscription channels to close unless we're reconnecting
 			// (in which case they stay open and get re-subscribed).
 			var subChans map[string]chan MutationNotification
+			var kvChans map[string]chan KVChangeEvent
 			if reconnect {
 				ws.reconnecting = true
 			} else {
@@ -579,6 +623,11 @@ func (ws *WebSocketClient) readLoop(conn *websocket.Conn) {
 					subChans[id] = ch
 					delete(ws.subscriptions, id)
 				}
+				kvChans = make(map[string]chan KVChangeEvent)
+				for pattern, ch := range ws.kvWatches {
+					kvChans[pattern] = ch
+					delete(ws.kvWatches, pattern)
+				}
 			}
 			ws.mu.Unlock()
 
@@ -599,6 +648,9 @@ func (ws *WebSocketClient) readLoop(conn *websocket.Conn) {
 			for _, ch := range subChans {
 				close(ch)
 			}
+			for _, ch := range kvChans {
+				close(ch)
+			}
 
 			// Mark connection as closed so subsequent writes fail fast
 			ws.writeMu.Lock()
@@ -673,6 +725,9 @@ func (ws *WebSocketClient) routeMessage(msgType string, msg map[string]json.RawM
 
 	case "SchemaChanged":
 		ws.routeSchemaChanged(msg)
+
+	case "KVChange":
+		ws.routeKVChange(msg)
 	}
 }
 
@@ -1576,6 +1631,10 @@ func (ws *WebSocketClient) Close() error {
 		delete(ws.subParams, collection)
 		close(ch)
 	}
+	for pattern, ch := range ws.kvWatches {
+		delete(ws.kvWatches, pattern)
+		close(ch)
+	}
 	ws.mu.Unlock()
 
 	// Cancel context first so the reconnect loop's sleep/dial wakes and exits.